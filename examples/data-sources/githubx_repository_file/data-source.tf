@@ -74,3 +74,15 @@ data "githubx_repository_file" "example_default_branch" {
 output "file_from_default_branch" {
   value = data.githubx_repository_file.example_default_branch.content
 }
+
+# Example 4: Reading a file with normalized line endings
+data "githubx_repository_file" "example_normalized" {
+  full_name               = "cloudbuildlab/.github"
+  file                    = "README.md"
+  normalize_line_endings  = true
+  ensure_trailing_newline = true
+}
+
+output "file_normalized_content" {
+  value = data.githubx_repository_file.example_normalized.content
+}
@@ -172,3 +172,42 @@ output "complete_repository_full_name" {
 output "complete_repository_default_branch" {
   value = githubx_repository.complete.default_branch
 }
+
+# # Example 10: Transfer a repository to another owner
+# # NOTE: Changing `owner` transfers the repository in place, keeping its stars, issues and history.
+# # Changing `name` at the same time renames the repository as part of the transfer.
+# resource "githubx_repository" "transferred" {
+#   name        = "my-transferred-repo"
+#   owner       = "my-other-org"
+#   team_ids    = [1234567]
+#   description = "Repository transferred to another organization"
+#   visibility  = "private"
+# }
+
+# output "transferred_repository_full_name" {
+#   value = githubx_repository.transferred.full_name
+# }
+
+# # Example 11: Repository protected from accidental deletion
+# # NOTE: While deletion_protection = true, any plan that destroys or replaces the repository fails.
+# # Set deletion_protection = false and apply before destroying. The backup is written just before deletion.
+# resource "githubx_repository" "protected" {
+#   name                   = "my-protected-repo"
+#   description            = "Repository protected from accidental deletion"
+#   visibility             = "private"
+#   deletion_protection    = true
+#   backup_on_destroy_path = "${path.module}/backups"
+# }
+
+# Example 12: Migrate the default branch from master to main
+# NOTE: With rename_default_branch = true, the old default branch is renamed so GitHub
+# redirects links to it and retargets open pull requests. Without it, main is created
+# from the head of the current default branch and the old branch is kept.
+resource "githubx_repository" "migrated" {
+  name                  = "my-migrated-repo"
+  description           = "Repository with its default branch renamed to main"
+  visibility            = "private"
+  auto_init             = true
+  default_branch        = "main"
+  rename_default_branch = true
+}
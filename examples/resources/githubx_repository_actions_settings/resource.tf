@@ -0,0 +1,56 @@
+terraform {
+  required_providers {
+    githubx = {
+      source  = "tfstack/githubx"
+      version = "~> 0.1"
+    }
+  }
+}
+
+# Configure the provider
+# The owner can be set via provider config, environment variable GITHUB_OWNER, or will default to authenticated user
+provider "githubx" {
+  # owner = "cloudbuildlab" # Optional: set your GitHub username or organization. If not set, will use authenticated user.
+  # Token can be provided here or via GITHUB_TOKEN environment variable
+  # token = "your-github-token-here"
+}
+
+# First, create a repository
+resource "githubx_repository" "example" {
+  name        = "my-actions-settings-example-repo"
+  description = "Repository for Actions settings examples"
+  visibility  = "public"
+}
+
+# Example 1: Restrict Actions to GitHub-owned actions and a few trusted patterns
+resource "githubx_repository_actions_settings" "example" {
+  repository = githubx_repository.example.name
+
+  allowed_actions      = "selected"
+  github_owned_allowed = true
+  verified_allowed     = false
+  patterns_allowed = [
+    "hashicorp/setup-terraform@*",
+    "docker/*",
+  ]
+
+  # Give workflows a read-only GITHUB_TOKEN and stop them from approving pull requests
+  default_workflow_permissions     = "read"
+  can_approve_pull_request_reviews = false
+
+  # Require approval for workflows from all outside contributors
+  fork_pr_approval_policy = "all_external_contributors"
+
+  # Keep artifacts and logs for 30 days
+  retention_days = 30
+}
+
+output "allowed_actions" {
+  value = githubx_repository_actions_settings.example.allowed_actions
+}
+
+# Example 2: Disable GitHub Actions entirely
+# resource "githubx_repository_actions_settings" "disabled" {
+#   repository = "my-other-repo"
+#   enabled    = false
+# }
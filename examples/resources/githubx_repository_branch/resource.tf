@@ -23,7 +23,7 @@ resource "githubx_repository" "example" {
   auto_init   = true # Initialize with README to create default branch
 }
 
-# Example 1: Create a branch from default source (main)
+# Example 1: Create a branch from the repository's default branch
 resource "githubx_repository_branch" "develop" {
   repository = githubx_repository.example.name
   branch     = "develop"
@@ -62,3 +62,34 @@ resource "githubx_repository_branch" "hotfix" {
 output "hotfix_branch_sha" {
   value = githubx_repository_branch.hotfix.sha
 }
+
+# Example 4: Keep a staging branch in sync with main
+# Plans show a change to sha when main has new commits, and apply fast-forwards staging
+resource "githubx_repository_branch" "staging" {
+  repository    = githubx_repository.example.name
+  branch        = "staging"
+  source_branch = "main"
+  track_source  = true
+  depends_on    = [githubx_repository.example]
+}
+
+# Example 5: Reset a branch to main even if it has commits of its own
+resource "githubx_repository_branch" "preview" {
+  repository    = githubx_repository.example.name
+  branch        = "preview"
+  source_branch = "main"
+  track_source  = true
+  force         = true
+  depends_on    = [githubx_repository.example]
+}
+
+# Example 6: Pin a deploy branch to a release commit
+# Changing source_sha force pushes the branch instead of recreating it, so open
+# pull requests and branch protection stay attached
+resource "githubx_repository_branch" "deploy" {
+  repository         = githubx_repository.example.name
+  branch             = "deploy/production"
+  allow_force_update = true
+  # source_sha = "abc123def456..." # Uncomment and replace with the commit to deploy
+  depends_on = [githubx_repository.example]
+}
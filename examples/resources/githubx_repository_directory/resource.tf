@@ -0,0 +1,56 @@
+terraform {
+  required_providers {
+    githubx = {
+      source  = "tfstack/githubx"
+      version = "~> 0.1"
+    }
+  }
+}
+
+# Configure the provider
+# The owner can be set via provider config, environment variable GITHUB_OWNER, or will default to authenticated user
+provider "githubx" {
+  # owner = "cloudbuildlab" # Optional: set your GitHub username or organization. If not set, will use authenticated user.
+  # Token can be provided here or via GITHUB_TOKEN environment variable
+  # token = "your-github-token-here"
+}
+
+# First, create a repository
+resource "githubx_repository" "example" {
+  name        = "my-directory-example-repo"
+  description = "Repository for directory sync examples"
+  visibility  = "public"
+  auto_init   = true # Initialize with README to create default branch
+}
+
+# Example 1: Mirror shared workflows into .github/workflows
+# Workflows that are removed locally, or added by hand in the repository, are deleted on apply
+resource "githubx_repository_directory" "workflows" {
+  repository       = githubx_repository.example.name
+  source           = "${path.module}/shared/workflows"
+  path             = ".github/workflows"
+  include          = ["*.yml", "*.yaml"]
+  delete_unmanaged = true
+  commit_message   = "Sync shared CI workflows"
+}
+
+output "workflow_hashes" {
+  value = githubx_repository_directory.workflows.file_hashes
+}
+
+# Example 2: Mirror a config directory into a new branch, leaving other files alone
+resource "githubx_repository_directory" "config" {
+  repository                      = githubx_repository.example.name
+  branch                          = "ci-config"
+  source                          = "${path.module}/shared/config"
+  path                            = "config"
+  exclude                         = ["**/*.local", "**/.DS_Store"]
+  autocreate_branch               = true
+  autocreate_branch_source_branch = "main"
+  commit_author                   = "CI Bot"
+  commit_email                    = "ci-bot@example.com"
+}
+
+output "config_commit_sha" {
+  value = githubx_repository_directory.config.commit_sha
+}
@@ -72,6 +72,7 @@ output "license_commit_message" {
 }
 
 # Example 4: Create a file with auto-create branch feature
+# The branch is deleted on destroy, since this resource created it
 resource "githubx_repository_file" "feature_file" {
   repository                      = githubx_repository.example.name
   file                            = "feature/new-feature.md"
@@ -79,6 +80,7 @@ resource "githubx_repository_file" "feature_file" {
   content                         = "# New Feature\n\nThis is a new feature file."
   autocreate_branch               = true
   autocreate_branch_source_branch = "main"
+  on_destroy                      = "delete_branch_if_autocreated"
   depends_on                      = [githubx_repository.example]
 }
 
@@ -112,3 +114,91 @@ resource "githubx_repository_file" "changelog" {
 output "changelog_commit_sha" {
   value = githubx_repository_file.changelog.commit_sha
 }
+
+# Example 7: Binary file from base64 content
+# Drift is detected by comparing the git blob SHA, so binary files are safe to manage
+resource "githubx_repository_file" "logo" {
+  repository     = githubx_repository.example.name
+  file           = "assets/logo.png"
+  content_base64 = filebase64("${path.module}/files/logo.png")
+}
+
+# Example 8: File read from a local path at apply time
+resource "githubx_repository_file" "installer" {
+  repository = githubx_repository.example.name
+  file       = "dist/installer.sh"
+  source     = "${path.module}/files/installer.sh"
+}
+
+# Example 9: Propose the change through a pull request on a protected branch
+# The file is committed to "githubx/CODEOWNERS" and reported as pending until the pull request merges
+resource "githubx_repository_file" "codeowners" {
+  repository = githubx_repository.example.name
+  file       = "CODEOWNERS"
+  content    = "* @my-org/maintainers\n"
+
+  pull_request = {
+    title        = "Update CODEOWNERS"
+    auto_merge   = true
+    merge_method = "squash"
+  }
+}
+
+output "codeowners_pull_request_url" {
+  value = githubx_repository_file.codeowners.pull_request_url
+}
+
+# Example 10: Seed a file once and leave later edits to humans
+resource "githubx_repository_file" "contributing" {
+  repository     = githubx_repository.example.name
+  file           = "CONTRIBUTING.md"
+  content        = "# Contributing\n\nPull requests are welcome.\n"
+  lifecycle_mode = "create_only"
+}
+
+# Example 11: Manage only a marked section of a shared file
+# The rest of CODEOWNERS can still be edited by hand
+resource "githubx_repository_file" "codeowners_platform" {
+  repository     = githubx_repository.example.name
+  file           = ".github/CODEOWNERS"
+  content        = "/terraform/ @my-org/platform\n"
+  lifecycle_mode = "append_block"
+  block_marker   = "# {mark} platform team"
+}
+
+# Example 12: Ignore line ending differences from Windows checkouts and editors
+resource "githubx_repository_file" "editorconfig" {
+  repository              = githubx_repository.example.name
+  file                    = ".editorconfig"
+  content                 = file("${path.module}/files/.editorconfig")
+  normalize_line_endings  = true
+  ensure_trailing_newline = true
+}
+
+# Example 13: Conventional commit messages with trailers
+resource "githubx_repository_file" "security" {
+  repository              = githubx_repository.example.name
+  file                    = "SECURITY.md"
+  content                 = "# Security Policy\n\nReport vulnerabilities to security@example.com.\n"
+  commit_message_template = "docs({{ .Branch }}): {{ .Action }} {{ base .Path }}"
+  commit_trailers         = ["Co-authored-by: Jane Doe <jane@example.com>"]
+}
+
+# Example 14: Render content from a template with repository metadata
+resource "githubx_repository_file" "dependabot" {
+  repository       = githubx_repository.example.name
+  file             = ".github/dependabot.yml"
+  content_template = <<-EOT
+    version: 2
+    updates:
+      - package-ecosystem: "{{ .Vars.ecosystem }}"
+        directory: "/"
+        target-branch: "{{ .Repository.DefaultBranch }}"
+        schedule:
+          interval: "{{ .Vars.interval | default "weekly" }}"
+  EOT
+  template_vars = {
+    ecosystem = "gomod"
+    interval  = "daily"
+  }
+}
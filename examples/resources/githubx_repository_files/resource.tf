@@ -0,0 +1,65 @@
+terraform {
+  required_providers {
+    githubx = {
+      source  = "tfstack/githubx"
+      version = "~> 0.1"
+    }
+  }
+}
+
+# Configure the provider
+# The owner can be set via provider config, environment variable GITHUB_OWNER, or will default to authenticated user
+provider "githubx" {
+  # owner = "cloudbuildlab" # Optional: set your GitHub username or organization. If not set, will use authenticated user.
+  # Token can be provided here or via GITHUB_TOKEN environment variable
+  # token = "your-github-token-here"
+}
+
+# First, create a repository
+resource "githubx_repository" "example" {
+  name        = "my-files-example-repo"
+  description = "Repository for multi-file examples"
+  visibility  = "public"
+  auto_init   = true # Initialize with README to create default branch
+}
+
+# Example 1: Add several files to the default branch in a single commit
+resource "githubx_repository_files" "scaffold" {
+  repository = githubx_repository.example.name
+
+  files = {
+    ".editorconfig"           = "root = true\n\n[*]\nend_of_line = lf\ninsert_final_newline = true\n"
+    ".github/CODEOWNERS"      = "* @my-org/platform-team\n"
+    "docs/GETTING_STARTED.md" = "# Getting Started\n\nThis guide is managed by Terraform.\n"
+  }
+}
+
+output "scaffold_commit_sha" {
+  value = githubx_repository_files.scaffold.commit_sha
+}
+
+output "scaffold_file_shas" {
+  value = githubx_repository_files.scaffold.file_shas
+}
+
+# Example 2: Commit files to a branch with a custom message, author and co-authors
+resource "githubx_repository_branch" "develop" {
+  repository = githubx_repository.example.name
+  branch     = "develop"
+}
+
+resource "githubx_repository_files" "workflows" {
+  repository     = githubx_repository.example.name
+  branch         = githubx_repository_branch.develop.branch
+  commit_message = "Add CI workflows"
+  commit_author  = "Terraform Bot"
+  commit_email   = "terraform@example.com"
+  co_authors = [
+    "Jane Doe <jane@example.com>",
+  ]
+
+  files = {
+    ".github/workflows/ci.yml"   = file("${path.module}/workflows/ci.yml")
+    ".github/workflows/lint.yml" = file("${path.module}/workflows/lint.yml")
+  }
+}
@@ -0,0 +1,2 @@
+# Pull requests can be imported using the repository name and the pull request number, separated by a colon
+terraform import githubx_repository_pull_request.feature my-pr-example-repo:1
@@ -0,0 +1,65 @@
+terraform {
+  required_providers {
+    githubx = {
+      source  = "tfstack/githubx"
+      version = "~> 0.1"
+    }
+  }
+}
+
+# Configure the provider
+provider "githubx" {
+  owner = "cloudbuildlab" # Optional: set your GitHub username or organization. If not set, will use authenticated user.
+  # Token can be provided here or via GITHUB_TOKEN environment variable
+  # token = "your-github-token-here"
+}
+
+# First, create a repository
+resource "githubx_repository" "example" {
+  name        = "my-pr-example-repo"
+  description = "Repository for pull request examples"
+  visibility  = "public"
+  auto_init   = true # Initialize with README to create default branch
+}
+
+# Create a feature branch for the PR
+resource "githubx_repository_branch" "feature" {
+  repository    = githubx_repository.example.name
+  branch        = "feature/new-feature"
+  source_branch = "main"
+}
+
+# Add a file to the feature branch
+resource "githubx_repository_file" "feature_file" {
+  repository = githubx_repository.example.name
+  branch     = githubx_repository_branch.feature.branch
+  file       = "feature/new-file.md"
+  content    = "# New Feature\n\nThis is a new feature file added via Terraform."
+}
+
+# Example 1: Pull request with reviewers, assignees and labels
+resource "githubx_repository_pull_request" "feature" {
+  repository = githubx_repository.example.name
+  base_ref   = "main"
+  head_ref   = githubx_repository_branch.feature.branch
+  title      = "Add new feature"
+  body       = "This PR adds a new feature."
+  reviewers  = ["octocat"]
+  assignees  = ["octocat"]
+  labels     = ["enhancement"]
+
+  depends_on = [githubx_repository_file.feature_file]
+}
+
+# Example 2: Draft pull request that is left open when destroyed
+# Note: Only one PR can exist per base_ref/head_ref pair, so comment out Example 1 to use this
+# resource "githubx_repository_pull_request" "draft" {
+#   repository       = githubx_repository.example.name
+#   base_ref         = "main"
+#   head_ref         = githubx_repository_branch.feature.branch
+#   title            = "WIP: new feature"
+#   draft            = true
+#   team_reviewers   = ["platform"]
+#   milestone        = 1
+#   close_on_destroy = false
+# }
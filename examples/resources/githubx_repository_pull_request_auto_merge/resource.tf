@@ -68,5 +68,37 @@ resource "githubx_repository_pull_request_auto_merge" "auto_merge_pr" {
   merge_when_ready   = true
   merge_method       = "merge" # Use "merge" as default (or "squash"/"rebase" if allowed by repository settings)
   wait_for_checks    = true
+  required_checks    = ["build", "test"] # Optional: defaults to the checks required by the protection of base_ref
+  checks_timeout     = "15m"
+  poll_interval      = "10s"
+  wait_for_approvals = true # Wait for the reviews required by the protection of base_ref
+  approvals_timeout  = "1h"
   auto_delete_branch = true
 }
+
+# Example 4: Pull request approved by a separate reviewer account before merging
+# Note: GitHub does not let authors approve their own pull requests, so the approver token must belong to another user
+# resource "githubx_repository_pull_request_auto_merge" "approved_pr" {
+#   repository       = githubx_repository.example.name
+#   base_ref         = "main"
+#   head_ref         = githubx_repository_branch.feature.branch
+#   title            = "Approved feature PR"
+#   merge_when_ready = true
+#
+#   approve = {
+#     body  = "Approved by the release pipeline"
+#     token = "approver-token" # Use a sensitive value, e.g. from a secrets manager
+#   }
+# }
+
+# Example 3: Pull request merged by GitHub's native auto-merge
+# Apply returns as soon as auto-merge is enabled, and GitHub merges the PR once its checks and approvals pass
+# Note: Requires "Allow auto-merge" in the repository settings. Comment out Example 2 to use this
+# resource "githubx_repository_pull_request_auto_merge" "native_auto_merge_pr" {
+#   repository   = githubx_repository.example.name
+#   base_ref     = "main"
+#   head_ref     = githubx_repository_branch.feature.branch
+#   title        = "Auto-merge feature PR"
+#   auto_merge   = true
+#   merge_method = "squash"
+# }
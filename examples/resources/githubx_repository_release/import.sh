@@ -0,0 +1,2 @@
+# Releases can be imported using the repository and tag names, separated by a colon
+terraform import githubx_repository_release.v1_0_0 my-release-example-repo:v1.0.0
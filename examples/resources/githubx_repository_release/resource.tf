@@ -0,0 +1,76 @@
+terraform {
+  required_providers {
+    githubx = {
+      source  = "tfstack/githubx"
+      version = "~> 0.1"
+    }
+  }
+}
+
+# Configure the provider
+# The owner can be set via provider config, environment variable GITHUB_OWNER, or will default to authenticated user
+provider "githubx" {
+  # owner = "cloudbuildlab" # Optional: set your GitHub username or organization. If not set, will use authenticated user.
+  # Token can be provided here or via GITHUB_TOKEN environment variable
+  # token = "your-github-token-here"
+}
+
+# First, create a repository
+resource "githubx_repository" "example" {
+  name        = "my-release-example-repo"
+  description = "Repository for release examples"
+  visibility  = "public"
+  auto_init   = true # Initialize with README to create default branch
+}
+
+# Example 1: Publish a release with generated release notes
+# The tag is created at the head of the default branch if it does not exist
+resource "githubx_repository_release" "v1_0_0" {
+  repository             = githubx_repository.example.name
+  tag                    = "v1.0.0"
+  generate_release_notes = true
+}
+
+output "v1_0_0_url" {
+  value = githubx_repository_release.v1_0_0.html_url
+}
+
+# Example 2: Publish a release of an annotated tag, with build artifacts as assets
+# Assets are uploaded again when the SHA-256 of a local file changes
+resource "githubx_repository_tag" "v1_1_0" {
+  repository = githubx_repository.example.name
+  tag        = "v1.1.0"
+  message    = "Release 1.1.0"
+}
+
+resource "githubx_repository_release" "v1_1_0" {
+  repository = githubx_repository.example.name
+  tag        = githubx_repository_tag.v1_1_0.tag
+  name       = "v1.1.0"
+  body       = "Adds support for ARM64 builds."
+
+  assets = {
+    "tool-linux-amd64.tar.gz" = "${path.module}/dist/tool-linux-amd64.tar.gz"
+    "tool-linux-arm64.tar.gz" = "${path.module}/dist/tool-linux-arm64.tar.gz"
+    "SHA256SUMS"              = "${path.module}/dist/SHA256SUMS"
+  }
+}
+
+output "v1_1_0_asset_sha256" {
+  value = githubx_repository_release.v1_1_0.asset_sha256
+}
+
+# Example 3: Draft a prerelease from a release branch, without making it the latest release
+resource "githubx_repository_branch" "release" {
+  repository = githubx_repository.example.name
+  branch     = "release/2.0"
+}
+
+resource "githubx_repository_release" "v2_0_0_rc1" {
+  repository       = githubx_repository.example.name
+  tag              = "v2.0.0-rc.1"
+  target_commitish = githubx_repository_branch.release.branch
+  draft            = true
+  prerelease       = true
+  make_latest      = "false"
+}
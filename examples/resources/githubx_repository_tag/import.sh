@@ -0,0 +1,2 @@
+# Tags can be imported using the repository and tag names, separated by a colon
+terraform import githubx_repository_tag.v1_0_0 my-tag-example-repo:v1.0.0
@@ -0,0 +1,66 @@
+terraform {
+  required_providers {
+    githubx = {
+      source  = "tfstack/githubx"
+      version = "~> 0.1"
+    }
+  }
+}
+
+# Configure the provider
+# The owner can be set via provider config, environment variable GITHUB_OWNER, or will default to authenticated user
+provider "githubx" {
+  # owner = "cloudbuildlab" # Optional: set your GitHub username or organization. If not set, will use authenticated user.
+  # Token can be provided here or via GITHUB_TOKEN environment variable
+  # token = "your-github-token-here"
+}
+
+# First, create a repository
+resource "githubx_repository" "example" {
+  name        = "my-tag-example-repo"
+  description = "Repository for tag examples"
+  visibility  = "public"
+  auto_init   = true # Initialize with README to create default branch
+}
+
+# Example 1: Create a lightweight tag at the head of the default branch
+resource "githubx_repository_tag" "nightly" {
+  repository = githubx_repository.example.name
+  tag        = "nightly-2024-01-01"
+}
+
+output "nightly_tag_sha" {
+  value = githubx_repository_tag.nightly.sha
+}
+
+# Example 2: Create an annotated release tag at the head of a branch
+resource "githubx_repository_branch" "release" {
+  repository = githubx_repository.example.name
+  branch     = "release/1.0"
+}
+
+resource "githubx_repository_tag" "v1_0_0" {
+  repository    = githubx_repository.example.name
+  tag           = "v1.0.0"
+  source_branch = githubx_repository_branch.release.branch
+  message       = "Release 1.0.0"
+  tagger_name   = "Release Bot"
+  tagger_email  = "release-bot@example.com"
+}
+
+output "v1_0_0_tag_object_sha" {
+  value = githubx_repository_tag.v1_0_0.tag_sha
+}
+
+# Example 3: Tag a specific commit and protect the tag from being moved or deleted
+resource "githubx_repository_tag" "v1_0_1" {
+  repository = githubx_repository.example.name
+  tag        = "v1.0.1"
+  source_sha = githubx_repository_branch.release.sha
+  message    = "Release 1.0.1"
+  protect    = true
+}
+
+output "v1_0_1_ruleset_id" {
+  value = githubx_repository_tag.v1_0_1.ruleset_id
+}
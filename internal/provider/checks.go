@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// githubActionsAppSlug is the slug of the GitHub Actions app, whose check suites are
+// created before their check runs when a workflow is triggered.
+const githubActionsAppSlug = "github-actions"
+
+// commitChecks summarises the check runs, check suites and commit statuses of a commit.
+type commitChecks struct {
+	// Pending are the names of the checks that have not completed yet.
+	Pending []string
+	// Failed are the names of the checks that completed without succeeding.
+	Failed []string
+}
+
+// getCommitChecks evaluates the check runs, check suites and commit statuses of a commit.
+// If required is not empty, only the checks with those names are evaluated, and the ones
+// that have not been reported yet are pending. Otherwise all reported checks are.
+func getCommitChecks(ctx context.Context, client *github.Client, owner, repoName, sha string, required []string) (*commitChecks, error) {
+	// The latest result of each check, by check run name or status context
+	results := map[string]string{}
+	record := func(name, result string) {
+		// A check that is reported twice, e.g. by two apps, only passes if both pass
+		if results[name] == "" || results[name] == "success" {
+			results[name] = result
+		}
+	}
+
+	// The check suites that have check runs
+	suitesWithRuns := map[int64]bool{}
+
+	runOpts := &github.ListCheckRunsOptions{
+		Filter:      github.String("latest"),
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		runs, resp, err := client.Checks.ListCheckRunsForRef(ctx, owner, repoName, sha, runOpts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list check runs for %s: %w", sha, err)
+		}
+		for _, run := range runs.CheckRuns {
+			record(run.GetName(), checkRunResult(run.GetStatus(), run.GetConclusion()))
+			suitesWithRuns[run.GetCheckSuite().GetID()] = true
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		runOpts.Page = resp.NextPage
+	}
+
+	statusOpts := &github.ListOptions{PerPage: 100}
+	for {
+		combined, resp, err := client.Repositories.GetCombinedStatus(ctx, owner, repoName, sha, statusOpts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get commit statuses for %s: %w", sha, err)
+		}
+		for _, status := range combined.Statuses {
+			record(status.GetContext(), statusResult(status.GetState()))
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		statusOpts.Page = resp.NextPage
+	}
+
+	checks := &commitChecks{}
+	if len(required) > 0 {
+		for _, name := range required {
+			switch results[name] {
+			case "success":
+			case "failure":
+				checks.Failed = append(checks.Failed, name)
+			default:
+				checks.Pending = append(checks.Pending, name)
+			}
+		}
+		return checks, nil
+	}
+
+	for name, result := range results {
+		switch result {
+		case "failure":
+			checks.Failed = append(checks.Failed, name)
+		case "pending":
+			checks.Pending = append(checks.Pending, name)
+		}
+	}
+
+	// A triggered workflow has a check suite before it has any check runs. Suites of other
+	// apps are only waited for once they have check runs, as apps that never report
+	// anything leave theirs queued forever.
+	suiteOpts := &github.ListCheckSuiteOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		suites, resp, err := client.Checks.ListCheckSuitesForRef(ctx, owner, repoName, sha, suiteOpts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list check suites for %s: %w", sha, err)
+		}
+		for _, suite := range suites.CheckSuites {
+			hasRuns := suitesWithRuns[suite.GetID()]
+			if suite.GetApp().GetSlug() != githubActionsAppSlug && !hasRuns {
+				continue
+			}
+			name := fmt.Sprintf("%s check suite", suite.GetApp().GetName())
+			switch checkRunResult(suite.GetStatus(), suite.GetConclusion()) {
+			case "pending":
+				checks.Pending = append(checks.Pending, name)
+			case "failure":
+				// The failed check runs of the suite are reported by name already
+				if !hasRuns {
+					checks.Failed = append(checks.Failed, name)
+				}
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		suiteOpts.Page = resp.NextPage
+	}
+
+	sort.Strings(checks.Pending)
+	sort.Strings(checks.Failed)
+	return checks, nil
+}
+
+// checkRunResult maps the status and conclusion of a check run or check suite to
+// "pending", "success" or "failure".
+func checkRunResult(status, conclusion string) string {
+	if status != "completed" {
+		return "pending"
+	}
+	switch conclusion {
+	case "success", "neutral", "skipped":
+		return "success"
+	default:
+		return "failure"
+	}
+}
+
+// statusResult maps the state of a commit status to "pending", "success" or "failure".
+func statusResult(state string) string {
+	switch state {
+	case "success":
+		return "success"
+	case "pending":
+		return "pending"
+	default:
+		return "failure"
+	}
+}
+
+// branchRequiredChecks returns the names of the status checks that the protection of a
+// branch requires. Branches without protection, or whose protection cannot be read with
+// the configured token, have none.
+func branchRequiredChecks(ctx context.Context, client *github.Client, owner, repoName, branch string) ([]string, error) {
+	requiredChecks, _, err := client.Repositories.GetRequiredStatusChecks(ctx, owner, repoName, branch)
+	if err != nil {
+		if kind := classifyGitHubError(err); errors.Is(err, github.ErrBranchNotProtected) || kind == githubErrorNotFound || kind == githubErrorPermissionDenied {
+			log.Printf("[DEBUG] No required status checks found for branch %s in %s/%s: %v", branch, owner, repoName, err)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to get required status checks of branch %s: %w", branch, err)
+	}
+
+	var names []string
+	if requiredChecks.Checks != nil {
+		for _, check := range *requiredChecks.Checks {
+			names = append(names, check.Context)
+		}
+	} else if requiredChecks.Contexts != nil {
+		names = append(names, *requiredChecks.Contexts...)
+	}
+	return names, nil
+}
+
+// sleepContext waits for d, or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// durationValidator checks that a string is a positive Go duration, e.g. "30s" or "10m".
+type durationValidator struct{}
+
+func (v durationValidator) Description(_ context.Context) string {
+	return "value must be a positive duration, e.g. \"30s\" or \"10m\""
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	d, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil || d <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("Expected a positive duration such as \"30s\" or \"10m\", got %q.", req.ConfigValue.ValueString()),
+		)
+	}
+}
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCommitChecks(t *testing.T) {
+	client := newTestGitHubClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/repos/test-owner/test-repo/commits/head-sha/check-runs":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"total_count": 2,
+				"check_runs": []map[string]interface{}{
+					{"name": "build", "status": "completed", "conclusion": "success", "check_suite": map[string]int{"id": 1}},
+					{"name": "lint", "status": "completed", "conclusion": "failure", "check_suite": map[string]int{"id": 1}},
+				},
+			})
+		case "/repos/test-owner/test-repo/commits/head-sha/status":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"state": "pending",
+				"statuses": []map[string]string{
+					{"context": "ci/legacy", "state": "pending"},
+					{"context": "deploy", "state": "success"},
+				},
+			})
+		case "/repos/test-owner/test-repo/commits/head-sha/check-suites":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"total_count": 3,
+				"check_suites": []map[string]interface{}{
+					// Reported through its check runs
+					{"id": 1, "status": "completed", "conclusion": "failure", "app": map[string]string{"slug": "github-actions", "name": "GitHub Actions"}},
+					// An app that never reports anything
+					{"id": 2, "status": "queued", "app": map[string]string{"slug": "other", "name": "Other"}},
+					// A workflow that has not started yet
+					{"id": 3, "status": "queued", "app": map[string]string{"slug": "github-actions", "name": "GitHub Actions"}},
+				},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+		}
+	}))
+
+	tests := []struct {
+		name            string
+		required        []string
+		expectedPending []string
+		expectedFailed  []string
+	}{
+		{
+			name:            "all checks",
+			expectedPending: []string{"GitHub Actions check suite", "ci/legacy"},
+			expectedFailed:  []string{"lint"},
+		},
+		{
+			name:            "required checks",
+			required:        []string{"build", "deploy", "e2e"},
+			expectedPending: []string{"e2e"},
+		},
+		{
+			name:           "failed required check",
+			required:       []string{"lint"},
+			expectedFailed: []string{"lint"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checks, err := getCommitChecks(t.Context(), client, "test-owner", "test-repo", "head-sha", tt.required)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedPending, checks.Pending)
+			assert.Equal(t, tt.expectedFailed, checks.Failed)
+		})
+	}
+}
+
+func TestBranchRequiredChecks(t *testing.T) {
+	client := newTestGitHubClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/repos/test-owner/test-repo/branches/main/protection/required_status_checks":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"strict": true,
+				"checks": []map[string]string{{"context": "build"}, {"context": "test"}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Branch not protected"}`))
+		}
+	}))
+
+	names, err := branchRequiredChecks(t.Context(), client, "test-owner", "test-repo", "main")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"build", "test"}, names)
+
+	names, err = branchRequiredChecks(t.Context(), client, "test-owner", "test-repo", "develop")
+	assert.NoError(t, err)
+	assert.Empty(t, names)
+}
+
+func TestDurationValidator(t *testing.T) {
+	tests := []struct {
+		value       types.String
+		expectError bool
+	}{
+		{value: types.StringValue("10m")},
+		{value: types.StringValue("1h30m")},
+		{value: types.StringNull()},
+		{value: types.StringValue("10"), expectError: true},
+		{value: types.StringValue("-5s"), expectError: true},
+		{value: types.StringValue("0s"), expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value.String(), func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			durationValidator{}.ValidateString(t.Context(), validator.StringRequest{Path: path.Root("checks_timeout"), ConfigValue: tt.value}, resp)
+			assert.Equal(t, tt.expectError, resp.Diagnostics.HasError())
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Commit actions passed to commit message templates.
+const (
+	commitActionCreate = "create"
+	commitActionUpdate = "update"
+	commitActionDelete = "delete"
+)
+
+// commitTrailerPattern matches a git trailer such as "Signed-off-by: Jane Doe <jane@example.com>".
+var commitTrailerPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*: \S.*$`)
+
+// commitMessageData is the data available to commit message templates.
+type commitMessageData struct {
+	// Action is "create", "update" or "delete".
+	Action     string
+	Path       string
+	Repository string
+	Branch     string
+}
+
+// commitMessageFuncs are the functions available to commit message templates.
+var commitMessageFuncs = template.FuncMap{
+	"lower":    strings.ToLower,
+	"upper":    strings.ToUpper,
+	"title":    capitalize,
+	"base":     path.Base,
+	"dir":      path.Dir,
+	"trim":     strings.TrimSpace,
+	"replace":  strings.ReplaceAll,
+	"contains": strings.Contains,
+}
+
+// capitalize returns s with its first letter in upper case.
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// parseCommitMessageTemplate parses a commit message template.
+func parseCommitMessageTemplate(text string) (*template.Template, error) {
+	return template.New("commit_message").Funcs(commitMessageFuncs).Option("missingkey=error").Parse(text)
+}
+
+// renderCommitMessage renders a commit message template.
+func renderCommitMessage(text string, data commitMessageData) (string, error) {
+	tmpl, err := parseCommitMessageTemplate(text)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse commit message template: %w", err)
+	}
+
+	var message bytes.Buffer
+	if err := tmpl.Execute(&message, data); err != nil {
+		return "", fmt.Errorf("unable to render commit message template: %w", err)
+	}
+	if strings.TrimSpace(message.String()) == "" {
+		return "", fmt.Errorf("the commit message template rendered an empty message")
+	}
+	return message.String(), nil
+}
+
+// appendCommitTrailers adds trailers to the end of a commit message, in a paragraph of
+// their own. Trailers that are already in the message are not repeated.
+func appendCommitTrailers(message string, trailers []string) string {
+	var missing []string
+	for _, trailer := range trailers {
+		if !strings.Contains(message, trailer) {
+			missing = append(missing, trailer)
+		}
+	}
+	if len(missing) == 0 {
+		return message
+	}
+	return strings.TrimRight(message, "\n") + "\n\n" + strings.Join(missing, "\n")
+}
+
+// commitMessageTemplateValidator checks that a string is a valid commit message template.
+type commitMessageTemplateValidator struct{}
+
+func (v commitMessageTemplateValidator) Description(_ context.Context) string {
+	return "value must be a valid Go text/template"
+}
+
+func (v commitMessageTemplateValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v commitMessageTemplateValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := parseCommitMessageTemplate(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Commit Message Template",
+			fmt.Sprintf("Unable to parse the commit message template: %v", err),
+		)
+	}
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderCommitMessage(t *testing.T) {
+	data := commitMessageData{
+		Action:     commitActionUpdate,
+		Path:       ".github/CODEOWNERS",
+		Repository: "test-repo",
+		Branch:     "main",
+	}
+
+	tests := []struct {
+		name        string
+		template    string
+		expected    string
+		expectError bool
+	}{
+		{name: "fields", template: "chore({{ .Repository }}): {{ .Action }} {{ .Path }} on {{ .Branch }}", expected: "chore(test-repo): update .github/CODEOWNERS on main"},
+		{name: "functions", template: "{{ .Action | title }} {{ base .Path }} in {{ dir .Path }}", expected: "Update CODEOWNERS in .github"},
+		{name: "unknown field", template: "{{ .Author }}", expectError: true},
+		{name: "empty message", template: "{{ if false }}x{{ end }}", expectError: true},
+		{name: "invalid template", template: "{{ .Action", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, err := renderCommitMessage(tt.template, data)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, message)
+		})
+	}
+}
+
+func TestAppendCommitTrailers(t *testing.T) {
+	assert.Equal(t, "Add README.md", appendCommitTrailers("Add README.md", nil))
+	assert.Equal(t,
+		"Add README.md\n\nSigned-off-by: Jane Doe <jane@example.com>\nCo-authored-by: John Doe <john@example.com>",
+		appendCommitTrailers("Add README.md\n", []string{"Signed-off-by: Jane Doe <jane@example.com>", "Co-authored-by: John Doe <john@example.com>"}),
+	)
+	assert.Equal(t,
+		"Add README.md\n\nSigned-off-by: Jane Doe <jane@example.com>",
+		appendCommitTrailers("Add README.md\n\nSigned-off-by: Jane Doe <jane@example.com>", []string{"Signed-off-by: Jane Doe <jane@example.com>"}),
+	)
+}
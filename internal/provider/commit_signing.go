@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/google/go-github/v60/github"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/crypto/ssh"
+)
+
+// commitSigningModel represents the commit signing configuration.
+type commitSigningModel struct {
+	GPGPrivateKey types.String `tfsdk:"gpg_private_key"`
+	SSHPrivateKey types.String `tfsdk:"ssh_private_key"`
+	Passphrase    types.String `tfsdk:"passphrase"`
+}
+
+// sshSignatureNamespace is the namespace git uses for SSH commit signatures.
+const sshSignatureNamespace = "git"
+
+// newCommitSigner returns a signer for the configured GPG or SSH key, or nil if commit
+// signing is not configured.
+func newCommitSigner(config *commitSigningModel) (github.MessageSigner, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	passphrase := config.Passphrase.ValueString()
+	switch {
+	case config.GPGPrivateKey.ValueString() != "":
+		return newGPGSigner(config.GPGPrivateKey.ValueString(), passphrase)
+	case config.SSHPrivateKey.ValueString() != "":
+		return newSSHSigner(config.SSHPrivateKey.ValueString(), passphrase)
+	}
+	return nil, nil
+}
+
+// newGPGSigner returns a signer that creates armored detached OpenPGP signatures.
+func newGPGSigner(armoredKey, passphrase string) (github.MessageSigner, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(armoredKey))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read GPG private key: %w", err)
+	}
+	if len(entities) == 0 || entities[0].PrivateKey == nil {
+		return nil, errors.New("the GPG key does not contain a private key")
+	}
+	entity := entities[0]
+
+	if entity.PrivateKey.Encrypted {
+		if passphrase == "" {
+			return nil, errors.New("the GPG private key is encrypted but no passphrase is set")
+		}
+		if err := entity.PrivateKey.Decrypt([]byte(passphrase)); err != nil {
+			return nil, fmt.Errorf("unable to decrypt GPG private key: %w", err)
+		}
+	}
+	for _, subkey := range entity.Subkeys {
+		if subkey.PrivateKey != nil && subkey.PrivateKey.Encrypted {
+			if err := subkey.PrivateKey.Decrypt([]byte(passphrase)); err != nil {
+				return nil, fmt.Errorf("unable to decrypt GPG private subkey: %w", err)
+			}
+		}
+	}
+
+	return github.MessageSignerFunc(func(w io.Writer, r io.Reader) error {
+		return openpgp.ArmoredDetachSign(w, entity, r, nil)
+	}), nil
+}
+
+// newSSHSigner returns a signer that creates armored SSH signatures in the format used
+// by `git commit -S` with `gpg.format=ssh`.
+func newSSHSigner(privateKey, passphrase string) (github.MessageSigner, error) {
+	var signer ssh.Signer
+	var err error
+	if passphrase != "" {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase([]byte(privateKey), []byte(passphrase))
+	} else {
+		signer, err = ssh.ParsePrivateKey([]byte(privateKey))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read SSH private key: %w", err)
+	}
+
+	return github.MessageSignerFunc(func(w io.Writer, r io.Reader) error {
+		signature, err := sshSign(signer, r)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(signature)
+		return err
+	}), nil
+}
+
+// sshSignedData is the data that is signed for an SSH signature, after the magic preamble.
+type sshSignedData struct {
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Hash          []byte
+}
+
+// sshSignatureBlob is an SSH signature, after the magic preamble.
+type sshSignatureBlob struct {
+	Version       uint32
+	PublicKey     []byte
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Signature     []byte
+}
+
+// sshSign signs a message following the OpenSSH PROTOCOL.sshsig format and returns the
+// armored signature.
+func sshSign(signer ssh.Signer, message io.Reader) ([]byte, error) {
+	h := sha512.New()
+	if _, err := io.Copy(h, message); err != nil {
+		return nil, err
+	}
+
+	signedData := append([]byte("SSHSIG"), ssh.Marshal(sshSignedData{
+		Namespace:     sshSignatureNamespace,
+		HashAlgorithm: "sha512",
+		Hash:          h.Sum(nil),
+	})...)
+
+	var signature *ssh.Signature
+	var err error
+	if algorithmSigner, ok := signer.(ssh.AlgorithmSigner); ok && signer.PublicKey().Type() == ssh.KeyAlgoRSA {
+		// SHA-1 RSA signatures are rejected by git and GitHub
+		signature, err = algorithmSigner.SignWithAlgorithm(rand.Reader, signedData, ssh.KeyAlgoRSASHA512)
+	} else {
+		signature, err = signer.Sign(rand.Reader, signedData)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to create SSH signature: %w", err)
+	}
+
+	blob := append([]byte("SSHSIG"), ssh.Marshal(sshSignatureBlob{
+		Version:       1,
+		PublicKey:     signer.PublicKey().Marshal(),
+		Namespace:     sshSignatureNamespace,
+		HashAlgorithm: "sha512",
+		Signature:     ssh.Marshal(signature),
+	})...)
+
+	encoded := base64.StdEncoding.EncodeToString(blob)
+	var armored bytes.Buffer
+	armored.WriteString("-----BEGIN SSH SIGNATURE-----\n")
+	for len(encoded) > 70 {
+		armored.WriteString(encoded[:70] + "\n")
+		encoded = encoded[70:]
+	}
+	armored.WriteString(encoded + "\n")
+	armored.WriteString("-----END SSH SIGNATURE-----\n")
+	return armored.Bytes(), nil
+}
+
+// prepareSignedCommit sets the author, committer and dates that a signed commit needs, as
+// they are part of the signed payload. The author defaults to the authenticated user.
+func prepareSignedCommit(ctx context.Context, client *github.Client, commit *github.Commit) error {
+	if commit.Author == nil {
+		user, _, err := client.Users.Get(ctx, "")
+		if err != nil {
+			return fmt.Errorf("unable to read the authenticated user for the commit author: %w", err)
+		}
+		email := user.GetEmail()
+		if email == "" {
+			emails, _, err := client.Users.ListEmails(ctx, nil)
+			if err == nil {
+				for _, e := range emails {
+					if e.GetPrimary() {
+						email = e.GetEmail()
+						break
+					}
+				}
+			}
+		}
+		if email == "" {
+			return errors.New("unable to determine the email address of the authenticated user, set 'commit_author' and 'commit_email' to sign commits")
+		}
+		name := user.GetName()
+		if name == "" {
+			name = user.GetLogin()
+		}
+		commit.Author = &github.CommitAuthor{Name: github.String(name), Email: github.String(email)}
+	}
+	if commit.Committer == nil {
+		commit.Committer = &github.CommitAuthor{Name: commit.Author.Name, Email: commit.Author.Email}
+	}
+
+	now := &github.Timestamp{Time: time.Now().UTC().Truncate(time.Second)}
+	commit.Author.Date = now
+	commit.Committer.Date = now
+	return nil
+}
@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/google/go-github/v60/github"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestNewCommitSigner_NotConfigured(t *testing.T) {
+	signer, err := newCommitSigner(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, signer)
+
+	signer, err = newCommitSigner(&commitSigningModel{
+		GPGPrivateKey: types.StringNull(),
+		SSHPrivateKey: types.StringNull(),
+		Passphrase:    types.StringNull(),
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, signer)
+}
+
+func TestNewCommitSigner_GPG(t *testing.T) {
+	entity, err := openpgp.NewEntity("Test User", "", "test@example.com", nil)
+	assert.NoError(t, err)
+
+	var key bytes.Buffer
+	w, err := armor.Encode(&key, openpgp.PrivateKeyType, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, entity.SerializePrivate(w, nil))
+	assert.NoError(t, w.Close())
+
+	signer, err := newCommitSigner(&commitSigningModel{
+		GPGPrivateKey: types.StringValue(key.String()),
+		SSHPrivateKey: types.StringNull(),
+		Passphrase:    types.StringNull(),
+	})
+	assert.NoError(t, err)
+
+	message := "tree abc\nauthor Test User <test@example.com> 0 +0000\n\nAdd README.md"
+	var signature bytes.Buffer
+	assert.NoError(t, signer.Sign(&signature, strings.NewReader(message)))
+	assert.True(t, strings.HasPrefix(signature.String(), "-----BEGIN PGP SIGNATURE-----"))
+
+	_, err = openpgp.CheckArmoredDetachedSignature(openpgp.EntityList{entity}, strings.NewReader(message), &signature, nil)
+	assert.NoError(t, err)
+}
+
+func TestNewCommitSigner_SSH(t *testing.T) {
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		key        interface{}
+		passphrase string
+		algorithm  string
+	}{
+		{name: "ed25519", key: ed25519Key, algorithm: ssh.KeyAlgoED25519},
+		{name: "ed25519 with passphrase", key: ed25519Key, passphrase: "secret", algorithm: ssh.KeyAlgoED25519},
+		{name: "rsa", key: rsaKey, algorithm: ssh.KeyAlgoRSASHA512},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var block *pem.Block
+			if tt.passphrase != "" {
+				block, err = ssh.MarshalPrivateKeyWithPassphrase(tt.key, "", []byte(tt.passphrase))
+			} else {
+				block, err = ssh.MarshalPrivateKey(tt.key, "")
+			}
+			assert.NoError(t, err)
+
+			signer, err := newCommitSigner(&commitSigningModel{
+				GPGPrivateKey: types.StringNull(),
+				SSHPrivateKey: types.StringValue(string(pem.EncodeToMemory(block))),
+				Passphrase:    types.StringValue(tt.passphrase),
+			})
+			assert.NoError(t, err)
+
+			message := "tree abc\nauthor Test User <test@example.com> 0 +0000\n\nAdd README.md"
+			var armored bytes.Buffer
+			assert.NoError(t, signer.Sign(&armored, strings.NewReader(message)))
+
+			// Decode the armored signature and verify it as ssh-keygen -Y verify would
+			lines := strings.Split(strings.TrimSpace(armored.String()), "\n")
+			assert.Equal(t, "-----BEGIN SSH SIGNATURE-----", lines[0])
+			assert.Equal(t, "-----END SSH SIGNATURE-----", lines[len(lines)-1])
+			for _, line := range lines[1 : len(lines)-1] {
+				assert.LessOrEqual(t, len(line), 70)
+			}
+			blob, err := base64.StdEncoding.DecodeString(strings.Join(lines[1:len(lines)-1], ""))
+			assert.NoError(t, err)
+			assert.True(t, bytes.HasPrefix(blob, []byte("SSHSIG")))
+
+			var sig sshSignatureBlob
+			assert.NoError(t, ssh.Unmarshal(blob[6:], &sig))
+			assert.Equal(t, uint32(1), sig.Version)
+			assert.Equal(t, "git", sig.Namespace)
+			assert.Equal(t, "sha512", sig.HashAlgorithm)
+
+			publicKey, err := ssh.ParsePublicKey(sig.PublicKey)
+			assert.NoError(t, err)
+			var signature ssh.Signature
+			assert.NoError(t, ssh.Unmarshal(sig.Signature, &signature))
+			assert.Equal(t, tt.algorithm, signature.Format)
+
+			hash := sha512.Sum512([]byte(message))
+			signedData := append([]byte("SSHSIG"), ssh.Marshal(sshSignedData{
+				Namespace:     "git",
+				HashAlgorithm: "sha512",
+				Hash:          hash[:],
+			})...)
+			assert.NoError(t, publicKey.Verify(signedData, &signature))
+		})
+	}
+}
+
+func TestNewCommitSigner_InvalidKeys(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	block, err := ssh.MarshalPrivateKeyWithPassphrase(key, "", []byte("secret"))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		config *commitSigningModel
+	}{
+		{
+			name:   "invalid gpg key",
+			config: &commitSigningModel{GPGPrivateKey: types.StringValue("not a key"), SSHPrivateKey: types.StringNull(), Passphrase: types.StringNull()},
+		},
+		{
+			name:   "invalid ssh key",
+			config: &commitSigningModel{GPGPrivateKey: types.StringNull(), SSHPrivateKey: types.StringValue("not a key"), Passphrase: types.StringNull()},
+		},
+		{
+			name:   "encrypted ssh key without passphrase",
+			config: &commitSigningModel{GPGPrivateKey: types.StringNull(), SSHPrivateKey: types.StringValue(string(pem.EncodeToMemory(block))), Passphrase: types.StringNull()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newCommitSigner(tt.config)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRepositoryFilesResource_Create_Signed(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	block, err := ssh.MarshalPrivateKey(key, "")
+	assert.NoError(t, err)
+	signer, err := newSSHSigner(string(pem.EncodeToMemory(block)), "")
+	assert.NoError(t, err)
+
+	fake := newFakeGitRepository(map[string]string{"README.md": "# test-repo\n"})
+	rs := &repositoryFilesResource{
+		client: newTestGitHubClient(t, fake),
+		owner:  "test-owner",
+		signer: signer,
+	}
+
+	plan := newRepositoryFilesPlan(t, rs, map[string]string{"a.txt": "a\n"})
+	assert.False(t, plan.SetAttribute(t.Context(), path.Root("commit_author"), "Test User").HasError())
+	assert.False(t, plan.SetAttribute(t.Context(), path.Root("commit_email"), "test@example.com").HasError())
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw.Copy()}}
+	rs.Create(t.Context(), resource.CreateRequest{Plan: plan}, resp)
+	assert.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+
+	commit := fake.commits[fake.refs["heads/main"]]
+	assert.True(t, strings.HasPrefix(commit.Signature, "-----BEGIN SSH SIGNATURE-----"))
+	assert.Equal(t, "Test User", commit.Author.GetName())
+	assert.NotNil(t, commit.Author.Date)
+}
+
+func TestPrepareSignedCommit(t *testing.T) {
+	commit := &github.Commit{
+		Message: github.String("Add README.md"),
+		Author:  &github.CommitAuthor{Name: github.String("Test User"), Email: github.String("test@example.com")},
+	}
+
+	assert.NoError(t, prepareSignedCommit(t.Context(), nil, commit))
+	assert.NotNil(t, commit.Author.Date)
+	assert.Equal(t, commit.Author.Date, commit.Committer.Date)
+	assert.Equal(t, "test@example.com", commit.Committer.GetEmail())
+	assert.Zero(t, commit.Author.Date.Nanosecond())
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// contentTemplateData is the data available to file content templates.
+type contentTemplateData struct {
+	Vars       map[string]string
+	Repository contentTemplateRepository
+	Branch     string
+	Path       string
+}
+
+// contentTemplateRepository is the repository metadata available to file content templates.
+type contentTemplateRepository struct {
+	Name          string
+	Owner         string
+	FullName      string
+	Description   string
+	Homepage      string
+	DefaultBranch string
+	Visibility    string
+	Topics        []string
+	Private       bool
+	Archived      bool
+}
+
+// contentTemplateFuncs are the functions available to file content templates. They only
+// transform values, so templates cannot read files or the environment.
+var contentTemplateFuncs = template.FuncMap{
+	"lower":    strings.ToLower,
+	"upper":    strings.ToUpper,
+	"title":    capitalize,
+	"trim":     strings.TrimSpace,
+	"replace":  strings.ReplaceAll,
+	"contains": strings.Contains,
+	"join": func(sep string, values []string) string {
+		return strings.Join(values, sep)
+	},
+	"split": func(sep, s string) []string {
+		return strings.Split(s, sep)
+	},
+	"indent": func(spaces int, s string) string {
+		pad := strings.Repeat(" ", spaces)
+		return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+	},
+	"quote": func(s string) string {
+		return fmt.Sprintf("%q", s)
+	},
+	"default": func(fallback, value string) string {
+		if value == "" {
+			return fallback
+		}
+		return value
+	},
+	"json": func(v interface{}) (string, error) {
+		encoded, err := json.Marshal(v)
+		return string(encoded), err
+	},
+}
+
+// parseContentTemplate parses a file content template.
+func parseContentTemplate(text string) (*template.Template, error) {
+	return template.New("content_template").Funcs(contentTemplateFuncs).Option("missingkey=error").Parse(text)
+}
+
+// renderContentTemplate renders a file content template.
+func renderContentTemplate(text string, data contentTemplateData) (string, error) {
+	tmpl, err := parseContentTemplate(text)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse `content_template`: %w", err)
+	}
+
+	var content bytes.Buffer
+	if err := tmpl.Execute(&content, data); err != nil {
+		return "", fmt.Errorf("unable to render `content_template`: %w", err)
+	}
+	return content.String(), nil
+}
+
+// newContentTemplateRepository returns the template metadata of a repository.
+func newContentTemplateRepository(repo *github.Repository) contentTemplateRepository {
+	topics := repo.Topics
+	if topics == nil {
+		topics = []string{}
+	}
+	return contentTemplateRepository{
+		Name:          repo.GetName(),
+		Owner:         repo.GetOwner().GetLogin(),
+		FullName:      repo.GetFullName(),
+		Description:   repo.GetDescription(),
+		Homepage:      repo.GetHomepage(),
+		DefaultBranch: repo.GetDefaultBranch(),
+		Visibility:    repo.GetVisibility(),
+		Topics:        topics,
+		Private:       repo.GetPrivate(),
+		Archived:      repo.GetArchived(),
+	}
+}
+
+// contentTemplateValidator checks that a string is a valid file content template.
+type contentTemplateValidator struct{}
+
+func (v contentTemplateValidator) Description(_ context.Context) string {
+	return "value must be a valid Go text/template"
+}
+
+func (v contentTemplateValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v contentTemplateValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := parseContentTemplate(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Content Template",
+			fmt.Sprintf("Unable to parse the content template: %v", err),
+		)
+	}
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderContentTemplate(t *testing.T) {
+	data := contentTemplateData{
+		Vars: map[string]string{"team": "platform"},
+		Repository: contentTemplateRepository{
+			Name:          "test-repo",
+			Owner:         "test-owner",
+			DefaultBranch: "main",
+			Topics:        []string{"go", "terraform"},
+		},
+		Branch: "main",
+		Path:   ".github/CODEOWNERS",
+	}
+
+	tests := []struct {
+		name        string
+		template    string
+		expected    string
+		expectError bool
+	}{
+		{name: "fields", template: "* @{{ .Repository.Owner }}/{{ .Vars.team }} # {{ .Path }} on {{ .Branch }}", expected: "* @test-owner/platform # .github/CODEOWNERS on main"},
+		{name: "join", template: "{{ join \", \" .Repository.Topics }}", expected: "go, terraform"},
+		{name: "indent", template: "x:\n{{ indent 2 \"a\\nb\" }}", expected: "x:\n  a\n  b"},
+		{name: "default", template: "{{ default \"none\" .Repository.Description }}", expected: "none"},
+		{name: "json", template: "{{ json .Repository.Topics }}", expected: `["go","terraform"]`},
+		{name: "missing variable", template: "{{ .Vars.owner }}", expectError: true},
+		{name: "unknown field", template: "{{ .Owner }}", expectError: true},
+		{name: "unknown function", template: "{{ env \"HOME\" }}", expectError: true},
+		{name: "invalid template", template: "{{ .Path", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := renderContentTemplate(tt.template, data)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, content)
+		})
+	}
+}
+
+func TestRepositoryFileResource_RenderContent(t *testing.T) {
+	client := newTestGitHubClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/test-owner/test-repo" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(&github.Repository{
+			Name:          github.String("test-repo"),
+			Owner:         &github.User{Login: github.String("test-owner")},
+			DefaultBranch: github.String("trunk"),
+		})
+	}))
+	r := &repositoryFileResource{client: client, owner: "test-owner"}
+
+	model := &repositoryFileResourceModel{
+		Repository:      types.StringValue("test-repo"),
+		File:            types.StringValue("README.md"),
+		Branch:          types.StringNull(),
+		ContentTemplate: types.StringValue("# {{ .Repository.Name }} ({{ .Branch }}) by {{ .Vars.team }}\n"),
+		TemplateVars:    types.MapValueMust(types.StringType, map[string]attr.Value{"team": types.StringValue("platform")}),
+	}
+	assert.NoError(t, r.renderContent(t.Context(), "test-owner", model))
+	assert.Equal(t, "# test-repo (trunk) by platform\n", model.RenderedContent.ValueString())
+
+	content, err := fileContentFromModel(model)
+	assert.NoError(t, err)
+	assert.Equal(t, "# test-repo (trunk) by platform\n", string(content))
+
+	model.Repository = types.StringValue("missing-repo")
+	err = r.renderContent(t.Context(), "test-owner", model)
+	assert.True(t, isNotFound(err))
+}
@@ -2,9 +2,7 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"net/http"
 	"strings"
 
 	"github.com/google/go-github/v60/github"
@@ -414,25 +412,21 @@ func (d *repositoryDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	}
 
 	// Fetch the repository from GitHub
-	repo, ghResp, err := d.client.Repositories.Get(ctx, owner, repoName)
+	repo, _, err := d.client.Repositories.Get(ctx, owner, repoName)
 	if err != nil {
-		var ghErr *github.ErrorResponse
-		if errors.As(err, &ghErr) {
-			// Check both ghResp and ghErr.Response for 404 status
-			if (ghResp != nil && ghResp.StatusCode == http.StatusNotFound) ||
-				(ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusNotFound) {
-				resp.Diagnostics.AddWarning(
-					"Repository Not Found",
-					fmt.Sprintf("Repository %s/%s not found. Setting empty state.", owner, repoName),
-				)
-				data.ID = types.StringValue("")
-				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-				return
-			}
+		if isNotFound(err) {
+			resp.Diagnostics.AddWarning(
+				"Repository Not Found",
+				fmt.Sprintf("Repository %s/%s not found. Setting empty state.", owner, repoName),
+			)
+			data.ID = types.StringValue("")
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
 		}
-		resp.Diagnostics.AddError(
+		addGitHubError(&resp.Diagnostics,
 			"Error fetching GitHub repository",
 			fmt.Sprintf("Unable to fetch repository %s/%s: %v", owner, repoName, err),
+			err,
 		)
 		return
 	}
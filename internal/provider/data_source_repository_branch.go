@@ -2,10 +2,8 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
-	"net/http"
 
 	"github.com/google/go-github/v60/github"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -199,24 +197,20 @@ func (d *repositoryBranchDataSource) Read(ctx context.Context, req datasource.Re
 	// Fetch the branch reference from GitHub
 	ref, ghResp, err := d.client.Git.GetRef(ctx, owner, repoName, branchRefName)
 	if err != nil {
-		var ghErr *github.ErrorResponse
-		if errors.As(err, &ghErr) {
-			// Check both ghResp and ghErr.Response for 404 status
-			if (ghResp != nil && ghResp.StatusCode == http.StatusNotFound) ||
-				(ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusNotFound) {
-				log.Printf("[DEBUG] Missing GitHub branch %s/%s (%s)", owner, repoName, branchRefName)
-				resp.Diagnostics.AddWarning(
-					"Branch Not Found",
-					fmt.Sprintf("Branch %s not found in repository %s/%s. Setting empty state.", branchName, owner, repoName),
-				)
-				data.ID = types.StringValue("")
-				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-				return
-			}
+		if isNotFound(err) {
+			log.Printf("[DEBUG] Missing GitHub branch %s/%s (%s)", owner, repoName, branchRefName)
+			resp.Diagnostics.AddWarning(
+				"Branch Not Found",
+				fmt.Sprintf("Branch %s not found in repository %s/%s. Setting empty state.", branchName, owner, repoName),
+			)
+			data.ID = types.StringValue("")
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
 		}
-		resp.Diagnostics.AddError(
+		addGitHubError(&resp.Diagnostics,
 			"Error fetching GitHub branch",
 			fmt.Sprintf("Unable to fetch branch %s from repository %s/%s: %v", branchName, owner, repoName, err),
+			err,
 		)
 		return
 	}
@@ -2,11 +2,11 @@ package provider
 
 import (
 	"context"
-	"errors"
+	"encoding/base64"
 	"fmt"
 	"log"
-	"net/http"
 	"net/url"
+	"unicode/utf8"
 
 	"github.com/google/go-github/v60/github"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -29,18 +29,21 @@ type repositoryFileDataSource struct {
 }
 
 type repositoryFileDataSourceModel struct {
-	Repository    types.String `tfsdk:"repository"`
-	FullName      types.String `tfsdk:"full_name"`
-	File          types.String `tfsdk:"file"`
-	Branch        types.String `tfsdk:"branch"`
-	Ref           types.String `tfsdk:"ref"`
-	Content       types.String `tfsdk:"content"`
-	CommitSHA     types.String `tfsdk:"commit_sha"`
-	CommitMessage types.String `tfsdk:"commit_message"`
-	CommitAuthor  types.String `tfsdk:"commit_author"`
-	CommitEmail   types.String `tfsdk:"commit_email"`
-	SHA           types.String `tfsdk:"sha"`
-	ID            types.String `tfsdk:"id"`
+	Repository            types.String `tfsdk:"repository"`
+	FullName              types.String `tfsdk:"full_name"`
+	File                  types.String `tfsdk:"file"`
+	Branch                types.String `tfsdk:"branch"`
+	NormalizeLineEndings  types.Bool   `tfsdk:"normalize_line_endings"`
+	EnsureTrailingNewline types.Bool   `tfsdk:"ensure_trailing_newline"`
+	Ref                   types.String `tfsdk:"ref"`
+	Content               types.String `tfsdk:"content"`
+	ContentBase64         types.String `tfsdk:"content_base64"`
+	CommitSHA             types.String `tfsdk:"commit_sha"`
+	CommitMessage         types.String `tfsdk:"commit_message"`
+	CommitAuthor          types.String `tfsdk:"commit_author"`
+	CommitEmail           types.String `tfsdk:"commit_email"`
+	SHA                   types.String `tfsdk:"sha"`
+	ID                    types.String `tfsdk:"id"`
 }
 
 func (d *repositoryFileDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -71,8 +74,20 @@ func (d *repositoryFileDataSource) Schema(_ context.Context, _ datasource.Schema
 				Description: "The name of the commit/branch/tag.",
 				Computed:    true,
 			},
+			"normalize_line_endings": schema.BoolAttribute{
+				Description: "Convert CRLF and CR line endings in text content to LF. Binary content is left alone. Defaults to \"false\".",
+				Optional:    true,
+			},
+			"ensure_trailing_newline": schema.BoolAttribute{
+				Description: "End non-empty text content with a newline. Binary content is left alone. Defaults to \"false\".",
+				Optional:    true,
+			},
 			"content": schema.StringAttribute{
-				Description: "The file's content.",
+				Description: "The file's content. Not set for binary files, use `content_base64` instead.",
+				Computed:    true,
+			},
+			"content_base64": schema.StringAttribute{
+				Description: "The file's content, base64 encoded.",
 				Computed:    true,
 			},
 			"commit_sha": schema.StringAttribute{
@@ -209,18 +224,16 @@ func (d *repositoryFileDataSource) Read(ctx context.Context, req datasource.Read
 
 	fc, dc, _, err := d.client.Repositories.GetContents(ctx, owner, repoName, filePath, opts)
 	if err != nil {
-		var ghErr *github.ErrorResponse
-		if errors.As(err, &ghErr) {
-			if ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusNotFound {
-				log.Printf("[DEBUG] Missing GitHub repository file %s/%s/%s", owner, repoName, filePath)
-				data.ID = types.StringValue("")
-				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-				return
-			}
+		if isNotFound(err) {
+			log.Printf("[DEBUG] Missing GitHub repository file %s/%s/%s", owner, repoName, filePath)
+			data.ID = types.StringValue("")
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
 		}
-		resp.Diagnostics.AddError(
+		addGitHubError(&resp.Diagnostics,
 			"Error fetching GitHub repository file",
 			fmt.Sprintf("Unable to fetch file %s from repository %s/%s: %v", filePath, owner, repoName, err),
+			err,
 		)
 		return
 	}
@@ -234,16 +247,23 @@ func (d *repositoryFileDataSource) Read(ctx context.Context, req datasource.Read
 		return
 	}
 
-	content, err := fc.GetContent()
+	content, err := repositoryFileContent(ctx, d.client, owner, repoName, fc)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addGitHubError(&resp.Diagnostics,
 			"Error reading file content",
 			fmt.Sprintf("Unable to get content from file %s/%s/%s: %v", owner, repoName, filePath, err),
+			err,
 		)
 		return
 	}
+	content = normalizeFileContent(content, data.NormalizeLineEndings.ValueBool(), data.EnsureTrailingNewline.ValueBool())
 
-	data.Content = types.StringValue(content)
+	if utf8.Valid(content) {
+		data.Content = types.StringValue(string(content))
+	} else {
+		data.Content = types.StringNull()
+	}
+	data.ContentBase64 = types.StringValue(base64.StdEncoding.EncodeToString(content))
 	data.SHA = types.StringValue(fc.GetSHA())
 
 	parsedURL, err := url.Parse(fc.GetURL())
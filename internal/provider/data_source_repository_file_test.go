@@ -57,10 +57,22 @@ func TestRepositoryFileDataSource_Schema(t *testing.T) {
 	assert.True(t, ok)
 	assert.True(t, refAttr.IsComputed())
 
+	normalizeLineEndingsAttr, ok := resp.Schema.Attributes["normalize_line_endings"]
+	assert.True(t, ok)
+	assert.True(t, normalizeLineEndingsAttr.IsOptional())
+
+	ensureTrailingNewlineAttr, ok := resp.Schema.Attributes["ensure_trailing_newline"]
+	assert.True(t, ok)
+	assert.True(t, ensureTrailingNewlineAttr.IsOptional())
+
 	contentAttr, ok := resp.Schema.Attributes["content"]
 	assert.True(t, ok)
 	assert.True(t, contentAttr.IsComputed())
 
+	contentBase64Attr, ok := resp.Schema.Attributes["content_base64"]
+	assert.True(t, ok)
+	assert.True(t, contentBase64Attr.IsComputed())
+
 	shaAttr, ok := resp.Schema.Attributes["sha"]
 	assert.True(t, ok)
 	assert.True(t, shaAttr.IsComputed())
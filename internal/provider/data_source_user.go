@@ -185,9 +185,10 @@ func (d *userDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	// Fetch the user from GitHub
 	user, _, err := d.client.Users.Get(ctx, username)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addGitHubError(&resp.Diagnostics,
 			"Error fetching GitHub user",
 			fmt.Sprintf("Unable to fetch user %s: %v", username, err),
+			err,
 		)
 		return
 	}
@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// githubErrorKind is the class of an error returned by the GitHub API.
+type githubErrorKind int
+
+// Classes of GitHub API errors.
+const (
+	githubErrorOther githubErrorKind = iota
+	githubErrorNotFound
+	githubErrorAlreadyExists
+	githubErrorConflict
+	githubErrorRateLimited
+	githubErrorPermissionDenied
+	githubErrorValidationFailed
+	githubErrorMethodNotAllowed
+)
+
+// classifyGitHubError returns the class of an error returned by the GitHub API, based on
+// the status code and error codes of the response rather than the error text. GraphQL
+// errors are classified by the type of their first error.
+func classifyGitHubError(err error) githubErrorKind {
+	if err == nil {
+		return githubErrorOther
+	}
+
+	var gqlErrs graphQLErrors
+	if errors.As(err, &gqlErrs) && len(gqlErrs) > 0 {
+		switch gqlErrs[0].Type {
+		case "NOT_FOUND":
+			return githubErrorNotFound
+		case "FORBIDDEN":
+			return githubErrorPermissionDenied
+		case "RATE_LIMITED":
+			return githubErrorRateLimited
+		case "UNPROCESSABLE":
+			return githubErrorValidationFailed
+		}
+		return githubErrorOther
+	}
+
+	var rateLimitErr *github.RateLimitError
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &rateLimitErr) || errors.As(err, &abuseErr) {
+		return githubErrorRateLimited
+	}
+
+	var ghErr *github.ErrorResponse
+	if !errors.As(err, &ghErr) || ghErr.Response == nil {
+		return githubErrorOther
+	}
+
+	switch ghErr.Response.StatusCode {
+	case http.StatusNotFound:
+		return githubErrorNotFound
+	case http.StatusConflict:
+		return githubErrorConflict
+	case http.StatusMethodNotAllowed:
+		return githubErrorMethodNotAllowed
+	case http.StatusTooManyRequests:
+		return githubErrorRateLimited
+	case http.StatusUnauthorized, http.StatusForbidden:
+		if ghErr.Response.Header.Get("X-RateLimit-Remaining") == "0" {
+			return githubErrorRateLimited
+		}
+		return githubErrorPermissionDenied
+	case http.StatusUnprocessableEntity:
+		// Other codes, such as "missing" and "invalid", are problems with the input, which
+		// may name something that does not exist, rather than a resource that is gone
+		for _, e := range ghErr.Errors {
+			if e.Code == "already_exists" {
+				return githubErrorAlreadyExists
+			}
+		}
+		return githubErrorValidationFailed
+	}
+	return githubErrorOther
+}
+
+// isNotFound reports whether err means that the GitHub resource does not exist.
+func isNotFound(err error) bool {
+	return classifyGitHubError(err) == githubErrorNotFound
+}
+
+// isAlreadyExists reports whether err means that the GitHub resource already exists.
+func isAlreadyExists(err error) bool {
+	return classifyGitHubError(err) == githubErrorAlreadyExists
+}
+
+// isReferenceAlreadyExists reports whether err means that the git reference to create
+// already exists. The git references API (POST /repos/{owner}/{repo}/git/refs) reports
+// this as a 422 with the message "Reference already exists" and no error codes.
+func isReferenceAlreadyExists(err error) bool {
+	return isAlreadyExists(err) || (isValidationFailed(err) && hasGitHubErrorMessage(err, "Reference already exists"))
+}
+
+// isReferenceNotFound reports whether err means that the git reference does not exist.
+// Deleting a missing reference with the git references API
+// (DELETE /repos/{owner}/{repo}/git/refs/{ref}) is a 422 with the message "Reference does
+// not exist" and no error codes, rather than a 404.
+func isReferenceNotFound(err error) bool {
+	return isNotFound(err) || (isValidationFailed(err) && hasGitHubErrorMessage(err, "Reference does not exist"))
+}
+
+// isConflict reports whether err is a 409 Conflict, such as a file SHA that no longer matches.
+func isConflict(err error) bool {
+	return classifyGitHubError(err) == githubErrorConflict
+}
+
+// isValidationFailed reports whether err is a 422 that GitHub returned for invalid input.
+func isValidationFailed(err error) bool {
+	return classifyGitHubError(err) == githubErrorValidationFailed
+}
+
+// isMethodNotAllowed reports whether err is a 405 Method Not Allowed, such as a merge
+// method that the repository does not allow.
+func isMethodNotAllowed(err error) bool {
+	return classifyGitHubError(err) == githubErrorMethodNotAllowed
+}
+
+// isPullRequestAlreadyMergeable reports whether err is the GraphQL error that GitHub
+// returns when enabling auto-merge for a pull request that can already be merged, i.e.
+// one in clean or unstable status. GitHub only tells these apart by the message.
+func isPullRequestAlreadyMergeable(err error) bool {
+	var gqlErrs graphQLErrors
+	if !errors.As(err, &gqlErrs) {
+		return false
+	}
+	for _, e := range gqlErrs {
+		if e.Type == "UNPROCESSABLE" && (strings.Contains(e.Message, "clean status") || strings.Contains(e.Message, "unstable status")) {
+			return true
+		}
+	}
+	return false
+}
+
+// githubErrorHint returns advice on how to resolve an error of the given class.
+func githubErrorHint(kind githubErrorKind) string {
+	switch kind {
+	case githubErrorRateLimited:
+		return "The GitHub API rate limit was exceeded. Wait for it to reset before retrying, or authenticate to get a higher limit."
+	case githubErrorPermissionDenied:
+		return "The token was denied access. Check that it has the required scopes or GitHub App permissions for this repository."
+	case githubErrorNotFound:
+		return "The resource was not found. Check that it exists and that the token can access it, as GitHub also returns 404 for private resources the token cannot see."
+	case githubErrorConflict:
+		return "The resource was changed concurrently. Refresh and apply again."
+	}
+	return ""
+}
+
+// addGitHubError adds an error diagnostic for a failed GitHub API call, with advice on
+// how to resolve it for errors that are rate limits, permission problems or the like.
+func addGitHubError(diags *diag.Diagnostics, summary, detail string, err error) {
+	kind := classifyGitHubError(err)
+	if kind == githubErrorRateLimited {
+		summary = "Rate Limit Exceeded"
+	}
+	if hint := githubErrorHint(kind); hint != "" {
+		detail = fmt.Sprintf("%s\n\n%s", detail, hint)
+	}
+	diags.AddError(summary, detail)
+}
+
+// hasGitHubErrorMessage reports whether the message of a GitHub API error, or of one of
+// its detailed errors, contains text.
+func hasGitHubErrorMessage(err error, text string) bool {
+	var ghErr *github.ErrorResponse
+	if !errors.As(err, &ghErr) {
+		return false
+	}
+	if strings.Contains(ghErr.Message, text) {
+		return true
+	}
+	for _, e := range ghErr.Errors {
+		if strings.Contains(e.Message, text) {
+			return true
+		}
+	}
+	return false
+}
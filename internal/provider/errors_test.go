@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestErrorResponse(status int, message string, errs ...github.Error) *github.ErrorResponse {
+	return &github.ErrorResponse{
+		Response: &http.Response{StatusCode: status, Header: http.Header{}, Request: &http.Request{Method: http.MethodGet}},
+		Message:  message,
+		Errors:   errs,
+	}
+}
+
+func TestClassifyGitHubError(t *testing.T) {
+	rateLimited := newTestErrorResponse(http.StatusForbidden, "API rate limit exceeded")
+	rateLimited.Response.Header.Set("X-RateLimit-Remaining", "0")
+
+	tests := []struct {
+		name     string
+		err      error
+		expected githubErrorKind
+	}{
+		{name: "nil", err: nil, expected: githubErrorOther},
+		{name: "not a GitHub error", err: errors.New("connection refused"), expected: githubErrorOther},
+		{name: "not found", err: newTestErrorResponse(http.StatusNotFound, "Not Found"), expected: githubErrorNotFound},
+		{name: "wrapped not found", err: fmt.Errorf("reading: %w", newTestErrorResponse(http.StatusNotFound, "Not Found")), expected: githubErrorNotFound},
+		{name: "reference does not exist", err: newTestErrorResponse(http.StatusUnprocessableEntity, "Reference does not exist"), expected: githubErrorValidationFailed},
+		{name: "reference already exists", err: newTestErrorResponse(http.StatusUnprocessableEntity, "Reference already exists"), expected: githubErrorValidationFailed},
+		{name: "missing base branch", err: newTestErrorResponse(http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "PullRequest", Code: "missing", Field: "base"}), expected: githubErrorValidationFailed},
+		{name: "already exists message without code", err: newTestErrorResponse(http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "PullRequest", Code: "custom", Message: "A pull request already exists for test-owner:feature."}), expected: githubErrorValidationFailed},
+		{name: "already_exists code", err: newTestErrorResponse(http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "Repository", Code: "already_exists", Field: "name"}), expected: githubErrorAlreadyExists},
+		{name: "validation failed", err: newTestErrorResponse(http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "Repository", Code: "invalid", Field: "name"}), expected: githubErrorValidationFailed},
+		{name: "conflict", err: newTestErrorResponse(http.StatusConflict, "is at abc but expected def"), expected: githubErrorConflict},
+		{name: "forbidden", err: newTestErrorResponse(http.StatusForbidden, "Resource not accessible by integration"), expected: githubErrorPermissionDenied},
+		{name: "unauthorized", err: newTestErrorResponse(http.StatusUnauthorized, "Bad credentials"), expected: githubErrorPermissionDenied},
+		{name: "rate limit header", err: rateLimited, expected: githubErrorRateLimited},
+		{name: "too many requests", err: newTestErrorResponse(http.StatusTooManyRequests, "Too Many Requests"), expected: githubErrorRateLimited},
+		{name: "rate limit error", err: &github.RateLimitError{Response: &http.Response{StatusCode: http.StatusForbidden}}, expected: githubErrorRateLimited},
+		{name: "secondary rate limit", err: &github.AbuseRateLimitError{Response: &http.Response{StatusCode: http.StatusForbidden}}, expected: githubErrorRateLimited},
+		{name: "method not allowed", err: newTestErrorResponse(http.StatusMethodNotAllowed, "Merge commits are not allowed on this repository."), expected: githubErrorMethodNotAllowed},
+		{name: "graphql not found", err: graphQLErrors{{Type: "NOT_FOUND", Message: "Could not resolve to a PullRequest"}}, expected: githubErrorNotFound},
+		{name: "graphql forbidden", err: graphQLErrors{{Type: "FORBIDDEN", Message: "Resource not accessible by integration"}}, expected: githubErrorPermissionDenied},
+		{name: "graphql unprocessable", err: fmt.Errorf("enabling: %w", graphQLErrors{{Type: "UNPROCESSABLE", Message: "Pull request is in clean status"}}), expected: githubErrorValidationFailed},
+		{name: "graphql without type", err: graphQLErrors{{Message: "Something went wrong"}}, expected: githubErrorOther},
+		{name: "server error", err: newTestErrorResponse(http.StatusBadGateway, "Bad Gateway"), expected: githubErrorOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, classifyGitHubError(tt.err))
+		})
+	}
+}
+
+func TestHasGitHubErrorMessage(t *testing.T) {
+	err := newTestErrorResponse(http.StatusUnprocessableEntity, "Validation Failed",
+		github.Error{Resource: "PullRequest", Code: "custom", Message: "No commits between main and feature"})
+
+	assert.True(t, hasGitHubErrorMessage(err, "Validation Failed"))
+	assert.True(t, hasGitHubErrorMessage(err, "No commits between"))
+	assert.False(t, hasGitHubErrorMessage(err, "already exists"))
+	assert.False(t, hasGitHubErrorMessage(errors.New("No commits between"), "No commits between"))
+}
+
+func TestIsReferenceError(t *testing.T) {
+	assert.True(t, isReferenceAlreadyExists(newTestErrorResponse(http.StatusUnprocessableEntity, "Reference already exists")))
+	assert.True(t, isReferenceAlreadyExists(newTestErrorResponse(http.StatusUnprocessableEntity, "Validation Failed", github.Error{Code: "already_exists"})))
+	assert.False(t, isReferenceAlreadyExists(newTestErrorResponse(http.StatusUnprocessableEntity, "Object does not exist")))
+
+	assert.True(t, isReferenceNotFound(newTestErrorResponse(http.StatusUnprocessableEntity, "Reference does not exist")))
+	assert.True(t, isReferenceNotFound(newTestErrorResponse(http.StatusNotFound, "Not Found")))
+	assert.False(t, isReferenceNotFound(newTestErrorResponse(http.StatusUnprocessableEntity, "Object does not exist")))
+}
+
+func TestIsPullRequestAlreadyMergeable(t *testing.T) {
+	assert.True(t, isPullRequestAlreadyMergeable(graphQLErrors{{Type: "UNPROCESSABLE", Message: "Pull request Pull request is in clean status"}}))
+	assert.True(t, isPullRequestAlreadyMergeable(fmt.Errorf("enabling: %w", graphQLErrors{{Type: "UNPROCESSABLE", Message: "Pull request Pull request is in unstable status"}})))
+	assert.False(t, isPullRequestAlreadyMergeable(graphQLErrors{{Type: "UNPROCESSABLE", Message: "Pull request is in draft status"}}))
+	assert.False(t, isPullRequestAlreadyMergeable(graphQLErrors{{Type: "FORBIDDEN", Message: "clean status"}}))
+	assert.False(t, isPullRequestAlreadyMergeable(errors.New("Pull request is in clean status")))
+}
+
+func TestAddGitHubError(t *testing.T) {
+	var diags diag.Diagnostics
+	addGitHubError(&diags, "Error reading file", "Unable to read file", newTestErrorResponse(http.StatusTooManyRequests, "Too Many Requests"))
+	assert.Equal(t, "Rate Limit Exceeded", diags[0].Summary())
+	assert.Contains(t, diags[0].Detail(), "Unable to read file")
+	assert.Contains(t, diags[0].Detail(), "rate limit")
+
+	diags = nil
+	addGitHubError(&diags, "Error reading file", "Unable to read file", errors.New("connection refused"))
+	assert.Equal(t, "Error reading file", diags[0].Summary())
+	assert.Equal(t, "Unable to read file", diags[0].Detail())
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// graphQLError is an error returned in the `errors` list of a GraphQL response.
+type graphQLError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// graphQLErrors are the errors of a GraphQL response. They keep the type of each error,
+// so that callers can tell them apart without matching the joined messages.
+type graphQLErrors []graphQLError
+
+func (e graphQLErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// graphQLRequest runs a GraphQL query or mutation with the REST client's transport and
+// authentication, and decodes the `data` of the response into result.
+func graphQLRequest(ctx context.Context, client *github.Client, query string, variables map[string]interface{}, result interface{}) error {
+	// GitHub Enterprise Server serves GraphQL at /api/graphql rather than /api/v3/graphql
+	endpoint := "graphql"
+	if strings.HasSuffix(client.BaseURL.Path, "/api/v3/") {
+		endpoint = "../graphql"
+	}
+
+	req, err := client.NewRequest("POST", endpoint, map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return err
+	}
+
+	var response struct {
+		Data   interface{}    `json:"data"`
+		Errors []graphQLError `json:"errors"`
+	}
+	response.Data = result
+	if _, err := client.Do(ctx, req, &response); err != nil {
+		return err
+	}
+
+	if len(response.Errors) > 0 {
+		return graphQLErrors(response.Errors)
+	}
+	return nil
+}
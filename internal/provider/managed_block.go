@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Lifecycle modes of a repository file.
+const (
+	lifecycleModeManaged     = "managed"
+	lifecycleModeCreateOnly  = "create_only"
+	lifecycleModeAppendBlock = "append_block"
+)
+
+// defaultBlockMarker is the marker line template of a managed block, with `{mark}`
+// replaced by BEGIN and END.
+const defaultBlockMarker = "# {mark} MANAGED BY TERRAFORM"
+
+// blockMarkers returns the begin and end marker lines for a marker template.
+func blockMarkers(marker string) (string, string) {
+	return strings.ReplaceAll(marker, "{mark}", "BEGIN"), strings.ReplaceAll(marker, "{mark}", "END")
+}
+
+// managedBlockContent returns the content of a block as it is written between the
+// markers, which always ends with a newline.
+func managedBlockContent(block []byte) []byte {
+	if len(block) == 0 || block[len(block)-1] == '\n' {
+		return block
+	}
+	return append(append([]byte{}, block...), '\n')
+}
+
+// findManagedBlock returns the offsets of the start and end of the begin marker line and
+// of the end marker line, or false if the file does not contain the block.
+func findManagedBlock(content []byte, marker string) (int, int, int, int, bool) {
+	begin, end := blockMarkers(marker)
+
+	offset := 0
+	beginStart, beginEnd := -1, -1
+	for offset < len(content) {
+		lineEnd := bytes.IndexByte(content[offset:], '\n')
+		next := len(content)
+		if lineEnd >= 0 {
+			next = offset + lineEnd + 1
+		}
+		line := strings.TrimRight(string(content[offset:next]), "\r\n")
+
+		switch {
+		case beginStart < 0 && line == begin:
+			beginStart, beginEnd = offset, next
+		case beginStart >= 0 && line == end:
+			return beginStart, beginEnd, offset, next, true
+		}
+		offset = next
+	}
+	return 0, 0, 0, 0, false
+}
+
+// extractManagedBlock returns the content between the block markers, and whether the
+// markers were found.
+func extractManagedBlock(content []byte, marker string) ([]byte, bool) {
+	_, blockStart, blockEnd, _, ok := findManagedBlock(content, marker)
+	if !ok {
+		return nil, false
+	}
+	return content[blockStart:blockEnd], true
+}
+
+// replaceManagedBlock returns content with the managed block set to block. The block is
+// appended to the end of the file if it is not there yet.
+func replaceManagedBlock(content, block []byte, marker string) []byte {
+	begin, end := blockMarkers(marker)
+	section := []byte(begin + "\n" + string(managedBlockContent(block)) + end + "\n")
+
+	if start, _, _, stop, ok := findManagedBlock(content, marker); ok {
+		result := append([]byte{}, content[:start]...)
+		result = append(result, section...)
+		return append(result, content[stop:]...)
+	}
+
+	result := append([]byte{}, content...)
+	if len(result) > 0 && result[len(result)-1] != '\n' {
+		result = append(result, '\n')
+	}
+	return append(result, section...)
+}
+
+// removeManagedBlock returns content without the managed block and its markers.
+func removeManagedBlock(content []byte, marker string) []byte {
+	start, _, _, stop, ok := findManagedBlock(content, marker)
+	if !ok {
+		return content
+	}
+	result := append([]byte{}, content[:start]...)
+	return append(result, content[stop:]...)
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplaceManagedBlock(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		block    string
+		marker   string
+		expected string
+	}{
+		{
+			name:     "new file",
+			content:  "",
+			block:    "* @team",
+			marker:   defaultBlockMarker,
+			expected: "# BEGIN MANAGED BY TERRAFORM\n* @team\n# END MANAGED BY TERRAFORM\n",
+		},
+		{
+			name:     "appended to existing content",
+			content:  "/docs @writers",
+			block:    "* @team\n",
+			marker:   defaultBlockMarker,
+			expected: "/docs @writers\n# BEGIN MANAGED BY TERRAFORM\n* @team\n# END MANAGED BY TERRAFORM\n",
+		},
+		{
+			name:     "replaced in place",
+			content:  "/docs @writers\n# BEGIN MANAGED BY TERRAFORM\n* @old\n# END MANAGED BY TERRAFORM\n/api @backend\n",
+			block:    "* @team\n",
+			marker:   defaultBlockMarker,
+			expected: "/docs @writers\n# BEGIN MANAGED BY TERRAFORM\n* @team\n# END MANAGED BY TERRAFORM\n/api @backend\n",
+		},
+		{
+			name:     "custom marker with windows line endings",
+			content:  "# Title\r\n<!-- BEGIN badges -->\r\nold\r\n<!-- END badges -->\r\n",
+			block:    "new\n",
+			marker:   "<!-- {mark} badges -->",
+			expected: "# Title\r\n<!-- BEGIN badges -->\nnew\n<!-- END badges -->\n",
+		},
+		{
+			name:     "other markers are left alone",
+			content:  "# BEGIN other\nkeep\n# END other\n",
+			block:    "* @team\n",
+			marker:   defaultBlockMarker,
+			expected: "# BEGIN other\nkeep\n# END other\n# BEGIN MANAGED BY TERRAFORM\n* @team\n# END MANAGED BY TERRAFORM\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := replaceManagedBlock([]byte(tt.content), []byte(tt.block), tt.marker)
+			assert.Equal(t, tt.expected, string(result))
+
+			block, ok := extractManagedBlock(result, tt.marker)
+			assert.True(t, ok)
+			assert.Equal(t, string(managedBlockContent([]byte(tt.block))), string(block))
+		})
+	}
+}
+
+func TestExtractManagedBlock(t *testing.T) {
+	_, ok := extractManagedBlock([]byte("* @team\n"), defaultBlockMarker)
+	assert.False(t, ok)
+
+	// A begin marker without an end marker is not a block
+	_, ok = extractManagedBlock([]byte("# BEGIN MANAGED BY TERRAFORM\n* @team\n"), defaultBlockMarker)
+	assert.False(t, ok)
+
+	block, ok := extractManagedBlock([]byte("# BEGIN MANAGED BY TERRAFORM\n# END MANAGED BY TERRAFORM\n"), defaultBlockMarker)
+	assert.True(t, ok)
+	assert.Empty(t, block)
+}
+
+func TestRemoveManagedBlock(t *testing.T) {
+	content := "/docs @writers\n# BEGIN MANAGED BY TERRAFORM\n* @team\n# END MANAGED BY TERRAFORM\n/api @backend\n"
+	assert.Equal(t, "/docs @writers\n/api @backend\n", string(removeManagedBlock([]byte(content), defaultBlockMarker)))
+	assert.Equal(t, "/docs @writers\n", string(removeManagedBlock([]byte("/docs @writers\n"), defaultBlockMarker)))
+}
@@ -16,10 +16,14 @@ import (
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/go-github/v60/github"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"golang.org/x/oauth2"
 )
@@ -45,12 +49,15 @@ type githubxProvider struct {
 
 // githubxProviderModel maps provider schema data to a Go type.
 type githubxProviderModel struct {
-	Token      types.String  `tfsdk:"token"`
-	OAuthToken types.String  `tfsdk:"oauth_token"`
-	AppAuth    *appAuthModel `tfsdk:"app_auth"`
-	BaseURL    types.String  `tfsdk:"base_url"`
-	Owner      types.String  `tfsdk:"owner"`
-	Insecure   types.Bool    `tfsdk:"insecure"`
+	Token                 types.String        `tfsdk:"token"`
+	OAuthToken            types.String        `tfsdk:"oauth_token"`
+	AppAuth               *appAuthModel       `tfsdk:"app_auth"`
+	BaseURL               types.String        `tfsdk:"base_url"`
+	Owner                 types.String        `tfsdk:"owner"`
+	Insecure              types.Bool          `tfsdk:"insecure"`
+	CommitSigning         *commitSigningModel `tfsdk:"commit_signing"`
+	CommitMessageTemplate types.String        `tfsdk:"commit_message_template"`
+	CommitTrailers        types.List          `tfsdk:"commit_trailers"`
 }
 
 // appAuthModel represents GitHub App authentication configuration.
@@ -64,6 +71,13 @@ type appAuthModel struct {
 type githubxClientData struct {
 	Client *github.Client
 	Owner  string
+	// Signer signs commits created through the Git Data API. It is nil when
+	// commit signing is not configured.
+	Signer github.MessageSigner
+	// CommitMessageTemplate is the default commit message template of file resources.
+	CommitMessageTemplate string
+	// CommitTrailers are appended to the commit messages of file resources.
+	CommitTrailers []string
 }
 
 // Metadata returns the provider type name.
@@ -117,6 +131,45 @@ func (p *githubxProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 				Optional:    true,
 				Description: "Enable insecure mode for testing purposes. This disables TLS certificate verification. Use only in development/testing environments.",
 			},
+			"commit_signing": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Signs the commits that resources create through the Git Data API with a GPG or SSH key, so they show as verified and satisfy rulesets that require signed commits. Commits made with a GitHub App token are signed by GitHub and do not need this.",
+				Attributes: map[string]schema.Attribute{
+					"gpg_private_key": schema.StringAttribute{
+						Optional:    true,
+						Sensitive:   true,
+						Description: "An ASCII-armored GPG private key. Its public key must be added to the GitHub account of the commit author.",
+						Validators: []validator.String{
+							stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("ssh_private_key")),
+						},
+					},
+					"ssh_private_key": schema.StringAttribute{
+						Optional:    true,
+						Sensitive:   true,
+						Description: "An OpenSSH private key. Its public key must be added as a signing key to the GitHub account of the commit author.",
+					},
+					"passphrase": schema.StringAttribute{
+						Optional:    true,
+						Sensitive:   true,
+						Description: "The passphrase of the private key, if it is encrypted.",
+					},
+				},
+			},
+			"commit_message_template": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					commitMessageTemplateValidator{},
+				},
+				Description: "The default commit message of `githubx_repository_file`, as a Go text/template. The template can use `.Action` (create, update or delete), `.Path`, `.Repository` and `.Branch`, and the functions `lower`, `upper`, `title`, `base`, `dir`, `trim`, `replace` and `contains`. For example `chore({{ .Repository }}): {{ .Action }} {{ .Path }}`. Defaults to `Add <path>`, `Update <path>` and `Delete <path>`.",
+			},
+			"commit_trailers": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Trailers appended to the commit messages of `githubx_repository_file`, such as `Signed-off-by: Jane Doe <jane@example.com>`.",
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(commitTrailerPattern, "must be a trailer in the form 'Token: value'")),
+				},
+			},
 		},
 	}
 }
@@ -248,10 +301,30 @@ func (p *githubxProvider) Configure(ctx context.Context, req provider.ConfigureR
 		// If we can't get the user, owner will remain empty and resources can try again
 	}
 
+	signer, err := newCommitSigner(config.CommitSigning)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Commit Signing Key",
+			fmt.Sprintf("Unable to load the commit signing key: %v", err),
+		)
+		return
+	}
+
+	var commitTrailers []string
+	if !config.CommitTrailers.IsNull() && !config.CommitTrailers.IsUnknown() {
+		resp.Diagnostics.Append(config.CommitTrailers.ElementsAs(ctx, &commitTrailers, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Store the client for use in resources and data sources
 	clientData := githubxClientData{
-		Client: client,
-		Owner:  owner,
+		Client:                client,
+		Owner:                 owner,
+		Signer:                signer,
+		CommitMessageTemplate: config.CommitMessageTemplate.ValueString(),
+		CommitTrailers:        commitTrailers,
 	}
 
 	resp.ResourceData = clientData
@@ -273,8 +346,14 @@ func (p *githubxProvider) Resources(_ context.Context) []func() resource.Resourc
 	return []func() resource.Resource{
 		NewRepositoryResource,
 		NewRepositoryBranchResource,
+		NewRepositoryTagResource,
+		NewRepositoryReleaseResource,
 		NewRepositoryFileResource,
+		NewRepositoryPullRequestResource,
 		NewRepositoryPullRequestAutoMergeResource,
+		NewRepositoryActionsSettingsResource,
+		NewRepositoryFilesResource,
+		NewRepositoryDirectoryResource,
 	}
 }
 
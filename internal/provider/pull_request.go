@@ -0,0 +1,573 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// pullRequestModel maps the schema data that the pull request resources have in common.
+type pullRequestModel struct {
+	Repository          types.String `tfsdk:"repository"`
+	BaseRef             types.String `tfsdk:"base_ref"`
+	HeadRef             types.String `tfsdk:"head_ref"`
+	Title               types.String `tfsdk:"title"`
+	Body                types.String `tfsdk:"body"`
+	MaintainerCanModify types.Bool   `tfsdk:"maintainer_can_modify"`
+	BaseSHA             types.String `tfsdk:"base_sha"`
+	HeadSHA             types.String `tfsdk:"head_sha"`
+	Number              types.Int64  `tfsdk:"number"`
+	State               types.String `tfsdk:"state"`
+	Merged              types.Bool   `tfsdk:"merged"`
+	MergedAt            types.String `tfsdk:"merged_at"`
+	MergeCommitSHA      types.String `tfsdk:"merge_commit_sha"`
+	ID                  types.String `tfsdk:"id"`
+}
+
+// pullRequestSchemaAttributes returns the schema attributes that the pull request
+// resources have in common, which map to pullRequestModel.
+func pullRequestSchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"repository": schema.StringAttribute{
+			Description: "The GitHub repository name.",
+			Required:    true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"base_ref": schema.StringAttribute{
+			Description: "The base branch name (e.g., 'main', 'develop').",
+			Required:    true,
+		},
+		"head_ref": schema.StringAttribute{
+			Description: "The head branch name (e.g., 'feature-branch').",
+			Required:    true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"title": schema.StringAttribute{
+			Description: "The title of the pull request.",
+			Required:    true,
+		},
+		"body": schema.StringAttribute{
+			Description: "The body/description of the pull request.",
+			Optional:    true,
+		},
+		"maintainer_can_modify": schema.BoolAttribute{
+			Description: "Allow maintainers to modify the pull request.",
+			Optional:    true,
+			Computed:    true,
+			Default:     booldefault.StaticBool(false),
+		},
+		"base_sha": schema.StringAttribute{
+			Description: "The SHA of the base branch.",
+			Computed:    true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"head_sha": schema.StringAttribute{
+			Description: "The SHA of the head branch.",
+			Computed:    true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"number": schema.Int64Attribute{
+			Description: "The pull request number.",
+			Computed:    true,
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+		},
+		"state": schema.StringAttribute{
+			Description: "The state of the pull request (open, closed, merged).",
+			Computed:    true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"merged": schema.BoolAttribute{
+			Description: "Whether the pull request has been merged.",
+			Computed:    true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"merged_at": schema.StringAttribute{
+			Description: "The timestamp when the pull request was merged.",
+			Computed:    true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"merge_commit_sha": schema.StringAttribute{
+			Description: "The SHA of the merge commit.",
+			Computed:    true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"id": schema.StringAttribute{
+			Description: "The Terraform state ID (repository:number).",
+			Computed:    true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+	}
+}
+
+// planPullRequestUpdate marks the computed attributes that an update changes as unknown,
+// as UseStateForUnknown otherwise plans their prior values: the base SHA when the base
+// branch changes, and the merge attributes when the update may merge the pull request.
+func planPullRequestUpdate(plan, state *pullRequestModel, mayMerge bool) {
+	if !plan.BaseRef.Equal(state.BaseRef) {
+		plan.BaseSHA = types.StringUnknown()
+	}
+	if mayMerge {
+		plan.State = types.StringUnknown()
+		plan.Merged = types.BoolUnknown()
+		plan.MergedAt = types.StringUnknown()
+		plan.MergeCommitSHA = types.StringUnknown()
+	}
+}
+
+// parsePullRequestID parses a pull request ID in the format "repository:number".
+func parsePullRequestID(id string) (string, int, error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) != 2 {
+		return "", 0, fmt.Errorf("unexpected format of ID (%s), expected repository:number", id)
+	}
+	number, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid pull request number in ID (%s): %w", id, err)
+	}
+	return parts[0], number, nil
+}
+
+// openPullRequest opens the pull request described by newPR. An existing pull request
+// between the same branches is adopted instead, and reopened if it was closed without
+// being merged.
+func openPullRequest(ctx context.Context, client *github.Client, owner, repoName string, newPR *github.NewPullRequest, diags *diag.Diagnostics) *github.PullRequest {
+	baseRef := newPR.GetBase()
+	headRef := newPR.GetHead()
+
+	if baseRef == headRef {
+		diags.AddError(
+			"Invalid Configuration",
+			fmt.Sprintf("Base branch '%s' and head branch '%s' cannot be the same. There must be a difference to create a pull request.", baseRef, headRef),
+		)
+		return nil
+	}
+
+	existingPR, err := findPullRequest(ctx, client, owner, repoName, baseRef, headRef, "all")
+	if err != nil {
+		addGitHubError(diags,
+			"Error checking for existing pull request",
+			fmt.Sprintf("Unable to check for existing pull request in repository %s/%s: %v", owner, repoName, err),
+			err,
+		)
+		return nil
+	}
+
+	if existingPR != nil {
+		if existingPR.GetState() == "closed" && !existingPR.GetMerged() {
+			// Reopen the closed PR
+			log.Printf("[INFO] Reopening closed pull request #%d from '%s' to '%s' in repository %s/%s", existingPR.GetNumber(), headRef, baseRef, owner, repoName)
+			update := &github.PullRequest{
+				State: github.String("open"),
+			}
+			reopenedPR, _, err := client.PullRequests.Edit(ctx, owner, repoName, existingPR.GetNumber(), update)
+			if err != nil {
+				addGitHubError(diags,
+					"Error reopening pull request",
+					fmt.Sprintf("Unable to reopen pull request #%d in repository %s/%s: %v", existingPR.GetNumber(), owner, repoName, err),
+					err,
+				)
+				return nil
+			}
+			return reopenedPR
+		}
+		// Adopt the existing PR - this handles the case where PR was created outside Terraform
+		// or if terraform apply is run again after the PR was already created
+		log.Printf("[INFO] Adopting existing pull request #%d from '%s' to '%s' in repository %s/%s", existingPR.GetNumber(), headRef, baseRef, owner, repoName)
+		return existingPR
+	}
+
+	// No existing PR found, create a new one
+	baseSHA, headSHA, err := pullRequestBranchSHAs(ctx, client, owner, repoName, baseRef, headRef)
+	if err != nil {
+		addGitHubError(diags,
+			"Error checking branch differences",
+			fmt.Sprintf("Unable to get branch information: %v", err),
+			err,
+		)
+		return nil
+	}
+
+	if baseSHA == headSHA {
+		diags.AddError(
+			"No Differences",
+			fmt.Sprintf("Branches '%s' and '%s' are at the same commit (SHA: %s). There are no changes to create a pull request.", headRef, baseRef, headSHA),
+		)
+		return nil
+	}
+
+	pr, _, err := client.PullRequests.Create(ctx, owner, repoName, newPR)
+	if err != nil {
+		// GitHub reports both with the "custom" error code, so only the messages tell them apart
+		if isValidationFailed(err) && (hasGitHubErrorMessage(err, "A pull request already exists") || hasGitHubErrorMessage(err, "No commits between")) {
+			diags.AddError(
+				"Pull Request Already Exists or No Changes",
+				fmt.Sprintf("Unable to create pull request: %v. A pull request may already exist, or there are no commits between '%s' and '%s'.", err, headRef, baseRef),
+			)
+			return nil
+		}
+		addGitHubError(diags,
+			"Error creating pull request",
+			fmt.Sprintf("Unable to create pull request in repository %s/%s: %v", owner, repoName, err),
+			err,
+		)
+		return nil
+	}
+	return pr
+}
+
+// editPullRequest updates the title, body, base branch and maintainer setting of a pull
+// request to match the plan.
+func editPullRequest(ctx context.Context, client *github.Client, owner, repoName string, number int, plan, state *pullRequestModel, diags *diag.Diagnostics) {
+	update := &github.PullRequest{
+		Title:               github.String(plan.Title.ValueString()),
+		MaintainerCanModify: github.Bool(plan.MaintainerCanModify.ValueBool()),
+	}
+
+	if !plan.Body.IsNull() && !plan.Body.IsUnknown() {
+		update.Body = github.String(plan.Body.ValueString())
+	}
+
+	if !plan.BaseRef.Equal(state.BaseRef) {
+		update.Base = &github.PullRequestBranch{
+			Ref: github.String(plan.BaseRef.ValueString()),
+		}
+	}
+
+	_, _, err := client.PullRequests.Edit(ctx, owner, repoName, number, update)
+	if err != nil {
+		addGitHubError(diags,
+			"Error updating pull request",
+			fmt.Sprintf("Unable to update pull request #%d in repository %s/%s: %v", number, owner, repoName, err),
+			err,
+		)
+	}
+}
+
+// closePullRequest closes a pull request unless it is merged or closed already.
+func closePullRequest(ctx context.Context, client *github.Client, owner, repoName string, number int, diags *diag.Diagnostics) {
+	pr, _, err := client.PullRequests.Get(ctx, owner, repoName, number)
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[INFO] Pull request #%d not found, assuming already deleted", number)
+			return
+		}
+		addGitHubError(diags,
+			"Error reading pull request",
+			fmt.Sprintf("Unable to read pull request #%d from repository %s/%s: %v", number, owner, repoName, err),
+			err,
+		)
+		return
+	}
+
+	// If PR is already merged, don't close it - just cleanup
+	if pr.GetMerged() {
+		log.Printf("[INFO] Pull request #%d is already merged, skipping close operation", number)
+		return
+	}
+
+	// Close the PR if it's still open
+	if pr.GetState() == "open" {
+		update := &github.PullRequest{State: github.String("closed")}
+		_, _, err = client.PullRequests.Edit(ctx, owner, repoName, number, update)
+		if err != nil {
+			addGitHubError(diags,
+				"Error closing pull request",
+				fmt.Sprintf("Unable to close pull request #%d in repository %s/%s: %v", number, owner, repoName, err),
+				err,
+			)
+			return
+		}
+		log.Printf("[INFO] Closed pull request #%d", number)
+	} else {
+		log.Printf("[INFO] Pull request #%d is already closed", number)
+	}
+}
+
+// readPullRequest reads a pull request from GitHub and populates the common fields of the
+// model. It returns the pull request, or nil with the model ID cleared if it no longer exists.
+func readPullRequest(ctx context.Context, client *github.Client, owner, repoName string, number int, model *pullRequestModel, diags *diag.Diagnostics) *github.PullRequest {
+	pr, _, err := client.PullRequests.Get(ctx, owner, repoName, number)
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[INFO] Pull request #%d not found, removing from state", number)
+			model.ID = types.StringValue("")
+			return nil
+		}
+		addGitHubError(diags,
+			"Error reading pull request",
+			fmt.Sprintf("Unable to read pull request #%d from repository %s/%s: %v", number, owner, repoName, err),
+			err,
+		)
+		return nil
+	}
+
+	model.Repository = types.StringValue(repoName)
+	model.Number = types.Int64Value(int64(pr.GetNumber()))
+	model.Title = types.StringValue(pr.GetTitle())
+	// GitHub reports a pull request without a body as having an empty one
+	if pr.GetBody() != "" || !model.Body.IsNull() {
+		model.Body = types.StringValue(pr.GetBody())
+	}
+	model.State = types.StringValue(pr.GetState())
+	model.Merged = types.BoolValue(pr.GetMerged())
+	model.MaintainerCanModify = types.BoolValue(pr.GetMaintainerCanModify())
+
+	mergedAt := pr.GetMergedAt()
+	if !mergedAt.IsZero() && pr.GetMerged() {
+		model.MergedAt = types.StringValue(mergedAt.Format(time.RFC3339))
+	} else {
+		model.MergedAt = types.StringNull()
+	}
+
+	mergeCommitSHA := pr.GetMergeCommitSHA()
+	if mergeCommitSHA != "" && pr.GetMerged() {
+		model.MergeCommitSHA = types.StringValue(mergeCommitSHA)
+	} else {
+		model.MergeCommitSHA = types.StringNull()
+	}
+
+	if head := pr.GetHead(); head != nil {
+		model.HeadRef = types.StringValue(head.GetRef())
+		model.HeadSHA = types.StringValue(head.GetSHA())
+	}
+
+	if base := pr.GetBase(); base != nil {
+		model.BaseRef = types.StringValue(base.GetRef())
+		model.BaseSHA = types.StringValue(base.GetSHA())
+	}
+	return pr
+}
+
+// pullRequestBranchSHAs returns the head commits of the base and head branches of a pull request.
+func pullRequestBranchSHAs(ctx context.Context, client *github.Client, owner, repoName, baseRef, headRef string) (string, string, error) {
+	baseRefFull := fmt.Sprintf("refs/heads/%s", baseRef)
+	headRefFull := fmt.Sprintf("refs/heads/%s", headRef)
+
+	baseRefObj, _, err := client.Git.GetRef(ctx, owner, repoName, baseRefFull)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to get base branch %s: %w", baseRef, err)
+	}
+
+	headRefObj, _, err := client.Git.GetRef(ctx, owner, repoName, headRefFull)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to get head branch %s: %w", headRef, err)
+	}
+
+	baseSHA := baseRefObj.GetObject().GetSHA()
+	headSHA := headRefObj.GetObject().GetSHA()
+
+	return baseSHA, headSHA, nil
+}
+
+// findPullRequest returns the most recent pull request from headRef into baseRef in the
+// given state ("open", "closed" or "all"), or nil if there is none.
+func findPullRequest(ctx context.Context, client *github.Client, owner, repoName, baseRef, headRef, state string) (*github.PullRequest, error) {
+	opts := &github.PullRequestListOptions{
+		State:       state,
+		Head:        fmt.Sprintf("%s:%s", owner, headRef),
+		Base:        baseRef,
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	prs, _, err := client.PullRequests.List(ctx, owner, repoName, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pr := range prs {
+		if pr.GetBase().GetRef() == baseRef && pr.GetHead().GetRef() == headRef {
+			return pr, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// openOrUpdatePullRequest makes sure there is an open pull request from headRef into
+// baseRef with the given title and body, editing an existing one rather than opening a
+// second. It returns nil if there are no commits between the branches.
+func openOrUpdatePullRequest(ctx context.Context, client *github.Client, owner, repoName, baseRef, headRef, title, body string) (*github.PullRequest, error) {
+	pr, err := findPullRequest(ctx, client, owner, repoName, baseRef, headRef, "open")
+	if err != nil {
+		return nil, fmt.Errorf("unable to list pull requests: %w", err)
+	}
+
+	if pr != nil {
+		if pr.GetTitle() == title && pr.GetBody() == body {
+			return pr, nil
+		}
+		number := pr.GetNumber()
+		pr, _, err = client.PullRequests.Edit(ctx, owner, repoName, number, &github.PullRequest{
+			Title: github.String(title),
+			Body:  github.String(body),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to update pull request #%d: %w", number, err)
+		}
+		return pr, nil
+	}
+
+	pr, _, err = client.PullRequests.Create(ctx, owner, repoName, &github.NewPullRequest{
+		Title: github.String(title),
+		Head:  github.String(headRef),
+		Base:  github.String(baseRef),
+		Body:  github.String(body),
+	})
+	if err != nil {
+		if isValidationFailed(err) && hasGitHubErrorMessage(err, "No commits between") {
+			log.Printf("[DEBUG] No commits between %s and %s in %s/%s, not opening a pull request", baseRef, headRef, owner, repoName)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to create pull request: %w", err)
+	}
+	log.Printf("[INFO] Opened pull request #%d in %s/%s", pr.GetNumber(), owner, repoName)
+	return pr, nil
+}
+
+// enablePullRequestAutoMerge turns on GitHub's auto-merge for a pull request, so that it
+// is merged with mergeMethod once its requirements are met. GitHub refuses to enable
+// auto-merge for a pull request that can already be merged, so such pull requests are
+// merged straight away. It returns whether the pull request was merged.
+func enablePullRequestAutoMerge(ctx context.Context, client *github.Client, owner, repoName string, pr *github.PullRequest, mergeMethod string) (bool, error) {
+	if mergeMethod == "" {
+		mergeMethod = "merge"
+	}
+
+	const mutation = `mutation($pullRequestId: ID!, $mergeMethod: PullRequestMergeMethod!) {
+  enablePullRequestAutoMerge(input: {pullRequestId: $pullRequestId, mergeMethod: $mergeMethod}) {
+    clientMutationId
+  }
+}`
+	err := graphQLRequest(ctx, client, mutation, map[string]interface{}{
+		"pullRequestId": pr.GetNodeID(),
+		"mergeMethod":   strings.ToUpper(mergeMethod),
+	}, nil)
+	if err == nil {
+		log.Printf("[INFO] Enabled auto-merge for pull request #%d in %s/%s", pr.GetNumber(), owner, repoName)
+		return false, nil
+	}
+	if !isPullRequestAlreadyMergeable(err) {
+		return false, fmt.Errorf("unable to enable auto-merge for pull request #%d: %w", pr.GetNumber(), err)
+	}
+
+	log.Printf("[DEBUG] Pull request #%d can already be merged, merging it now", pr.GetNumber())
+	if _, _, err := client.PullRequests.Merge(ctx, owner, repoName, pr.GetNumber(), "", &github.PullRequestOptions{
+		MergeMethod: mergeMethod,
+	}); err != nil {
+		return false, fmt.Errorf("unable to merge pull request #%d: %w", pr.GetNumber(), err)
+	}
+	return true, nil
+}
+
+// disablePullRequestAutoMerge turns off GitHub's auto-merge for a pull request.
+func disablePullRequestAutoMerge(ctx context.Context, client *github.Client, owner, repoName string, pr *github.PullRequest) error {
+	const mutation = `mutation($pullRequestId: ID!) {
+  disablePullRequestAutoMerge(input: {pullRequestId: $pullRequestId}) {
+    clientMutationId
+  }
+}`
+	err := graphQLRequest(ctx, client, mutation, map[string]interface{}{
+		"pullRequestId": pr.GetNodeID(),
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("unable to disable auto-merge for pull request #%d: %w", pr.GetNumber(), err)
+	}
+	log.Printf("[INFO] Disabled auto-merge for pull request #%d in %s/%s", pr.GetNumber(), owner, repoName)
+	return nil
+}
+
+// pullRequestReviewDecision returns whether the reviews of a pull request meet the review
+// requirements of its base branch: "APPROVED", "CHANGES_REQUESTED" or "REVIEW_REQUIRED".
+// Pull requests into branches that require no reviews are approved once somebody has
+// approved them, and nobody's latest review requests changes.
+func pullRequestReviewDecision(ctx context.Context, client *github.Client, owner, repoName string, number int) (string, error) {
+	const query = `query($owner: String!, $name: String!, $number: Int!) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      reviewDecision
+    }
+  }
+}`
+	var result struct {
+		Repository struct {
+			PullRequest struct {
+				ReviewDecision *string `json:"reviewDecision"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	}
+	err := graphQLRequest(ctx, client, query, map[string]interface{}{
+		"owner":  owner,
+		"name":   repoName,
+		"number": number,
+	}, &result)
+	if err != nil {
+		return "", fmt.Errorf("unable to get the review decision of pull request #%d: %w", number, err)
+	}
+	if decision := result.Repository.PullRequest.ReviewDecision; decision != nil {
+		return *decision, nil
+	}
+
+	// The latest review of each reviewer decides
+	latest := map[string]string{}
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		reviews, resp, err := client.PullRequests.ListReviews(ctx, owner, repoName, number, opts)
+		if err != nil {
+			return "", fmt.Errorf("unable to list the reviews of pull request #%d: %w", number, err)
+		}
+		for _, review := range reviews {
+			switch state := review.GetState(); state {
+			case "APPROVED", "CHANGES_REQUESTED", "DISMISSED":
+				latest[review.GetUser().GetLogin()] = state
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	decision := "REVIEW_REQUIRED"
+	for _, state := range latest {
+		switch state {
+		case "CHANGES_REQUESTED":
+			return state, nil
+		case "APPROVED":
+			decision = state
+		}
+	}
+	return decision, nil
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakePullRequests is a fake GitHub API for the pull requests of test-owner/test-repo.
+type fakePullRequests struct {
+	mu            sync.Mutex
+	pulls         []*github.PullRequest
+	noCommits     bool
+	cleanStatus   bool
+	graphQLInputs []map[string]interface{}
+	merged        []string
+}
+
+func (f *fakePullRequests) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	writeJSON := func(status int, v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(v)
+	}
+
+	switch {
+	case r.URL.Path == "/repos/test-owner/test-repo/pulls" && r.Method == http.MethodGet:
+		var open []*github.PullRequest
+		for _, pr := range f.pulls {
+			if r.URL.Query().Get("state") == "all" || pr.GetState() == r.URL.Query().Get("state") {
+				open = append(open, pr)
+			}
+		}
+		writeJSON(http.StatusOK, open)
+	case r.URL.Path == "/repos/test-owner/test-repo/pulls" && r.Method == http.MethodPost:
+		if f.noCommits {
+			writeJSON(http.StatusUnprocessableEntity, map[string]interface{}{
+				"message": "Validation Failed",
+				"errors":  []map[string]string{{"resource": "PullRequest", "code": "custom", "message": "No commits between main and githubx/README.md"}},
+			})
+			return
+		}
+		var body github.NewPullRequest
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		pr := &github.PullRequest{
+			Number: github.Int(len(f.pulls) + 1),
+			NodeID: github.String("PR_node"),
+			State:  github.String("open"),
+			Title:  body.Title,
+			Body:   body.Body,
+			Head:   &github.PullRequestBranch{Ref: body.Head},
+			Base:   &github.PullRequestBranch{Ref: body.Base},
+		}
+		f.pulls = append(f.pulls, pr)
+		writeJSON(http.StatusCreated, pr)
+	case r.URL.Path == "/repos/test-owner/test-repo/pulls/1" && r.Method == http.MethodGet:
+		writeJSON(http.StatusOK, f.pulls[0])
+	case strings.HasPrefix(r.URL.Path, "/repos/test-owner/test-repo/git/ref/heads/"):
+		branch := strings.TrimPrefix(r.URL.Path, "/repos/test-owner/test-repo/git/ref/heads/")
+		writeJSON(http.StatusOK, map[string]interface{}{"ref": "refs/heads/" + branch, "object": map[string]string{"sha": branch + "-sha"}})
+	case r.URL.Path == "/repos/test-owner/test-repo/pulls/1" && r.Method == http.MethodPatch:
+		var body github.PullRequest
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.pulls[0].Title = body.Title
+		f.pulls[0].Body = body.Body
+		writeJSON(http.StatusOK, f.pulls[0])
+	case r.URL.Path == "/repos/test-owner/test-repo/pulls/1/merge" && r.Method == http.MethodPut:
+		var body struct {
+			MergeMethod string `json:"merge_method"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.merged = append(f.merged, body.MergeMethod)
+		writeJSON(http.StatusOK, map[string]interface{}{"merged": true})
+	case r.URL.Path == "/graphql" && r.Method == http.MethodPost:
+		var body struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.graphQLInputs = append(f.graphQLInputs, body.Variables)
+		if f.cleanStatus {
+			writeJSON(http.StatusOK, map[string]interface{}{
+				"data":   map[string]interface{}{"enablePullRequestAutoMerge": nil},
+				"errors": []map[string]string{{"type": "UNPROCESSABLE", "message": "Pull request Pull request is in clean status"}},
+			})
+			return
+		}
+		if len(f.pulls) > 0 {
+			if mergeMethod, ok := body.Variables["mergeMethod"].(string); ok {
+				f.pulls[0].AutoMerge = &github.PullRequestAutoMerge{MergeMethod: github.String(strings.ToLower(mergeMethod))}
+			} else {
+				f.pulls[0].AutoMerge = nil
+			}
+		}
+		writeJSON(http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"enablePullRequestAutoMerge": map[string]interface{}{"clientMutationId": nil}}})
+	default:
+		writeJSON(http.StatusNotFound, map[string]string{"message": "Not Found"})
+	}
+}
+
+func TestOpenOrUpdatePullRequest(t *testing.T) {
+	fake := &fakePullRequests{}
+	client := newTestGitHubClient(t, fake)
+
+	// Opens a pull request when there is none
+	pr, err := openOrUpdatePullRequest(t.Context(), client, "test-owner", "test-repo", "main", "githubx/README.md", "Update README.md", "")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, pr.GetNumber())
+	assert.Len(t, fake.pulls, 1)
+
+	// Edits the open pull request rather than opening another
+	pr, err = openOrUpdatePullRequest(t.Context(), client, "test-owner", "test-repo", "main", "githubx/README.md", "Rewrite README.md", "Details")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, pr.GetNumber())
+	assert.Len(t, fake.pulls, 1)
+	assert.Equal(t, "Rewrite README.md", fake.pulls[0].GetTitle())
+	assert.Equal(t, "Details", fake.pulls[0].GetBody())
+
+	// Nothing to propose once the target branch has the change
+	fake.pulls[0].State = github.String("closed")
+	fake.noCommits = true
+	pr, err = openOrUpdatePullRequest(t.Context(), client, "test-owner", "test-repo", "main", "githubx/README.md", "Update README.md", "")
+	assert.NoError(t, err)
+	assert.Nil(t, pr)
+}
+
+func TestEnablePullRequestAutoMerge(t *testing.T) {
+	pr := &github.PullRequest{Number: github.Int(1), NodeID: github.String("PR_node")}
+
+	t.Run("enabled", func(t *testing.T) {
+		fake := &fakePullRequests{}
+		merged, err := enablePullRequestAutoMerge(t.Context(), newTestGitHubClient(t, fake), "test-owner", "test-repo", pr, "squash")
+		assert.NoError(t, err)
+		assert.False(t, merged)
+		assert.Equal(t, []map[string]interface{}{{"pullRequestId": "PR_node", "mergeMethod": "SQUASH"}}, fake.graphQLInputs)
+		assert.Empty(t, fake.merged)
+	})
+
+	t.Run("merged when already mergeable", func(t *testing.T) {
+		fake := &fakePullRequests{cleanStatus: true}
+		merged, err := enablePullRequestAutoMerge(t.Context(), newTestGitHubClient(t, fake), "test-owner", "test-repo", pr, "")
+		assert.NoError(t, err)
+		assert.True(t, merged)
+		assert.Equal(t, []string{"merge"}, fake.merged)
+	})
+}
@@ -2,12 +2,19 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"os"
+	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/go-github/v60/github"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -25,6 +32,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -32,6 +40,7 @@ var (
 	_ resource.Resource                = &repositoryResource{}
 	_ resource.ResourceWithConfigure   = &repositoryResource{}
 	_ resource.ResourceWithImportState = &repositoryResource{}
+	_ resource.ResourceWithModifyPlan  = &repositoryResource{}
 )
 
 // NewRepositoryResource is a helper function to simplify the provider implementation.
@@ -49,6 +58,8 @@ type repositoryResource struct {
 // repositoryResourceModel maps the resource schema data.
 type repositoryResourceModel struct {
 	Name                     types.String `tfsdk:"name"`
+	Owner                    types.String `tfsdk:"owner"`
+	TeamIDs                  types.Set    `tfsdk:"team_ids"`
 	Description              types.String `tfsdk:"description"`
 	HomepageURL              types.String `tfsdk:"homepage_url"`
 	Visibility               types.String `tfsdk:"visibility"`
@@ -69,6 +80,8 @@ type repositoryResourceModel struct {
 	MergeCommitMessage       types.String `tfsdk:"merge_commit_message"`
 	DeleteBranchOnMerge      types.Bool   `tfsdk:"delete_branch_on_merge"`
 	ArchiveOnDestroy         types.Bool   `tfsdk:"archive_on_destroy"`
+	DeletionProtection       types.Bool   `tfsdk:"deletion_protection"`
+	BackupOnDestroyPath      types.String `tfsdk:"backup_on_destroy_path"`
 	Archived                 types.Bool   `tfsdk:"archived"`
 	AutoInit                 types.Bool   `tfsdk:"auto_init"`
 	Pages                    types.Object `tfsdk:"pages"`
@@ -77,6 +90,7 @@ type repositoryResourceModel struct {
 	ID                       types.String `tfsdk:"id"`
 	FullName                 types.String `tfsdk:"full_name"`
 	DefaultBranch            types.String `tfsdk:"default_branch"`
+	RenameDefaultBranch      types.Bool   `tfsdk:"rename_default_branch"`
 	HTMLURL                  types.String `tfsdk:"html_url"`
 	NodeID                   types.String `tfsdk:"node_id"`
 	RepoID                   types.Int64  `tfsdk:"repo_id"`
@@ -93,7 +107,7 @@ func (r *repositoryResource) Schema(_ context.Context, _ resource.SchemaRequest,
 		Description: "Creates and manages a GitHub repository.",
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
-				Description: "The name of the repository.",
+				Description: "The name of the repository. Changing this renames the repository in place.",
 				Required:    true,
 				Validators: []validator.String{
 					stringvalidator.RegexMatches(
@@ -101,10 +115,20 @@ func (r *repositoryResource) Schema(_ context.Context, _ resource.SchemaRequest,
 						"must include only alphanumeric characters, underscores or hyphens and consist of 100 characters or less",
 					),
 				},
+			},
+			"owner": schema.StringAttribute{
+				Description: "The owner (user or organization) of the repository. Defaults to the provider-level `owner`. Changing this transfers the repository to the new owner instead of recreating it.",
+				Optional:    true,
+				Computed:    true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"team_ids": schema.SetAttribute{
+				Description: "The IDs of teams in the new organization that should be granted access when the repository is transferred. Only used when `owner` changes.",
+				ElementType: types.Int64Type,
+				Optional:    true,
+			},
 			"description": schema.StringAttribute{
 				Description: "A description of the repository.",
 				Optional:    true,
@@ -270,8 +294,19 @@ func (r *repositoryResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Computed:    true,
 				Default:     booldefault.StaticBool(false),
 			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Whether Terraform is prevented from destroying the repository. While this is `true`, any plan that destroys or replaces the repository fails. Set it to `false` and apply before destroying.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"backup_on_destroy_path": schema.StringAttribute{
+				Description: "A local directory to write a backup to before the repository is deleted. The backup consists of a tarball of the default branch and a JSON file with the repository metadata. Not used when `archive_on_destroy` is `true`.",
+				Optional:    true,
+			},
 			"archived": schema.BoolAttribute{
-				Description: "Whether the repository is archived.",
+				Description: "Whether the repository is archived. GitHub does not allow changes to archived repositories, so when other attributes change the repository is unarchived, updated and archived again.",
+				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Bool{
 					boolplanmodifier.UseStateForUnknown(),
@@ -338,7 +373,7 @@ func (r *repositoryResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				},
 			},
 			"id": schema.StringAttribute{
-				Description: "The repository name (same as `name`).",
+				Description: "The GitHub repository ID. It does not change when the repository is renamed or transferred. Repositories can be imported by ID with an import ID of the form `id:<repository ID>`.",
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
@@ -352,12 +387,19 @@ func (r *repositoryResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				},
 			},
 			"default_branch": schema.StringAttribute{
-				Description: "The default branch of the repository.",
+				Description: "The default branch of the repository. When set, the branch is created from the current default branch if it does not exist and is then made the default. The repository must have at least one commit, for example through `auto_init`.",
+				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"rename_default_branch": schema.BoolAttribute{
+				Description: "Rename the current default branch to `default_branch` instead of creating `default_branch` from it, if `default_branch` does not exist yet. GitHub redirects the old branch name, retargets open pull requests and moves branch protection rules to the renamed branch. Defaults to \"false\".",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
 			"html_url": schema.StringAttribute{
 				Description: "The HTML URL of the repository.",
 				Computed:    true,
@@ -412,6 +454,58 @@ func (r *repositoryResource) Configure(ctx context.Context, req resource.Configu
 	}
 }
 
+// ModifyPlan warns when changes require an archived repository to be
+// unarchived, and rejects plans that would destroy or replace a repository
+// with deletion protection enabled.
+func (r *repositoryResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	if !req.Plan.Raw.IsNull() && len(resp.RequiresReplace) == 0 {
+		// State written by earlier versions used the repository name as the ID.
+		// It is replaced by the repository ID on the next refresh or apply.
+		var stateID types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &stateID)...)
+		if _, err := strconv.ParseInt(stateID.ValueString(), 10, 64); err != nil {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+		}
+
+		var stateArchived, planArchived types.Bool
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("archived"), &stateArchived)...)
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("archived"), &planArchived)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if stateArchived.ValueBool() && (planArchived.IsUnknown() || planArchived.ValueBool()) {
+			changedAttributes, diags := repositoryRemoteChanges(req.Plan.Raw, req.State.Raw)
+			resp.Diagnostics.Append(diags...)
+			if len(changedAttributes) > 0 {
+				resp.Diagnostics.AddWarning(
+					"Archived repository will be temporarily unarchived",
+					fmt.Sprintf("GitHub does not allow changes to archived repositories. To apply changes to %s, the repository will be unarchived, updated and archived again. This requires admin access to the repository.", strings.Join(changedAttributes, ", ")),
+				)
+			}
+		}
+	}
+
+	var deletionProtection types.Bool
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("deletion_protection"), &deletionProtection)...)
+	if resp.Diagnostics.HasError() || !deletionProtection.ValueBool() {
+		return
+	}
+
+	if req.Plan.Raw.IsNull() || len(resp.RequiresReplace) > 0 {
+		var name types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &name)...)
+		resp.Diagnostics.AddError(
+			"Repository is protected from deletion",
+			fmt.Sprintf("Repository %s has `deletion_protection` set to true. Set `deletion_protection = false` and apply before destroying or replacing the repository.", name.ValueString()),
+		)
+	}
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *repositoryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan repositoryResourceModel
@@ -430,8 +524,8 @@ func (r *repositoryResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
-	// Get owner, falling back to authenticated user if not set
-	owner, err := r.getOwner(ctx)
+	// Get owner from the plan, falling back to the provider-level owner
+	owner, err := r.resolveOwner(ctx, plan.Owner)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Missing Owner",
@@ -561,9 +655,10 @@ func (r *repositoryResource) Create(ctx context.Context, req resource.CreateRequ
 	}
 	repo, _, err := r.client.Repositories.Create(ctx, createOwner, repoReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addGitHubError(&resp.Diagnostics,
 			"Error creating repository",
 			fmt.Sprintf("Unable to create repository %s: %v", plan.Name.ValueString(), err),
+			err,
 		)
 		return
 	}
@@ -598,6 +693,21 @@ func (r *repositoryResource) Create(ctx context.Context, req resource.CreateRequ
 		resp.Diagnostics.Append(diags...)
 	}
 
+	if !plan.DefaultBranch.IsNull() && !plan.DefaultBranch.IsUnknown() {
+		r.setDefaultBranch(ctx, owner, repo.GetName(), plan.DefaultBranch.ValueString(), plan.RenameDefaultBranch.ValueBool(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Archive last, as GitHub rejects changes to archived repositories
+	if !plan.Archived.IsNull() && !plan.Archived.IsUnknown() && plan.Archived.ValueBool() {
+		r.setArchived(ctx, owner, repo.GetName(), true, nil, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	explicitHasWiki := plan.HasWiki
 	explicitHasIssues := plan.HasIssues
 	explicitHasProjects := plan.HasProjects
@@ -675,8 +785,8 @@ func (r *repositoryResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	// Get owner, falling back to authenticated user if not set
-	owner, err := r.getOwner(ctx)
+	// Get owner from state, falling back to the provider-level owner
+	owner, err := r.resolveOwner(ctx, state.Owner)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Missing Owner",
@@ -685,11 +795,11 @@ func (r *repositoryResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	repoName := state.ID.ValueString()
+	repoName := state.Name.ValueString()
 	if repoName == "" {
 		resp.Diagnostics.AddError(
 			"Missing Repository Name",
-			"The repository name (id) is required.",
+			"The repository name is required.",
 		)
 		return
 	}
@@ -706,6 +816,15 @@ func (r *repositoryResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	if state.ID.ValueString() == "" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// The repository may have been renamed or transferred since the last refresh
+	owner = state.Owner.ValueString()
+	repoName = state.Name.ValueString()
+
 	if !existingHasWiki.IsNull() && !existingHasWiki.IsUnknown() {
 		state.HasWiki = existingHasWiki
 	}
@@ -766,8 +885,8 @@ func (r *repositoryResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
-	// Get owner, falling back to authenticated user if not set
-	owner, err := r.getOwner(ctx)
+	// Get current owner from state, falling back to the provider-level owner
+	owner, err := r.resolveOwner(ctx, state.Owner)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Missing Owner",
@@ -780,7 +899,71 @@ func (r *repositoryResource) Update(ctx context.Context, req resource.UpdateRequ
 		plan.Topics = types.SetNull(types.StringType)
 	}
 
-	repoName := state.ID.ValueString()
+	repoName := state.Name.ValueString()
+
+	// GitHub rejects most changes to archived repositories, so unarchive the
+	// repository first and archive it again once the changes are applied.
+	changedAttributes, diags := repositoryRemoteChanges(req.Plan.Raw, req.State.Raw)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	archivedBefore := state.Archived.ValueBool()
+	archivedAfter := archivedBefore
+	if !plan.Archived.IsNull() && !plan.Archived.IsUnknown() {
+		archivedAfter = plan.Archived.ValueBool()
+	}
+
+	if archivedBefore && (!archivedAfter || len(changedAttributes) > 0) {
+		r.setArchived(ctx, owner, repoName, false, changedAttributes, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Transfer the repository before applying any other changes so that the
+	// remaining edits are made against the new owner.
+	newOwner := plan.Owner.ValueString()
+	if !plan.Owner.IsNull() && !plan.Owner.IsUnknown() && newOwner != "" && !strings.EqualFold(newOwner, owner) {
+		var teamIDs []int64
+		if !plan.TeamIDs.IsNull() && !plan.TeamIDs.IsUnknown() {
+			resp.Diagnostics.Append(plan.TeamIDs.ElementsAs(ctx, &teamIDs, false)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
+		// A new name is applied as part of the transfer, so no rename is needed below
+		newName := plan.Name.ValueString()
+		r.transferRepository(ctx, owner, repoName, newOwner, newName, teamIDs, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		owner = newOwner
+		repoName = newName
+	}
+
+	// Rename in place; the repository keeps its ID so no replacement is needed
+	// and GitHub redirects requests for the old name.
+	if newRepoName := plan.Name.ValueString(); newRepoName != "" && newRepoName != repoName {
+		_, _, err := r.client.Repositories.Edit(ctx, owner, repoName, &github.Repository{Name: github.String(newRepoName)})
+		if err != nil {
+			addGitHubError(&resp.Diagnostics,
+				"Error renaming repository",
+				fmt.Sprintf("Unable to rename repository %s/%s to %s: %v", owner, repoName, newRepoName, err),
+				err,
+			)
+			if archivedBefore && archivedAfter {
+				// Leave the repository archived as it was before the update
+				r.setArchived(ctx, owner, repoName, true, changedAttributes, &resp.Diagnostics)
+			}
+			return
+		}
+		repoName = newRepoName
+	}
+
 	repoReq := &github.Repository{}
 
 	if !plan.Description.Equal(state.Description) {
@@ -912,10 +1095,6 @@ func (r *repositoryResource) Update(ctx context.Context, req resource.UpdateRequ
 		repoReq.DeleteBranchOnMerge = github.Bool(plan.DeleteBranchOnMerge.ValueBool())
 	}
 
-	if !plan.Archived.Equal(state.Archived) {
-		repoReq.Archived = github.Bool(plan.Archived.ValueBool())
-	}
-
 	if repoReq.AllowMergeCommit != nil || repoReq.AllowSquashMerge != nil || repoReq.AllowRebaseMerge != nil {
 		if !finalAllowMergeCommit && !finalAllowSquashMerge && !finalAllowRebaseMerge {
 			repoReq.AllowMergeCommit = github.Bool(true)
@@ -945,22 +1124,37 @@ func (r *repositoryResource) Update(ctx context.Context, req resource.UpdateRequ
 		repoReq.AllowRebaseMerge != nil || repoReq.AllowAutoMerge != nil ||
 		repoReq.AllowUpdateBranch != nil || repoReq.SquashMergeCommitTitle != nil ||
 		repoReq.SquashMergeCommitMessage != nil || repoReq.MergeCommitTitle != nil ||
-		repoReq.MergeCommitMessage != nil || repoReq.DeleteBranchOnMerge != nil ||
-		repoReq.Archived != nil
+		repoReq.MergeCommitMessage != nil || repoReq.DeleteBranchOnMerge != nil
 
 	if hasChanges {
 		_, _, err := r.client.Repositories.Edit(ctx, owner, repoName, repoReq)
 		if err != nil {
-			if !strings.Contains(err.Error(), "422 Privacy is already set") {
-				resp.Diagnostics.AddError(
+			if !isValidationFailed(err) || !hasGitHubErrorMessage(err, "Privacy is already set") {
+				addGitHubError(&resp.Diagnostics,
 					"Error updating repository",
 					fmt.Sprintf("Unable to update repository %s: %v", repoName, err),
+					err,
 				)
+				if archivedBefore && archivedAfter {
+					// Leave the repository archived as it was before the update
+					r.setArchived(ctx, owner, repoName, true, changedAttributes, &resp.Diagnostics)
+				}
 				return
 			}
 		}
 	}
 
+	if !plan.DefaultBranch.IsNull() && !plan.DefaultBranch.IsUnknown() && !plan.DefaultBranch.Equal(state.DefaultBranch) {
+		r.setDefaultBranch(ctx, owner, repoName, plan.DefaultBranch.ValueString(), plan.RenameDefaultBranch.ValueBool(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			if archivedBefore && archivedAfter {
+				// Leave the repository archived as it was before the update
+				r.setArchived(ctx, owner, repoName, true, changedAttributes, &resp.Diagnostics)
+			}
+			return
+		}
+	}
+
 	if !plan.Topics.Equal(state.Topics) {
 		if !plan.Topics.IsNull() && !plan.Topics.IsUnknown() {
 			topics := make([]string, 0, len(plan.Topics.Elements()))
@@ -1016,6 +1210,13 @@ func (r *repositoryResource) Update(ctx context.Context, req resource.UpdateRequ
 		resp.Diagnostics.Append(diags...)
 	}
 
+	if archivedAfter && (!archivedBefore || len(changedAttributes) > 0) {
+		r.setArchived(ctx, owner, repoName, true, nil, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	planHasWiki := plan.HasWiki
 	planHasIssues := plan.HasIssues
 	planHasProjects := plan.HasProjects
@@ -1117,8 +1318,8 @@ func (r *repositoryResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
-	// Get owner, falling back to authenticated user if not set
-	owner, err := r.getOwner(ctx)
+	// Get owner from state, falling back to the provider-level owner
+	owner, err := r.resolveOwner(ctx, state.Owner)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Missing Owner",
@@ -1127,7 +1328,15 @@ func (r *repositoryResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
-	repoName := state.ID.ValueString()
+	repoName := state.Name.ValueString()
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Repository is protected from deletion",
+			fmt.Sprintf("Repository %s/%s has `deletion_protection` set to true. Set `deletion_protection = false` and apply before destroying or replacing the repository.", owner, repoName),
+		)
+		return
+	}
+
 	archiveOnDestroy := state.ArchiveOnDestroy.ValueBool()
 	if archiveOnDestroy {
 		if state.Archived.ValueBool() {
@@ -1135,57 +1344,99 @@ func (r *repositoryResource) Delete(ctx context.Context, req resource.DeleteRequ
 			return
 		}
 
-		repoReq := &github.Repository{
-			Archived: github.Bool(true),
-		}
-		log.Printf("[DEBUG] Archiving repository on delete: %s/%s", owner, repoName)
-		_, _, err := r.client.Repositories.Edit(ctx, owner, repoName, repoReq)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error archiving repository",
-				fmt.Sprintf("Unable to archive repository %s: %v", repoName, err),
-			)
-		}
+		r.setArchived(ctx, owner, repoName, true, nil, &resp.Diagnostics)
 		return
 	}
 
+	if backupPath := state.BackupOnDestroyPath.ValueString(); backupPath != "" {
+		r.backupRepository(ctx, owner, repoName, backupPath, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	log.Printf("[DEBUG] Deleting repository: %s/%s", owner, repoName)
 	_, err = r.client.Repositories.Delete(ctx, owner, repoName)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addGitHubError(&resp.Diagnostics,
 			"Error deleting repository",
 			fmt.Sprintf("Unable to delete repository %s: %v", repoName, err),
+			err,
 		)
 		return
 	}
 }
 
 func (r *repositoryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// An "id:" prefix marks the repository ID, which stays the same across renames and
+	// transfers. It is explicit, as repository names can be numbers too.
+	if idStr, ok := strings.CutPrefix(req.ID, "id:"); ok {
+		r.importRepositoryByID(ctx, idStr, resp)
+		return
+	}
+
 	parts := strings.Split(req.ID, "/")
-	var repoName string
+	var owner, repoName string
 
 	if len(parts) == 2 {
+		owner = parts[0]
 		repoName = parts[1]
 	} else if len(parts) == 1 {
 		repoName = parts[0]
-		_, err := r.getOwner(ctx)
+		var err error
+		owner, err = r.getOwner(ctx)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Invalid Import ID",
-				fmt.Sprintf("Import ID must be in format 'owner/repo' or 'repo' (when provider-level owner is configured or authentication is available). Error: %v", err),
+				fmt.Sprintf("Import ID must be in format 'id:<repository ID>', 'owner/repo' or 'repo' (when provider-level owner is configured or authentication is available). Error: %v", err),
 			)
 			return
 		}
 	} else {
 		resp.Diagnostics.AddError(
 			"Invalid Import ID",
-			"Import ID must be in format 'owner/repo' or 'repo'.",
+			"Import ID must be in format 'id:<repository ID>', 'owner/repo' or 'repo'.",
 		)
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), repoName)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), repoName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("owner"), owner)...)
+}
+
+// importRepositoryByID imports the repository with the given repository ID.
+func (r *repositoryResource) importRepositoryByID(ctx context.Context, idStr string, resp *resource.ImportStateResponse) {
+	repoID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Invalid repository ID %q in import ID: %v", idStr, err),
+		)
+		return
+	}
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			"GitHub client is not configured. Please ensure a token is provided.",
+		)
+		return
+	}
+
+	repo, _, err := r.client.Repositories.GetByID(ctx, repoID)
+	if err != nil {
+		addGitHubError(&resp.Diagnostics,
+			"Error importing repository",
+			fmt.Sprintf("Unable to look up repository ID %d: %v", repoID, err),
+			err,
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), strconv.FormatInt(repo.GetID(), 10))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repo_id"), repo.GetID())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), repo.GetName())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("owner"), repo.GetOwner().GetLogin())...)
 }
 
 func (r *repositoryResource) getOwner(ctx context.Context) (string, error) {
@@ -1203,19 +1454,378 @@ func (r *repositoryResource) getOwner(ctx context.Context) (string, error) {
 	return user.GetLogin(), nil
 }
 
-func (r *repositoryResource) readRepository(ctx context.Context, owner, repoName string, model *repositoryResourceModel, diags *diag.Diagnostics) {
+// resolveOwner returns the configured repository owner, falling back to the
+// provider-level owner or the authenticated user when it is not set.
+func (r *repositoryResource) resolveOwner(ctx context.Context, owner types.String) (string, error) {
+	if !owner.IsNull() && !owner.IsUnknown() && owner.ValueString() != "" {
+		return owner.ValueString(), nil
+	}
+	return r.getOwner(ctx)
+}
+
+// transferPollInterval is how often to check whether a repository transfer has completed.
+var transferPollInterval = 5 * time.Second
+
+// transferRepository transfers a repository to a new owner and waits for the
+// asynchronous transfer to complete.
+func (r *repositoryResource) transferRepository(ctx context.Context, owner, repoName, newOwner, newName string, teamIDs []int64, diags *diag.Diagnostics) {
+	transferReq := github.TransferRequest{
+		NewOwner: newOwner,
+		TeamID:   teamIDs,
+	}
+	if newName != repoName {
+		transferReq.NewName = github.String(newName)
+	}
+
+	log.Printf("[DEBUG] Transferring repository %s/%s to %s/%s", owner, repoName, newOwner, newName)
+	_, _, err := r.client.Repositories.Transfer(ctx, owner, repoName, transferReq)
+	if err != nil {
+		// GitHub processes transfers in the background and responds with 202 Accepted
+		var acceptedErr *github.AcceptedError
+		if !errors.As(err, &acceptedErr) {
+			addGitHubError(diags,
+				"Error transferring repository",
+				fmt.Sprintf("Unable to transfer repository %s/%s to %s: %v", owner, repoName, newOwner, err),
+				err,
+			)
+			return
+		}
+	}
+
+	maxAttempts := 24
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		repo, _, err := r.client.Repositories.Get(ctx, newOwner, newName)
+		if err == nil && repo != nil && strings.EqualFold(repo.GetOwner().GetLogin(), newOwner) {
+			log.Printf("[INFO] Repository %s/%s transferred to %s", owner, repoName, repo.GetFullName())
+			return
+		}
+		log.Printf("[DEBUG] Waiting for transfer of %s/%s to %s/%s to complete (attempt %d/%d)", owner, repoName, newOwner, newName, attempt+1, maxAttempts)
+		if err := sleepContext(ctx, transferPollInterval); err != nil {
+			diags.AddError(
+				"Repository transfer not completed",
+				fmt.Sprintf("Stopped waiting for the transfer of repository %s/%s to %s/%s to complete: %v", owner, repoName, newOwner, newName, err),
+			)
+			return
+		}
+	}
+
+	diags.AddError(
+		"Repository transfer not completed",
+		fmt.Sprintf("Transfer of repository %s/%s to %s/%s did not complete after %d attempts. Transfers to a personal account must be accepted by the new owner before they complete.", owner, repoName, newOwner, newName, maxAttempts),
+	)
+}
+
+// backupRepository writes a tarball of the default branch and the repository
+// metadata to a local directory.
+func (r *repositoryResource) backupRepository(ctx context.Context, owner, repoName, dir string, diags *diag.Diagnostics) {
+	repo, _, err := r.client.Repositories.Get(ctx, owner, repoName)
+	if err != nil {
+		diags.AddError(
+			"Error backing up repository",
+			fmt.Sprintf("Unable to read repository %s/%s for backup: %v", owner, repoName, err),
+		)
+		return
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		diags.AddError(
+			"Error backing up repository",
+			fmt.Sprintf("Unable to create backup directory %s: %v", dir, err),
+		)
+		return
+	}
+
+	prefix := filepath.Join(dir, fmt.Sprintf("%s-%s-%s", owner, repoName, time.Now().UTC().Format("20060102T150405Z")))
+
+	metadata, err := json.MarshalIndent(repo, "", "  ")
+	if err != nil {
+		diags.AddError(
+			"Error backing up repository",
+			fmt.Sprintf("Unable to encode metadata for repository %s/%s: %v", owner, repoName, err),
+		)
+		return
+	}
+	if err := os.WriteFile(prefix+".json", metadata, 0o644); err != nil {
+		diags.AddError(
+			"Error backing up repository",
+			fmt.Sprintf("Unable to write metadata for repository %s/%s: %v", owner, repoName, err),
+		)
+		return
+	}
+
+	defaultBranch := repo.GetDefaultBranch()
+	archiveURL, archiveResp, err := r.client.Repositories.GetArchiveLink(ctx, owner, repoName, github.Tarball, &github.RepositoryContentGetOptions{Ref: defaultBranch}, 1)
+	if err != nil {
+		// Empty repositories have no default branch to archive
+		if isNotFound(err) || (archiveResp != nil && archiveResp.StatusCode == http.StatusNotFound) {
+			diags.AddWarning(
+				"Repository tarball not available",
+				fmt.Sprintf("Repository %s/%s has no content on its default branch; only the metadata was backed up to %s.json.", owner, repoName, prefix),
+			)
+			return
+		}
+		diags.AddError(
+			"Error backing up repository",
+			fmt.Sprintf("Unable to get archive link for repository %s/%s: %v", owner, repoName, err),
+		)
+		return
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, archiveURL.String(), nil)
+	if err != nil {
+		diags.AddError(
+			"Error backing up repository",
+			fmt.Sprintf("Unable to build archive request for repository %s/%s: %v", owner, repoName, err),
+		)
+		return
+	}
+	httpResp, err := r.client.Client().Do(req)
+	if err != nil {
+		diags.AddError(
+			"Error backing up repository",
+			fmt.Sprintf("Unable to download archive for repository %s/%s: %v", owner, repoName, err),
+		)
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		diags.AddError(
+			"Error backing up repository",
+			fmt.Sprintf("Unable to download archive for repository %s/%s: unexpected status %s", owner, repoName, httpResp.Status),
+		)
+		return
+	}
+
+	f, err := os.Create(prefix + ".tar.gz")
+	if err != nil {
+		diags.AddError(
+			"Error backing up repository",
+			fmt.Sprintf("Unable to create archive file for repository %s/%s: %v", owner, repoName, err),
+		)
+		return
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, httpResp.Body); err != nil {
+		diags.AddError(
+			"Error backing up repository",
+			fmt.Sprintf("Unable to write archive for repository %s/%s: %v", owner, repoName, err),
+		)
+		return
+	}
+
+	log.Printf("[INFO] Backed up repository %s/%s to %s.tar.gz", owner, repoName, prefix)
+}
+
+// setArchived archives or unarchives a repository. When unarchiving fails,
+// the diagnostic lists the changes that could not be applied.
+func (r *repositoryResource) setArchived(ctx context.Context, owner, repoName string, archived bool, changedAttributes []string, diags *diag.Diagnostics) {
+	if archived {
+		log.Printf("[DEBUG] Archiving repository: %s/%s", owner, repoName)
+	} else {
+		log.Printf("[DEBUG] Unarchiving repository: %s/%s", owner, repoName)
+	}
+
+	_, _, err := r.client.Repositories.Edit(ctx, owner, repoName, &github.Repository{
+		Archived: github.Bool(archived),
+	})
+	if err == nil {
+		return
+	}
+
+	if archived {
+		addGitHubError(diags,
+			"Error archiving repository",
+			fmt.Sprintf("Unable to archive repository %s/%s: %v", owner, repoName, err),
+			err,
+		)
+		return
+	}
+
+	detail := fmt.Sprintf("Repository %s/%s is archived and GitHub does not allow changes to archived repositories. Unarchiving it failed: %v. Unarchiving requires admin access to the repository.", owner, repoName, err)
+	if len(changedAttributes) > 0 {
+		detail += fmt.Sprintf(" The following changes could not be applied: %s.", strings.Join(changedAttributes, ", "))
+	}
+	diags.AddError("Unable to change archived repository", detail)
+}
+
+// setDefaultBranch makes branch the default branch of a repository. A missing branch is
+// created from the head of the current default branch, or the current default branch is
+// renamed to it if rename is set, which also preserves redirects for the old name.
+func (r *repositoryResource) setDefaultBranch(ctx context.Context, owner, repoName, branch string, rename bool, diags *diag.Diagnostics) {
 	repo, _, err := r.client.Repositories.Get(ctx, owner, repoName)
+	if err != nil {
+		addGitHubError(diags,
+			"Error reading repository",
+			fmt.Sprintf("Unable to read repository %s/%s: %v", owner, repoName, err),
+			err,
+		)
+		return
+	}
+
+	current := repo.GetDefaultBranch()
+	if current == branch {
+		return
+	}
+
+	// GetBranch follows redirects itself, so a missing branch is not always an ErrorResponse
+	_, branchResp, err := r.client.Repositories.GetBranch(ctx, owner, repoName, branch, 1)
+	if err != nil && !isNotFound(err) && (branchResp == nil || branchResp.StatusCode != http.StatusNotFound) {
+		addGitHubError(diags,
+			"Error querying branch",
+			fmt.Sprintf("Unable to query branch %s of repository %s/%s: %v", branch, owner, repoName, err),
+			err,
+		)
+		return
+	}
+
+	if err != nil {
+		if rename {
+			// GitHub makes the renamed branch the default branch
+			if _, _, err := r.client.Repositories.RenameBranch(ctx, owner, repoName, current, branch); err != nil {
+				addGitHubError(diags,
+					"Error renaming default branch",
+					fmt.Sprintf("Unable to rename branch %s of repository %s/%s to %s: %v", current, owner, repoName, branch, err),
+					err,
+				)
+				return
+			}
+			log.Printf("[INFO] Renamed default branch %s of %s/%s to %s", current, owner, repoName, branch)
+			return
+		}
+
+		ref, _, err := r.client.Git.GetRef(ctx, owner, repoName, "refs/heads/"+current)
+		if err != nil {
+			addGitHubError(diags,
+				"Error querying default branch",
+				fmt.Sprintf("Unable to query default branch %s of repository %s/%s: %v. The repository must have at least one commit to change its default branch, for example through `auto_init`.", current, owner, repoName, err),
+				err,
+			)
+			return
+		}
+		if _, _, err := r.client.Git.CreateRef(ctx, owner, repoName, &github.Reference{
+			Ref:    github.String("refs/heads/" + branch),
+			Object: &github.GitObject{SHA: ref.Object.SHA},
+		}); err != nil {
+			addGitHubError(diags,
+				"Error creating branch",
+				fmt.Sprintf("Unable to create branch %s of repository %s/%s from %s: %v", branch, owner, repoName, current, err),
+				err,
+			)
+			return
+		}
+		log.Printf("[INFO] Created branch %s of %s/%s from %s", branch, owner, repoName, current)
+	}
+
+	if _, _, err := r.client.Repositories.Edit(ctx, owner, repoName, &github.Repository{DefaultBranch: github.String(branch)}); err != nil {
+		addGitHubError(diags,
+			"Error setting default branch",
+			fmt.Sprintf("Unable to set the default branch of repository %s/%s to %s: %v", owner, repoName, branch, err),
+			err,
+		)
+		return
+	}
+	log.Printf("[INFO] Changed default branch of %s/%s from %s to %s", owner, repoName, current, branch)
+}
+
+// repositoryLocalOnlyAttributes lists attributes that only affect Terraform
+// behaviour and never require changes to the repository itself.
+var repositoryLocalOnlyAttributes = map[string]bool{
+	"archived":               true,
+	"archive_on_destroy":     true,
+	"auto_init":              true,
+	"backup_on_destroy_path": true,
+	"deletion_protection":    true,
+	"rename_default_branch":  true,
+	"team_ids":               true,
+}
+
+// repositoryRemoteChanges returns the sorted names of the top-level attributes
+// whose planned values require changes to the repository on GitHub.
+func repositoryRemoteChanges(planRaw, stateRaw tftypes.Value) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if planRaw.IsNull() || stateRaw.IsNull() {
+		return nil, diags
+	}
+
+	valueDiffs, err := planRaw.Diff(stateRaw)
 	if err != nil {
 		diags.AddError(
+			"Error comparing plan and state",
+			fmt.Sprintf("Unable to compare the planned repository with its current state: %v", err),
+		)
+		return nil, diags
+	}
+
+	changed := make(map[string]bool)
+	for _, valueDiff := range valueDiffs {
+		if valueDiff.Path == nil || len(valueDiff.Path.Steps()) == 0 {
+			continue
+		}
+		attrName, ok := valueDiff.Path.Steps()[0].(tftypes.AttributeName)
+		if !ok || repositoryLocalOnlyAttributes[string(attrName)] {
+			continue
+		}
+		// Unknown values are computed attributes that are not being changed
+		if valueDiff.Value1 != nil && !valueDiff.Value1.IsFullyKnown() {
+			continue
+		}
+		changed[string(attrName)] = true
+	}
+
+	attributes := make([]string, 0, len(changed))
+	for attrName := range changed {
+		attributes = append(attributes, attrName)
+	}
+	sort.Strings(attributes)
+
+	return attributes, diags
+}
+
+func (r *repositoryResource) readRepository(ctx context.Context, owner, repoName string, model *repositoryResourceModel, diags *diag.Diagnostics) {
+	repo, _, err := r.client.Repositories.Get(ctx, owner, repoName)
+	if err != nil && isNotFound(err) && !model.RepoID.IsNull() && !model.RepoID.IsUnknown() && model.RepoID.ValueInt64() != 0 {
+		// The repository may have been renamed or transferred outside of Terraform,
+		// so fall back to the immutable repository ID
+		log.Printf("[INFO] Repository %s/%s not found by name, looking it up by ID %d", owner, repoName, model.RepoID.ValueInt64())
+		repo, _, err = r.client.Repositories.GetByID(ctx, model.RepoID.ValueInt64())
+	}
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[INFO] Removing repository %s/%s from state because it no longer exists in GitHub", owner, repoName)
+			model.ID = types.StringValue("")
+			return
+		}
+		addGitHubError(diags,
 			"Error reading repository",
 			fmt.Sprintf("Unable to read repository %s/%s: %v", owner, repoName, err),
+			err,
 		)
 		return
 	}
 
-	model.ID = types.StringValue(repo.GetName())
+	if repo.GetName() != repoName || (repo.GetOwner().GetLogin() != "" && !strings.EqualFold(repo.GetOwner().GetLogin(), owner)) {
+		log.Printf("[INFO] Repository %s/%s is now %s", owner, repoName, repo.GetFullName())
+		if login := repo.GetOwner().GetLogin(); login != "" {
+			owner = login
+		}
+		repoName = repo.GetName()
+	}
+
+	model.ID = types.StringValue(strconv.FormatInt(repo.GetID(), 10))
 	model.Name = types.StringValue(repo.GetName())
 
+	// Logins are case-insensitive, so keep the configured casing when it matches
+	login := repo.GetOwner().GetLogin()
+	if login == "" {
+		login = owner
+	}
+	if model.Owner.IsNull() || model.Owner.IsUnknown() || !strings.EqualFold(model.Owner.ValueString(), login) {
+		model.Owner = types.StringValue(login)
+	}
+
 	fullName := repo.GetFullName()
 	if fullName == "" {
 		fullName = fmt.Sprintf("%s/%s", owner, repo.GetName())
@@ -1292,7 +1902,7 @@ func (r *repositoryResource) readRepository(ctx context.Context, owner, repoName
 
 	_, resp, err := r.client.Repositories.GetVulnerabilityAlerts(ctx, owner, repoName)
 	if err != nil {
-		if errResp, ok := err.(*github.ErrorResponse); ok && errResp != nil && errResp.Response != nil && errResp.Response.StatusCode == http.StatusNotFound {
+		if isNotFound(err) {
 			model.VulnerabilityAlerts = types.BoolValue(false)
 		} else {
 			diags.AddWarning(
@@ -1467,7 +2077,7 @@ func (r *repositoryResource) updatePages(ctx context.Context, owner, repoName st
 
 	_, err := r.client.Repositories.UpdatePages(ctx, owner, repoName, pagesUpdate)
 	if err != nil {
-		if errResp, ok := err.(*github.ErrorResponse); ok && errResp != nil && errResp.Response != nil && errResp.Response.StatusCode == http.StatusNotFound {
+		if isNotFound(err) {
 			log.Printf("[DEBUG] Pages not yet available for repository %s/%s (404): %v. Pages will be configured once the repository has content.", owner, repoName, err)
 		} else {
 			diags.AddWarning(
@@ -0,0 +1,726 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &repositoryActionsSettingsResource{}
+	_ resource.ResourceWithConfigure      = &repositoryActionsSettingsResource{}
+	_ resource.ResourceWithImportState    = &repositoryActionsSettingsResource{}
+	_ resource.ResourceWithValidateConfig = &repositoryActionsSettingsResource{}
+	_ resource.ResourceWithModifyPlan     = &repositoryActionsSettingsResource{}
+)
+
+// NewRepositoryActionsSettingsResource is a helper function to simplify the provider implementation.
+func NewRepositoryActionsSettingsResource() resource.Resource {
+	return &repositoryActionsSettingsResource{}
+}
+
+// repositoryActionsSettingsResource is the resource implementation.
+type repositoryActionsSettingsResource struct {
+	client *github.Client
+	owner  string
+}
+
+// repositoryActionsSettingsResourceModel maps the resource schema data.
+type repositoryActionsSettingsResourceModel struct {
+	Repository                   types.String `tfsdk:"repository"`
+	Enabled                      types.Bool   `tfsdk:"enabled"`
+	AllowedActions               types.String `tfsdk:"allowed_actions"`
+	GithubOwnedAllowed           types.Bool   `tfsdk:"github_owned_allowed"`
+	VerifiedAllowed              types.Bool   `tfsdk:"verified_allowed"`
+	PatternsAllowed              types.Set    `tfsdk:"patterns_allowed"`
+	DefaultWorkflowPermissions   types.String `tfsdk:"default_workflow_permissions"`
+	CanApprovePullRequestReviews types.Bool   `tfsdk:"can_approve_pull_request_reviews"`
+	ForkPRApprovalPolicy         types.String `tfsdk:"fork_pr_approval_policy"`
+	RetentionDays                types.Int64  `tfsdk:"retention_days"`
+	ID                           types.String `tfsdk:"id"`
+}
+
+// repositoryForkPRApproval is the body of the fork pull request contributor approval endpoints,
+// which are not covered by the go-github client.
+type repositoryForkPRApproval struct {
+	ApprovalPolicy *string `json:"approval_policy,omitempty"`
+}
+
+// repositoryArtifactRetention is the body of the artifact and log retention endpoints,
+// which are not covered by the go-github client.
+type repositoryArtifactRetention struct {
+	Days               *int64 `json:"days,omitempty"`
+	MaximumAllowedDays *int64 `json:"maximum_allowed_days,omitempty"`
+}
+
+// Metadata returns the resource type name.
+func (r *repositoryActionsSettingsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repository_actions_settings"
+}
+
+// Schema defines the schema for the resource.
+func (r *repositoryActionsSettingsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the GitHub Actions permissions and workflow settings of a repository. Destroying this resource removes it from state and leaves the settings unchanged.",
+		Attributes: map[string]schema.Attribute{
+			"repository": schema.StringAttribute{
+				Description: "The GitHub repository name.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether GitHub Actions is enabled for the repository. Defaults to `true`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"allowed_actions": schema.StringAttribute{
+				Description: "The actions and reusable workflows that are allowed to run: `all`, `local_only` or `selected`. Can only be set when `enabled` is `true`.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("all", "local_only", "selected"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"github_owned_allowed": schema.BoolAttribute{
+				Description: "Whether actions created by GitHub are allowed. Only used when `allowed_actions` is `selected`.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"verified_allowed": schema.BoolAttribute{
+				Description: "Whether actions from verified creators on GitHub Marketplace are allowed. Only used when `allowed_actions` is `selected`.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"patterns_allowed": schema.SetAttribute{
+				Description: "Patterns of allowed actions and reusable workflows, such as `monalisa/octocat@*` or `docker/*`. Only used when `allowed_actions` is `selected`.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"default_workflow_permissions": schema.StringAttribute{
+				Description: "The default permissions granted to the `GITHUB_TOKEN` when running workflows: `read` or `write`.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("read", "write"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"can_approve_pull_request_reviews": schema.BoolAttribute{
+				Description: "Whether GitHub Actions can create and approve pull requests.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"fork_pr_approval_policy": schema.StringAttribute{
+				Description: "Which pull requests from forks need approval before their workflows run: `first_time_contributors_new_to_github`, `first_time_contributors` or `all_external_contributors`.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("first_time_contributors_new_to_github", "first_time_contributors", "all_external_contributors"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"retention_days": schema.Int64Attribute{
+				Description: "The number of days artifacts and logs are retained. Cannot exceed the limit set by the owning organization or enterprise.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 400),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "The Terraform state ID (repository name).",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure enables provider-level data or clients to be set in the
+// provider-defined resource type.
+func (r *repositoryActionsSettingsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(githubxClientData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected githubxClientData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = clientData.Client
+	r.owner = clientData.Owner
+}
+
+// ValidateConfig checks that the selected actions settings are only used with `allowed_actions = "selected"`.
+func (r *repositoryActionsSettingsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config repositoryActionsSettingsResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Enabled.IsNull() && !config.Enabled.IsUnknown() && !config.Enabled.ValueBool() &&
+		!config.AllowedActions.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("allowed_actions"),
+			"Invalid Actions Settings",
+			"`allowed_actions` cannot be set when `enabled` is false.",
+		)
+	}
+
+	if config.AllowedActions.IsUnknown() || config.AllowedActions.ValueString() == "selected" {
+		return
+	}
+
+	selectedOnly := []struct {
+		name  string
+		value interface{ IsNull() bool }
+	}{
+		{"github_owned_allowed", config.GithubOwnedAllowed},
+		{"verified_allowed", config.VerifiedAllowed},
+		{"patterns_allowed", config.PatternsAllowed},
+	}
+	for _, attr := range selectedOnly {
+		if !attr.value.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attr.name),
+				"Invalid Actions Settings",
+				fmt.Sprintf("`%s` can only be set when `allowed_actions` is \"selected\".", attr.name),
+			)
+		}
+	}
+}
+
+// ModifyPlan corrects the values that UseStateForUnknown carries over from the state when
+// GitHub will no longer report them, or will report new ones: `allowed_actions` is only
+// reported while Actions is enabled, and the selected actions settings only while
+// `allowed_actions` is "selected".
+func (r *repositoryActionsSettingsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var config, plan, state repositoryActionsSettingsResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Enabled.IsUnknown() && config.AllowedActions.IsNull() {
+		if !plan.Enabled.ValueBool() {
+			plan.AllowedActions = types.StringNull()
+		} else if !state.Enabled.ValueBool() {
+			plan.AllowedActions = types.StringUnknown()
+		}
+	}
+
+	if !plan.AllowedActions.IsUnknown() {
+		if plan.AllowedActions.ValueString() != "selected" {
+			plan.GithubOwnedAllowed = types.BoolNull()
+			plan.VerifiedAllowed = types.BoolNull()
+			plan.PatternsAllowed = types.SetNull(types.StringType)
+		} else if state.AllowedActions.ValueString() != "selected" {
+			if config.GithubOwnedAllowed.IsNull() {
+				plan.GithubOwnedAllowed = types.BoolUnknown()
+			}
+			if config.VerifiedAllowed.IsNull() {
+				plan.VerifiedAllowed = types.BoolUnknown()
+			}
+			if config.PatternsAllowed.IsNull() {
+				plan.PatternsAllowed = types.SetUnknown(types.StringType)
+			}
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *repositoryActionsSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan repositoryActionsSettingsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			"GitHub client is not configured. Please ensure a token is provided.",
+		)
+		return
+	}
+
+	// Get owner, falling back to authenticated user if not set
+	owner, err := r.getOwner(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Missing Owner",
+			fmt.Sprintf("Unable to determine owner: %v. Please set provider-level `owner` configuration or ensure authentication is working.", err),
+		)
+		return
+	}
+
+	repoName := plan.Repository.ValueString()
+
+	r.applyActionsSettings(ctx, owner, repoName, &plan, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.readActionsSettings(ctx, owner, repoName, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *repositoryActionsSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state repositoryActionsSettingsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			"GitHub client is not configured. Please ensure a token is provided.",
+		)
+		return
+	}
+
+	// Get owner, falling back to authenticated user if not set
+	owner, err := r.getOwner(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Missing Owner",
+			fmt.Sprintf("Unable to determine owner: %v. Please set provider-level `owner` configuration or ensure authentication is working.", err),
+		)
+		return
+	}
+
+	repoName := state.Repository.ValueString()
+	if repoName == "" {
+		repoName = state.ID.ValueString()
+	}
+
+	r.readActionsSettings(ctx, owner, repoName, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.ID.ValueString() == "" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *repositoryActionsSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state repositoryActionsSettingsResourceModel
+
+	// Read Terraform plan and state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			"GitHub client is not configured. Please ensure a token is provided.",
+		)
+		return
+	}
+
+	// Get owner, falling back to authenticated user if not set
+	owner, err := r.getOwner(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Missing Owner",
+			fmt.Sprintf("Unable to determine owner: %v. Please set provider-level `owner` configuration or ensure authentication is working.", err),
+		)
+		return
+	}
+
+	repoName := plan.Repository.ValueString()
+
+	r.applyActionsSettings(ctx, owner, repoName, &plan, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.readActionsSettings(ctx, owner, repoName, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes the resource from the Terraform state. The Actions settings of the
+// repository are left as they are, since there is no safe default to restore.
+func (r *repositoryActionsSettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state repositoryActionsSettingsResourceModel
+
+	// Read Terraform state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	log.Printf("[INFO] Removing Actions settings for repository %s from state; the settings are left unchanged in GitHub", state.Repository.ValueString())
+}
+
+// ImportState imports the resource into Terraform state.
+func (r *repositoryActionsSettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Import ID must be the repository name.",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repository"), req.ID)...)
+}
+
+// Helper methods
+
+// getOwner gets the owner, falling back to authenticated user if not set.
+func (r *repositoryActionsSettingsResource) getOwner(ctx context.Context) (string, error) {
+	if r.owner != "" {
+		return r.owner, nil
+	}
+	// Try to get authenticated user
+	user, _, err := r.client.Users.Get(ctx, "")
+	if err != nil {
+		return "", fmt.Errorf("unable to determine owner: provider-level `owner` is not set and unable to fetch authenticated user: %v", err)
+	}
+	if user == nil || user.Login == nil {
+		return "", fmt.Errorf("unable to determine owner: provider-level `owner` is not set and authenticated user information is unavailable")
+	}
+	return user.GetLogin(), nil
+}
+
+// applyActionsSettings sends the planned settings to GitHub. When state is nil every
+// configured setting is sent, otherwise only the settings that differ from state.
+func (r *repositoryActionsSettingsResource) applyActionsSettings(ctx context.Context, owner, repoName string, plan, state *repositoryActionsSettingsResourceModel, diags *diag.Diagnostics) {
+	enabled := plan.Enabled.ValueBool()
+	permissions := github.ActionsPermissionsRepository{}
+	if state == nil || !plan.Enabled.Equal(state.Enabled) {
+		permissions.Enabled = github.Bool(enabled)
+	}
+	if enabled && isKnown(plan.AllowedActions) && (state == nil || !plan.AllowedActions.Equal(state.AllowedActions)) {
+		permissions.AllowedActions = github.String(plan.AllowedActions.ValueString())
+	}
+	if permissions.Enabled != nil || permissions.AllowedActions != nil {
+		// GitHub requires `enabled` on every update of the repository permissions
+		permissions.Enabled = github.Bool(enabled)
+		log.Printf("[DEBUG] Updating Actions permissions for %s/%s: %v", owner, repoName, permissions)
+		_, _, err := r.client.Repositories.EditActionsPermissions(ctx, owner, repoName, permissions)
+		if err != nil {
+			addGitHubError(diags,
+				"Error updating Actions permissions",
+				fmt.Sprintf("Unable to update Actions permissions for repository %s/%s: %v", owner, repoName, err),
+				err,
+			)
+			return
+		}
+	}
+
+	if enabled && plan.AllowedActions.ValueString() == "selected" {
+		allowed := github.ActionsAllowed{}
+		hasChanges := false
+		if isKnown(plan.GithubOwnedAllowed) && (state == nil || !plan.GithubOwnedAllowed.Equal(state.GithubOwnedAllowed)) {
+			hasChanges = true
+		}
+		if isKnown(plan.VerifiedAllowed) && (state == nil || !plan.VerifiedAllowed.Equal(state.VerifiedAllowed)) {
+			hasChanges = true
+		}
+		if isKnown(plan.PatternsAllowed) && (state == nil || !plan.PatternsAllowed.Equal(state.PatternsAllowed)) {
+			hasChanges = true
+		}
+		if state != nil && !plan.AllowedActions.Equal(state.AllowedActions) {
+			hasChanges = true
+		}
+
+		if hasChanges {
+			// The endpoint replaces all selected actions settings, so always send the full set
+			if isKnown(plan.GithubOwnedAllowed) {
+				allowed.GithubOwnedAllowed = github.Bool(plan.GithubOwnedAllowed.ValueBool())
+			}
+			if isKnown(plan.VerifiedAllowed) {
+				allowed.VerifiedAllowed = github.Bool(plan.VerifiedAllowed.ValueBool())
+			}
+			if isKnown(plan.PatternsAllowed) {
+				patterns := make([]string, 0, len(plan.PatternsAllowed.Elements()))
+				diags.Append(plan.PatternsAllowed.ElementsAs(ctx, &patterns, false)...)
+				if diags.HasError() {
+					return
+				}
+				sort.Strings(patterns)
+				allowed.PatternsAllowed = patterns
+			}
+
+			log.Printf("[DEBUG] Updating allowed actions for %s/%s: %v", owner, repoName, allowed)
+			_, _, err := r.client.Repositories.EditActionsAllowed(ctx, owner, repoName, allowed)
+			if err != nil {
+				addGitHubError(diags,
+					"Error updating allowed actions",
+					fmt.Sprintf("Unable to update allowed actions for repository %s/%s: %v", owner, repoName, err),
+					err,
+				)
+				return
+			}
+		}
+	}
+
+	workflowPermissions := github.DefaultWorkflowPermissionRepository{}
+	if isKnown(plan.DefaultWorkflowPermissions) && (state == nil || !plan.DefaultWorkflowPermissions.Equal(state.DefaultWorkflowPermissions)) {
+		workflowPermissions.DefaultWorkflowPermissions = github.String(plan.DefaultWorkflowPermissions.ValueString())
+	}
+	if isKnown(plan.CanApprovePullRequestReviews) && (state == nil || !plan.CanApprovePullRequestReviews.Equal(state.CanApprovePullRequestReviews)) {
+		workflowPermissions.CanApprovePullRequestReviews = github.Bool(plan.CanApprovePullRequestReviews.ValueBool())
+	}
+	if workflowPermissions.DefaultWorkflowPermissions != nil || workflowPermissions.CanApprovePullRequestReviews != nil {
+		log.Printf("[DEBUG] Updating default workflow permissions for %s/%s: %v", owner, repoName, workflowPermissions)
+		_, _, err := r.client.Repositories.EditDefaultWorkflowPermissions(ctx, owner, repoName, workflowPermissions)
+		if err != nil {
+			addGitHubError(diags,
+				"Error updating workflow permissions",
+				fmt.Sprintf("Unable to update default workflow permissions for repository %s/%s: %v", owner, repoName, err),
+				err,
+			)
+			return
+		}
+	}
+
+	if isKnown(plan.ForkPRApprovalPolicy) && (state == nil || !plan.ForkPRApprovalPolicy.Equal(state.ForkPRApprovalPolicy)) {
+		body := &repositoryForkPRApproval{ApprovalPolicy: github.String(plan.ForkPRApprovalPolicy.ValueString())}
+		log.Printf("[DEBUG] Updating fork pull request approval policy for %s/%s: %s", owner, repoName, plan.ForkPRApprovalPolicy.ValueString())
+		if err := r.actionsPermissionsRequest(ctx, http.MethodPut, owner, repoName, "fork-pr-contributor-approval", body, nil); err != nil {
+			addGitHubError(diags,
+				"Error updating fork pull request approval policy",
+				fmt.Sprintf("Unable to update fork pull request approval policy for repository %s/%s: %v", owner, repoName, err),
+				err,
+			)
+			return
+		}
+	}
+
+	if isKnown(plan.RetentionDays) && (state == nil || !plan.RetentionDays.Equal(state.RetentionDays)) {
+		body := &repositoryArtifactRetention{Days: github.Int64(plan.RetentionDays.ValueInt64())}
+		log.Printf("[DEBUG] Updating artifact and log retention for %s/%s: %d days", owner, repoName, plan.RetentionDays.ValueInt64())
+		if err := r.actionsPermissionsRequest(ctx, http.MethodPut, owner, repoName, "artifact-and-log-retention", body, nil); err != nil {
+			addGitHubError(diags,
+				"Error updating artifact and log retention",
+				fmt.Sprintf("Unable to update artifact and log retention for repository %s/%s: %v", owner, repoName, err),
+				err,
+			)
+			return
+		}
+	}
+}
+
+// readActionsSettings reads the Actions settings from GitHub and populates the model.
+func (r *repositoryActionsSettingsResource) readActionsSettings(ctx context.Context, owner, repoName string, model *repositoryActionsSettingsResourceModel, diags *diag.Diagnostics) {
+	permissions, _, err := r.client.Repositories.GetActionsPermissions(ctx, owner, repoName)
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[INFO] Removing Actions settings for %s/%s from state because the repository no longer exists in GitHub", owner, repoName)
+			model.ID = types.StringValue("")
+			return
+		}
+		addGitHubError(diags,
+			"Error reading Actions permissions",
+			fmt.Sprintf("Unable to read Actions permissions for repository %s/%s: %v", owner, repoName, err),
+			err,
+		)
+		return
+	}
+
+	model.ID = types.StringValue(repoName)
+	model.Repository = types.StringValue(repoName)
+	model.Enabled = types.BoolValue(permissions.GetEnabled())
+
+	// GitHub omits allowed_actions when Actions is disabled
+	if permissions.GetAllowedActions() != "" {
+		model.AllowedActions = types.StringValue(permissions.GetAllowedActions())
+	} else {
+		model.AllowedActions = types.StringNull()
+	}
+
+	model.GithubOwnedAllowed = types.BoolNull()
+	model.VerifiedAllowed = types.BoolNull()
+	model.PatternsAllowed = types.SetNull(types.StringType)
+	if permissions.GetEnabled() && permissions.GetAllowedActions() == "selected" {
+		allowed, _, err := r.client.Repositories.GetActionsAllowed(ctx, owner, repoName)
+		if err != nil {
+			addGitHubError(diags,
+				"Error reading allowed actions",
+				fmt.Sprintf("Unable to read allowed actions for repository %s/%s: %v", owner, repoName, err),
+				err,
+			)
+			return
+		}
+		model.GithubOwnedAllowed = types.BoolValue(allowed.GetGithubOwnedAllowed())
+		model.VerifiedAllowed = types.BoolValue(allowed.GetVerifiedAllowed())
+
+		patterns := make([]string, len(allowed.PatternsAllowed))
+		copy(patterns, allowed.PatternsAllowed)
+		sort.Strings(patterns)
+		patternsSet, setDiags := types.SetValueFrom(ctx, types.StringType, patterns)
+		diags.Append(setDiags...)
+		model.PatternsAllowed = patternsSet
+	}
+
+	workflowPermissions, _, err := r.client.Repositories.GetDefaultWorkflowPermissions(ctx, owner, repoName)
+	if err != nil {
+		addGitHubError(diags,
+			"Error reading workflow permissions",
+			fmt.Sprintf("Unable to read default workflow permissions for repository %s/%s: %v", owner, repoName, err),
+			err,
+		)
+		return
+	}
+	model.DefaultWorkflowPermissions = types.StringValue(workflowPermissions.GetDefaultWorkflowPermissions())
+	model.CanApprovePullRequestReviews = types.BoolValue(workflowPermissions.GetCanApprovePullRequestReviews())
+
+	// The fork approval policy only applies to public repositories, and retention may be
+	// unavailable for some plans, so a missing endpoint leaves the setting unset.
+	forkApproval := &repositoryForkPRApproval{}
+	if err := r.actionsPermissionsRequest(ctx, http.MethodGet, owner, repoName, "fork-pr-contributor-approval", nil, forkApproval); err != nil {
+		if !isNotFound(err) {
+			diags.AddWarning(
+				"Error reading fork pull request approval policy",
+				fmt.Sprintf("Unable to read fork pull request approval policy for repository %s/%s: %v", owner, repoName, err),
+			)
+		}
+		if !isKnown(model.ForkPRApprovalPolicy) {
+			model.ForkPRApprovalPolicy = types.StringNull()
+		}
+	} else {
+		model.ForkPRApprovalPolicy = types.StringValue(forkApproval.GetApprovalPolicy())
+	}
+
+	retention := &repositoryArtifactRetention{}
+	if err := r.actionsPermissionsRequest(ctx, http.MethodGet, owner, repoName, "artifact-and-log-retention", nil, retention); err != nil {
+		if !isNotFound(err) {
+			diags.AddWarning(
+				"Error reading artifact and log retention",
+				fmt.Sprintf("Unable to read artifact and log retention for repository %s/%s: %v", owner, repoName, err),
+			)
+		}
+		if !isKnown(model.RetentionDays) {
+			model.RetentionDays = types.Int64Null()
+		}
+	} else {
+		model.RetentionDays = types.Int64Value(retention.GetDays())
+	}
+}
+
+// actionsPermissionsRequest calls a repository Actions permissions endpoint that the
+// go-github client does not cover.
+func (r *repositoryActionsSettingsResource) actionsPermissionsRequest(ctx context.Context, method, owner, repoName, endpoint string, body, v interface{}) error {
+	u := fmt.Sprintf("repos/%s/%s/actions/permissions/%s", owner, repoName, endpoint)
+	req, err := r.client.NewRequest(method, u, body)
+	if err != nil {
+		return err
+	}
+	_, err = r.client.Do(ctx, req, v)
+	return err
+}
+
+// GetApprovalPolicy returns the ApprovalPolicy field if it's non-nil, zero value otherwise.
+func (f *repositoryForkPRApproval) GetApprovalPolicy() string {
+	if f == nil || f.ApprovalPolicy == nil {
+		return ""
+	}
+	return *f.ApprovalPolicy
+}
+
+// GetDays returns the Days field if it's non-nil, zero value otherwise.
+func (a *repositoryArtifactRetention) GetDays() int64 {
+	if a == nil || a.Days == nil {
+		return 0
+	}
+	return *a.Days
+}
+
+// isKnown reports whether a value is set in the plan.
+func isKnown(value interface {
+	IsNull() bool
+	IsUnknown() bool
+}) bool {
+	return !value.IsNull() && !value.IsUnknown()
+}
@@ -2,7 +2,6 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -13,8 +12,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -24,6 +23,7 @@ var (
 	_ resource.Resource                = &repositoryBranchResource{}
 	_ resource.ResourceWithConfigure   = &repositoryBranchResource{}
 	_ resource.ResourceWithImportState = &repositoryBranchResource{}
+	_ resource.ResourceWithModifyPlan  = &repositoryBranchResource{}
 )
 
 // NewRepositoryBranchResource is a helper function to simplify the provider implementation.
@@ -39,14 +39,17 @@ type repositoryBranchResource struct {
 
 // repositoryBranchResourceModel maps the resource schema data.
 type repositoryBranchResourceModel struct {
-	Repository   types.String `tfsdk:"repository"`
-	Branch       types.String `tfsdk:"branch"`
-	SourceBranch types.String `tfsdk:"source_branch"`
-	SourceSHA    types.String `tfsdk:"source_sha"`
-	ETag         types.String `tfsdk:"etag"`
-	Ref          types.String `tfsdk:"ref"`
-	SHA          types.String `tfsdk:"sha"`
-	ID           types.String `tfsdk:"id"`
+	Repository       types.String `tfsdk:"repository"`
+	Branch           types.String `tfsdk:"branch"`
+	SourceBranch     types.String `tfsdk:"source_branch"`
+	SourceSHA        types.String `tfsdk:"source_sha"`
+	TrackSource      types.Bool   `tfsdk:"track_source"`
+	Force            types.Bool   `tfsdk:"force"`
+	AllowForceUpdate types.Bool   `tfsdk:"allow_force_update"`
+	ETag             types.String `tfsdk:"etag"`
+	Ref              types.String `tfsdk:"ref"`
+	SHA              types.String `tfsdk:"sha"`
+	ID               types.String `tfsdk:"id"`
 }
 
 // Metadata returns the resource type name.
@@ -71,22 +74,41 @@ func (r *repositoryBranchResource) Schema(_ context.Context, _ resource.SchemaRe
 				Required:    true,
 			},
 			"source_branch": schema.StringAttribute{
-				Description: "The branch name to start from. Defaults to 'main'.",
+				Description: "The branch name to start from. Defaults to the repository's default branch. Changing it recreates the branch, unless `allow_force_update` is set.",
 				Optional:    true,
 				Computed:    true,
-				Default:     stringdefault.StaticString("main"),
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+					requiresReplaceUnlessForceUpdate(),
 				},
 			},
 			"source_sha": schema.StringAttribute{
-				Description: "The commit hash to start from. Defaults to the tip of 'source_branch'. If provided, 'source_branch' is ignored.",
+				Description: "The commit hash to start from. Defaults to the tip of 'source_branch'. If provided, 'source_branch' is ignored. Changing it recreates the branch, unless `allow_force_update` is set.",
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+					requiresReplaceUnlessForceUpdate(),
 				},
 			},
+			"track_source": schema.BoolAttribute{
+				Description: "Keep the branch up to date with `source_branch`. When the branch is behind `source_branch`, plans show a change to `sha` and apply fast-forwards the branch. Updates that are not fast-forwards are refused unless `force` is set. Defaults to \"false\".",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"force": schema.BoolAttribute{
+				Description: "Allow `track_source` to reset the branch to `source_branch` when the update is not a fast-forward, discarding commits that are only on the branch. Defaults to \"false\".",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"allow_force_update": schema.BoolAttribute{
+				Description: "Reset the branch in place with a force push when `source_sha` or `source_branch` changes, instead of deleting and recreating it. Open pull requests and branch protection stay attached to the branch, but commits that are only on the branch are discarded. Defaults to \"false\".",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
 			"etag": schema.StringAttribute{
 				Description: "An etag representing the Branch object.",
 				Optional:    true,
@@ -132,6 +154,75 @@ func (r *repositoryBranchResource) Configure(ctx context.Context, req resource.C
 	r.owner = clientData.Owner
 }
 
+// ModifyPlan plans the new `sha` of a branch that is reset in place through
+// `allow_force_update`, or moved to the head of `source_branch` through `track_source`.
+func (r *repositoryBranchResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() || len(resp.RequiresReplace) > 0 {
+		return
+	}
+
+	var plan, state repositoryBranchResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.AllowForceUpdate.ValueBool() {
+		// A new source branch resets the branch to its head, unless source_sha is set
+		if !plan.SourceBranch.Equal(state.SourceBranch) {
+			var configSourceSHA types.String
+			resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("source_sha"), &configSourceSHA)...)
+			if configSourceSHA.IsNull() {
+				plan.SourceSHA = types.StringUnknown()
+				resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("source_sha"), plan.SourceSHA)...)
+			}
+		}
+		if !plan.SourceSHA.Equal(state.SourceSHA) {
+			r.planBranchMove(ctx, req, resp, plan.SourceSHA)
+			return
+		}
+	}
+
+	if !plan.TrackSource.ValueBool() || plan.SourceBranch.IsUnknown() || state.SHA.IsNull() || r.client == nil {
+		return
+	}
+
+	owner, err := r.getOwner(ctx)
+	if err != nil {
+		return
+	}
+
+	repoName := state.Repository.ValueString()
+	sourceBranchName := plan.SourceBranch.ValueString()
+	targetSHA, err := r.trackedSourceSHA(ctx, owner, repoName, sourceBranchName, state.SHA.ValueString(), plan.Force.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Branch not updated from source branch",
+			fmt.Sprintf("Branch %s of %s/%s is not updated from %s: %v", state.Branch.ValueString(), owner, repoName, sourceBranchName, err),
+		)
+		targetSHA = state.SHA.ValueString()
+	}
+
+	if targetSHA == state.SHA.ValueString() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("sha"), targetSHA)...)
+		return
+	}
+	r.planBranchMove(ctx, req, resp, types.StringValue(targetSHA))
+}
+
+// planBranchMove plans a move of the branch to sha, which changes its etag unless the
+// etag is configured.
+func (r *repositoryBranchResource) planBranchMove(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, sha types.String) {
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("sha"), sha)...)
+
+	var configETag types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("etag"), &configETag)...)
+	if configETag.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("etag"), types.StringUnknown())...)
+	}
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *repositoryBranchResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan repositoryBranchResourceModel
@@ -163,39 +254,13 @@ func (r *repositoryBranchResource) Create(ctx context.Context, req resource.Crea
 	repoName := plan.Repository.ValueString()
 	branchName := plan.Branch.ValueString()
 	branchRefName := "refs/heads/" + branchName
-	sourceBranchName := plan.SourceBranch.ValueString()
-	if sourceBranchName == "" {
-		sourceBranchName = "main"
-	}
-	sourceBranchRefName := "refs/heads/" + sourceBranchName
 
-	// Get source SHA
-	var sourceBranchSHA string
-	if !plan.SourceSHA.IsNull() && !plan.SourceSHA.IsUnknown() && plan.SourceSHA.ValueString() != "" {
-		// Use provided source SHA
-		sourceBranchSHA = plan.SourceSHA.ValueString()
-	} else {
-		// Get SHA from source branch
-		ref, _, refErr := r.client.Git.GetRef(ctx, owner, repoName, sourceBranchRefName)
-		if refErr != nil {
-			resp.Diagnostics.AddError(
-				"Error querying source branch",
-				fmt.Sprintf("Unable to query GitHub branch reference %s/%s (%s): %v", owner, repoName, sourceBranchRefName, refErr),
-			)
-			return
-		}
-		if ref.Object != nil && ref.Object.SHA != nil {
-			sourceBranchSHA = *ref.Object.SHA
-			// Set source_sha in plan for state
-			plan.SourceSHA = types.StringValue(sourceBranchSHA)
-		} else {
-			resp.Diagnostics.AddError(
-				"Invalid source branch",
-				fmt.Sprintf("Source branch %s does not have a valid SHA", sourceBranchName),
-			)
-			return
-		}
+	// Resolve the commit to start from
+	resolveSourceSHA(ctx, r.client, owner, repoName, &plan.SourceBranch, &plan.SourceSHA, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	sourceBranchSHA := plan.SourceSHA.ValueString()
 
 	// Create the branch
 	_, _, createErr := r.client.Git.CreateRef(ctx, owner, repoName, &github.Reference{
@@ -204,10 +269,11 @@ func (r *repositoryBranchResource) Create(ctx context.Context, req resource.Crea
 	})
 	// If the branch already exists, rather than erroring out just continue on to reading the branch
 	// This avoids the case where a repo with gitignore_template and branch are being created at the same time crashing terraform
-	if createErr != nil && !strings.HasSuffix(createErr.Error(), "422 Reference already exists []") {
-		resp.Diagnostics.AddError(
+	if createErr != nil && !isReferenceAlreadyExists(createErr) {
+		addGitHubError(&resp.Diagnostics,
 			"Error creating branch",
 			fmt.Sprintf("Unable to create GitHub branch reference %s/%s (%s): %v", owner, repoName, branchRefName, createErr),
+			createErr,
 		)
 		return
 	}
@@ -269,6 +335,10 @@ func (r *repositoryBranchResource) Read(ctx context.Context, req resource.ReadRe
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if state.ID.ValueString() == "" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 
 	// Migrate ID to new format if it was in old format
 	// This ensures backward compatibility and updates state to new format
@@ -327,9 +397,10 @@ func (r *repositoryBranchResource) Update(ctx context.Context, req resource.Upda
 		// Rename the branch
 		_, _, err := r.client.Repositories.RenameBranch(ctx, owner, repoName, oldBranchName, newBranchName)
 		if err != nil {
-			resp.Diagnostics.AddError(
+			addGitHubError(&resp.Diagnostics,
 				"Error renaming branch",
 				fmt.Sprintf("Unable to rename GitHub branch %s/%s (%s -> %s): %v", owner, repoName, oldBranchName, newBranchName, err),
+				err,
 			)
 			return
 		}
@@ -340,6 +411,38 @@ func (r *repositoryBranchResource) Update(ctx context.Context, req resource.Upda
 		plan.ID = state.ID
 	}
 
+	// Move the branch to the commit planned by ModifyPlan. A new source is a force push,
+	// while track_source only forces the update if force is set.
+	targetSHA := plan.SHA
+	force := plan.Force.ValueBool()
+	if plan.AllowForceUpdate.ValueBool() && !plan.SourceSHA.Equal(state.SourceSHA) {
+		resolveSourceSHA(ctx, r.client, owner, repoName, &plan.SourceBranch, &plan.SourceSHA, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		targetSHA = plan.SourceSHA
+		force = true
+	} else if !plan.TrackSource.ValueBool() {
+		targetSHA = state.SHA
+	}
+
+	if !targetSHA.IsUnknown() && !targetSHA.Equal(state.SHA) {
+		branchRefName := "refs/heads/" + newBranchName
+		_, _, err := r.client.Git.UpdateRef(ctx, owner, repoName, &github.Reference{
+			Ref:    github.String(branchRefName),
+			Object: &github.GitObject{SHA: github.String(targetSHA.ValueString())},
+		}, force)
+		if err != nil {
+			addGitHubError(&resp.Diagnostics,
+				"Error updating branch",
+				fmt.Sprintf("Unable to update GitHub branch reference %s/%s (%s) to %s: %v", owner, repoName, branchRefName, targetSHA.ValueString(), err),
+				err,
+			)
+			return
+		}
+		log.Printf("[INFO] Updated branch %s/%s (%s) to %s", owner, repoName, branchRefName, targetSHA.ValueString())
+	}
+
 	// Read the branch to get all computed values
 	r.readBranch(ctx, owner, repoName, newBranchName, &plan, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
@@ -394,22 +497,15 @@ func (r *repositoryBranchResource) Delete(ctx context.Context, req resource.Dele
 	log.Printf("[DEBUG] Deleting branch: %s/%s (%s)", owner, repoName, branchRefName)
 	_, err = r.client.Git.DeleteRef(ctx, owner, repoName, branchRefName)
 	if err != nil {
-		// Check if the branch already doesn't exist (404 or 422 with "Reference does not exist")
-		var ghErr *github.ErrorResponse
-		if errors.As(err, &ghErr) && ghErr.Response != nil {
-			statusCode := ghErr.Response.StatusCode
-			// 404: Branch not found
-			// 422: Reference does not exist (branch was already deleted, e.g., by auto-merge)
-			if statusCode == http.StatusNotFound ||
-				(statusCode == http.StatusUnprocessableEntity &&
-					strings.Contains(err.Error(), "Reference does not exist")) {
-				log.Printf("[INFO] Branch %s/%s (%s) no longer exists, removing from state", owner, repoName, branchRefName)
-				return // Successfully removed from state
-			}
+		// The branch may already be gone, e.g. deleted by auto-merge
+		if isReferenceNotFound(err) {
+			log.Printf("[INFO] Branch %s/%s (%s) no longer exists, removing from state", owner, repoName, branchRefName)
+			return
 		}
-		resp.Diagnostics.AddError(
+		addGitHubError(&resp.Diagnostics,
 			"Error deleting branch",
 			fmt.Sprintf("Unable to delete GitHub branch reference %s/%s (%s): %v", owner, repoName, branchRefName, err),
+			err,
 		)
 		return
 	}
@@ -437,6 +533,13 @@ func (r *repositoryBranchResource) ImportState(ctx context.Context, req resource
 		sourceBranch = parts[2]
 	} else {
 		sourceBranch = "main"
+		if r.client != nil {
+			if owner, err := r.getOwner(ctx); err == nil {
+				if repo, _, err := r.client.Repositories.Get(ctx, owner, repoName); err == nil && repo.GetDefaultBranch() != "" {
+					sourceBranch = repo.GetDefaultBranch()
+				}
+			}
+		}
 	}
 
 	// Set the ID using colon delimiter
@@ -464,6 +567,90 @@ func (r *repositoryBranchResource) getOwner(ctx context.Context) (string, error)
 	return user.GetLogin(), nil
 }
 
+// requiresReplaceUnlessForceUpdate recreates the branch when its source changes, unless
+// `allow_force_update` is set so that the branch can be reset in place.
+func requiresReplaceUnlessForceUpdate() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			var allowForceUpdate types.Bool
+			resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("allow_force_update"), &allowForceUpdate)...)
+			resp.RequiresReplace = !allowForceUpdate.ValueBool()
+		},
+		"Changing the source recreates the branch, unless `allow_force_update` is set.",
+		"Changing the source recreates the branch, unless `allow_force_update` is set.",
+	)
+}
+
+// resolveSourceSHA resolves the commit that a new branch or tag starts from. sourceBranch
+// defaults to the repository's default branch, and sourceSHA, unless it is set, to the
+// head of sourceBranch.
+func resolveSourceSHA(ctx context.Context, client *github.Client, owner, repoName string, sourceBranch, sourceSHA *types.String, diags *diag.Diagnostics) {
+	sourceBranchName := sourceBranch.ValueString()
+	if sourceBranch.IsUnknown() || sourceBranchName == "" {
+		repo, _, err := client.Repositories.Get(ctx, owner, repoName)
+		if err != nil {
+			addGitHubError(diags,
+				"Error reading repository",
+				fmt.Sprintf("Unable to read repository %s/%s to determine the default branch: %v", owner, repoName, err),
+				err,
+			)
+			return
+		}
+		sourceBranchName = repo.GetDefaultBranch()
+		*sourceBranch = types.StringValue(sourceBranchName)
+	}
+	if !sourceSHA.IsNull() && !sourceSHA.IsUnknown() && sourceSHA.ValueString() != "" {
+		return
+	}
+
+	sourceBranchRefName := "refs/heads/" + sourceBranchName
+	ref, _, err := client.Git.GetRef(ctx, owner, repoName, sourceBranchRefName)
+	if err != nil {
+		addGitHubError(diags,
+			"Error querying source branch",
+			fmt.Sprintf("Unable to query GitHub branch reference %s/%s (%s): %v", owner, repoName, sourceBranchRefName, err),
+			err,
+		)
+		return
+	}
+	if ref.GetObject().GetSHA() == "" {
+		diags.AddError(
+			"Invalid source branch",
+			fmt.Sprintf("Source branch %s does not have a valid SHA", sourceBranchName),
+		)
+		return
+	}
+	*sourceSHA = types.StringValue(ref.GetObject().GetSHA())
+}
+
+// trackedSourceSHA returns the commit a tracking branch at sha should be moved to: the
+// head of the source branch if it is a fast-forward or force is set, or sha otherwise.
+// It returns an error if the branches have diverged and force is not set.
+func (r *repositoryBranchResource) trackedSourceSHA(ctx context.Context, owner, repoName, sourceBranchName, sha string, force bool) (string, error) {
+	ref, _, err := r.client.Git.GetRef(ctx, owner, repoName, "refs/heads/"+sourceBranchName)
+	if err != nil {
+		return "", fmt.Errorf("unable to read source branch: %w", err)
+	}
+	sourceSHA := ref.GetObject().GetSHA()
+	if sourceSHA == sha || force {
+		return sourceSHA, nil
+	}
+
+	comparison, _, err := r.client.Repositories.CompareCommits(ctx, owner, repoName, sha, sourceSHA, nil)
+	if err != nil {
+		return "", fmt.Errorf("unable to compare the branch with the source branch: %w", err)
+	}
+	switch comparison.GetStatus() {
+	case "ahead":
+		return sourceSHA, nil
+	case "behind", "identical":
+		// The source branch has nothing the branch does not have already
+		return sha, nil
+	default:
+		return "", fmt.Errorf("the branches have diverged, so the update would not be a fast-forward. Set `force = true` to reset the branch to the source branch")
+	}
+}
+
 // buildTwoPartID creates a two-part ID using colon as delimiter (standard Terraform pattern).
 // Format: "repository:branch".
 func buildTwoPartID(part1, part2 string) string {
@@ -498,23 +685,20 @@ func (r *repositoryBranchResource) readBranch(ctx context.Context, owner, repoNa
 
 	ref, resp, err := r.client.Git.GetRef(ctx, owner, repoName, branchRefName)
 	if err != nil {
-		var ghErr *github.ErrorResponse
-		if errors.As(err, &ghErr) {
-			if resp != nil && resp.StatusCode == http.StatusNotModified {
-				// Branch hasn't changed, use existing state
-				return
-			}
-			if (resp != nil && resp.StatusCode == http.StatusNotFound) ||
-				(ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusNotFound) {
-				log.Printf("[INFO] Removing branch %s/%s (%s) from state because it no longer exists in GitHub",
-					owner, repoName, branchName)
-				model.ID = types.StringValue("")
-				return
-			}
+		if resp != nil && resp.StatusCode == http.StatusNotModified {
+			// Branch hasn't changed, use existing state
+			return
 		}
-		diags.AddError(
+		if isNotFound(err) {
+			log.Printf("[INFO] Removing branch %s/%s (%s) from state because it no longer exists in GitHub",
+				owner, repoName, branchName)
+			model.ID = types.StringValue("")
+			return
+		}
+		addGitHubError(diags,
 			"Error reading branch",
 			fmt.Sprintf("Unable to read branch %s/%s (%s): %v", owner, repoName, branchRefName, err),
+			err,
 		)
 		return
 	}
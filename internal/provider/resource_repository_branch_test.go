@@ -1,10 +1,14 @@
 package provider
 
 import (
+	"encoding/json"
+	"net/http"
 	"testing"
 
 	"github.com/google/go-github/v60/github"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -50,6 +54,21 @@ func TestRepositoryBranchResource_Schema(t *testing.T) {
 	assert.True(t, sourceSHAAttr.IsOptional())
 	assert.True(t, sourceSHAAttr.IsComputed())
 
+	trackSourceAttr, ok := resp.Schema.Attributes["track_source"]
+	assert.True(t, ok)
+	assert.True(t, trackSourceAttr.IsOptional())
+	assert.True(t, trackSourceAttr.IsComputed())
+
+	forceAttr, ok := resp.Schema.Attributes["force"]
+	assert.True(t, ok)
+	assert.True(t, forceAttr.IsOptional())
+	assert.True(t, forceAttr.IsComputed())
+
+	allowForceUpdateAttr, ok := resp.Schema.Attributes["allow_force_update"]
+	assert.True(t, ok)
+	assert.True(t, allowForceUpdateAttr.IsOptional())
+	assert.True(t, allowForceUpdateAttr.IsComputed())
+
 	etagAttr, ok := resp.Schema.Attributes["etag"]
 	assert.True(t, ok)
 	assert.True(t, etagAttr.IsOptional())
@@ -131,3 +150,103 @@ func TestRepositoryBranchResource_Configure(t *testing.T) {
 // should be implemented as acceptance tests with TF_ACC=1 environment variable set.
 // These unit tests verify the schema, metadata, and configuration validation
 // without making API calls.
+
+func TestRepositoryBranchResource_TrackedSourceSHA(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      string
+		force       bool
+		expected    string
+		expectError bool
+	}{
+		{name: "fast-forward", status: "ahead", expected: "source-sha"},
+		{name: "branch ahead of source", status: "behind", expected: "branch-sha"},
+		{name: "diverged", status: "diverged", expectError: true},
+		{name: "diverged with force", status: "diverged", force: true, expected: "source-sha"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestGitHubClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case "/repos/test-owner/test-repo/git/ref/heads/main":
+					_ = json.NewEncoder(w).Encode(map[string]interface{}{"ref": "refs/heads/main", "object": map[string]string{"sha": "source-sha"}})
+				case "/repos/test-owner/test-repo/compare/branch-sha...source-sha":
+					_ = json.NewEncoder(w).Encode(map[string]interface{}{"status": tt.status})
+				default:
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+				}
+			}))
+			r := &repositoryBranchResource{client: client, owner: "test-owner"}
+
+			sha, err := r.trackedSourceSHA(t.Context(), "test-owner", "test-repo", "main", "branch-sha", tt.force)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, sha)
+		})
+	}
+}
+
+func TestRepositoryBranchResource_Update_ForceUpdate(t *testing.T) {
+	var updates []map[string]interface{}
+	client := newTestGitHubClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/repos/test-owner/test-repo/git/refs/heads/deploy" && r.Method == http.MethodPatch:
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			updates = append(updates, body)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ref": "refs/heads/deploy", "object": map[string]interface{}{"sha": body["sha"]}})
+		case r.URL.Path == "/repos/test-owner/test-repo/git/ref/heads/deploy":
+			sha := "old-sha"
+			if len(updates) > 0 {
+				sha = updates[len(updates)-1]["sha"].(string)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ref": "refs/heads/deploy", "object": map[string]string{"sha": sha}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+		}
+	}))
+	r := &repositoryBranchResource{client: client, owner: "test-owner"}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(t.Context(), resource.SchemaRequest{}, schemaResp)
+
+	model := repositoryBranchResourceModel{
+		Repository:       types.StringValue("test-repo"),
+		Branch:           types.StringValue("deploy"),
+		SourceBranch:     types.StringValue("main"),
+		SourceSHA:        types.StringValue("old-sha"),
+		TrackSource:      types.BoolValue(false),
+		Force:            types.BoolValue(false),
+		AllowForceUpdate: types.BoolValue(true),
+		ETag:             types.StringValue(""),
+		Ref:              types.StringValue("refs/heads/deploy"),
+		SHA:              types.StringValue("old-sha"),
+		ID:               types.StringValue("test-repo:deploy"),
+	}
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	assert.False(t, state.Set(t.Context(), &model).HasError())
+
+	model.SourceSHA = types.StringValue("new-sha")
+	model.SHA = types.StringValue("new-sha")
+	model.ETag = types.StringUnknown()
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	assert.False(t, plan.Set(t.Context(), &model).HasError())
+
+	resp := &resource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Update(t.Context(), resource.UpdateRequest{Plan: plan, State: state}, resp)
+	assert.False(t, resp.Diagnostics.HasError(), "unexpected errors: %v", resp.Diagnostics)
+
+	assert.Equal(t, []map[string]interface{}{{"sha": "new-sha", "force": true}}, updates)
+	var updated repositoryBranchResourceModel
+	assert.False(t, resp.State.Get(t.Context(), &updated).HasError())
+	assert.Equal(t, "new-sha", updated.SHA.ValueString())
+	assert.Equal(t, "test-repo:deploy", updated.ID.ValueString())
+}
@@ -0,0 +1,918 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &repositoryDirectoryResource{}
+	_ resource.ResourceWithConfigure   = &repositoryDirectoryResource{}
+	_ resource.ResourceWithImportState = &repositoryDirectoryResource{}
+	_ resource.ResourceWithModifyPlan  = &repositoryDirectoryResource{}
+)
+
+// NewRepositoryDirectoryResource is a helper function to simplify the provider implementation.
+func NewRepositoryDirectoryResource() resource.Resource {
+	return &repositoryDirectoryResource{}
+}
+
+// repositoryDirectoryResource is the resource implementation.
+type repositoryDirectoryResource struct {
+	client *github.Client
+	owner  string
+	signer github.MessageSigner
+}
+
+// repositoryDirectoryResourceModel maps the resource schema data.
+type repositoryDirectoryResourceModel struct {
+	Repository                types.String `tfsdk:"repository"`
+	Branch                    types.String `tfsdk:"branch"`
+	Source                    types.String `tfsdk:"source"`
+	Path                      types.String `tfsdk:"path"`
+	Include                   types.List   `tfsdk:"include"`
+	Exclude                   types.List   `tfsdk:"exclude"`
+	DeleteUnmanaged           types.Bool   `tfsdk:"delete_unmanaged"`
+	CommitMessage             types.String `tfsdk:"commit_message"`
+	CommitAuthor              types.String `tfsdk:"commit_author"`
+	CommitEmail               types.String `tfsdk:"commit_email"`
+	AutocreateBranch          types.Bool   `tfsdk:"autocreate_branch"`
+	AutocreateBranchSource    types.String `tfsdk:"autocreate_branch_source_branch"`
+	AutocreateBranchSourceSHA types.String `tfsdk:"autocreate_branch_source_sha"`
+	FileHashes                types.Map    `tfsdk:"file_hashes"`
+	CommitSHA                 types.String `tfsdk:"commit_sha"`
+	ID                        types.String `tfsdk:"id"`
+}
+
+// Metadata returns the resource type name.
+func (r *repositoryDirectoryResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repository_directory"
+}
+
+// Schema defines the schema for the resource.
+func (r *repositoryDirectoryResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Mirrors a local directory into a path of a GitHub repository branch. All changes are written in a single commit using the Git Data API.",
+		Attributes: map[string]schema.Attribute{
+			"repository": schema.StringAttribute{
+				Description: "The GitHub repository name.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"branch": schema.StringAttribute{
+				Description: "The branch name, defaults to the repository's default branch.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source": schema.StringAttribute{
+				Description: "The path of the local directory to mirror.",
+				Required:    true,
+			},
+			"path": schema.StringAttribute{
+				Description: "The directory in the repository to mirror into. Defaults to the root of the repository.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^$|^[^/](.*[^/])?$`),
+						"must be a relative directory path without leading or trailing slashes",
+					),
+				},
+			},
+			"include": schema.ListAttribute{
+				Description: "Glob patterns of the files to mirror, relative to `source`. `*` matches within a single directory and `**` matches any number of directories. Defaults to all files.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"exclude": schema.ListAttribute{
+				Description: "Glob patterns of the files to leave out, relative to `source`. Takes precedence over `include`.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"delete_unmanaged": schema.BoolAttribute{
+				Description: "Delete files under `path` that match `include` and `exclude` but are not present in `source`, defaults to \"false\".",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"commit_message": schema.StringAttribute{
+				Description: "The commit message when creating, updating or deleting the files. Defaults to a message listing the number of changed files.",
+				Optional:    true,
+			},
+			"commit_author": schema.StringAttribute{
+				Description: "The commit author name, defaults to the authenticated user's name. GitHub app users may omit author and email information so GitHub can verify commits as the GitHub App.",
+				Optional:    true,
+			},
+			"commit_email": schema.StringAttribute{
+				Description: "The commit author email address, defaults to the authenticated user's email address. GitHub app users may omit author and email information so GitHub can verify commits as the GitHub App.",
+				Optional:    true,
+			},
+			"autocreate_branch": schema.BoolAttribute{
+				Description: "Automatically create the branch if it could not be found.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"autocreate_branch_source_branch": schema.StringAttribute{
+				Description: "The branch name to start from, if 'autocreate_branch' is set. Defaults to 'main'.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("main"),
+			},
+			"autocreate_branch_source_sha": schema.StringAttribute{
+				Description: "The commit hash to start from, if 'autocreate_branch' is set. Defaults to the tip of 'autocreate_branch_source_branch'. If provided, 'autocreate_branch_source_branch' is ignored.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"file_hashes": schema.MapAttribute{
+				Description: "A map of repository file paths to the git blob SHA of each mirrored file. The plan shows which files will be added, changed or deleted.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"commit_sha": schema.StringAttribute{
+				Description: "The SHA of the last commit that modified the files.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "The Terraform state ID (repository:branch, or repository:branch:path when `path` is set).",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure enables provider-level data or clients to be set in the
+// provider-defined resource type.
+func (r *repositoryDirectoryResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(githubxClientData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected githubxClientData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = clientData.Client
+	r.owner = clientData.Owner
+	r.signer = clientData.Signer
+}
+
+// ModifyPlan hashes the local directory so that the plan shows which files will change.
+func (r *repositoryDirectoryResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan repositoryDirectoryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Source.IsUnknown() || plan.Path.IsUnknown() || plan.Include.IsUnknown() || plan.Exclude.IsUnknown() {
+		plan.FileHashes = types.MapUnknown(types.StringType)
+		plan.CommitSHA = types.StringUnknown()
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+
+	files := r.localFiles(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hashes := make(map[string]string, len(files))
+	for filePath, content := range files {
+		hashes[filePath] = gitBlobSHA(content)
+	}
+	hashesMap, diags := types.MapValueFrom(ctx, types.StringType, hashes)
+	resp.Diagnostics.Append(diags...)
+
+	if !req.State.Raw.IsNull() {
+		var state repositoryDirectoryResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !hashesMap.Equal(state.FileHashes) {
+			plan.CommitSHA = types.StringUnknown()
+		}
+	}
+
+	plan.FileHashes = hashesMap
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *repositoryDirectoryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan repositoryDirectoryResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			"GitHub client is not configured. Please ensure a token is provided.",
+		)
+		return
+	}
+
+	// Get owner, falling back to authenticated user if not set
+	owner, err := r.getOwner(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Missing Owner",
+			fmt.Sprintf("Unable to determine owner: %v. Please set provider-level `owner` configuration or ensure authentication is working.", err),
+		)
+		return
+	}
+
+	repoName := plan.Repository.ValueString()
+
+	if plan.Branch.IsNull() || plan.Branch.IsUnknown() || plan.Branch.ValueString() == "" {
+		repo, _, err := r.client.Repositories.Get(ctx, owner, repoName)
+		if err != nil {
+			addGitHubError(&resp.Diagnostics,
+				"Error reading repository",
+				fmt.Sprintf("Unable to read repository %s/%s to determine the default branch: %v", owner, repoName, err),
+				err,
+			)
+			return
+		}
+		plan.Branch = types.StringValue(repo.GetDefaultBranch())
+	} else if !ensureBranch(ctx, r.client, owner, repoName, plan.Branch.ValueString(), plan.AutocreateBranch.ValueBool(),
+		plan.AutocreateBranchSource.ValueString(), &plan.AutocreateBranchSourceSHA, &resp.Diagnostics) {
+		return
+	}
+
+	r.syncDirectory(ctx, owner, repoName, &plan, nil, "Add", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *repositoryDirectoryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state repositoryDirectoryResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			"GitHub client is not configured. Please ensure a token is provided.",
+		)
+		return
+	}
+
+	// Get owner, falling back to authenticated user if not set
+	owner, err := r.getOwner(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Missing Owner",
+			fmt.Sprintf("Unable to determine owner: %v. Please set provider-level `owner` configuration or ensure authentication is working.", err),
+		)
+		return
+	}
+
+	r.readDirectory(ctx, owner, state.Repository.ValueString(), &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.ID.ValueString() == "" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *repositoryDirectoryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state repositoryDirectoryResourceModel
+
+	// Read Terraform plan and state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			"GitHub client is not configured. Please ensure a token is provided.",
+		)
+		return
+	}
+
+	// Get owner, falling back to authenticated user if not set
+	owner, err := r.getOwner(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Missing Owner",
+			fmt.Sprintf("Unable to determine owner: %v. Please set provider-level `owner` configuration or ensure authentication is working.", err),
+		)
+		return
+	}
+
+	repoName := plan.Repository.ValueString()
+	if !ensureBranch(ctx, r.client, owner, repoName, plan.Branch.ValueString(), plan.AutocreateBranch.ValueBool(),
+		plan.AutocreateBranchSource.ValueString(), &plan.AutocreateBranchSourceSHA, &resp.Diagnostics) {
+		return
+	}
+
+	plan.CommitSHA = state.CommitSHA
+
+	r.syncDirectory(ctx, owner, repoName, &plan, &state, "Update", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *repositoryDirectoryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state repositoryDirectoryResourceModel
+
+	// Read Terraform state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			"GitHub client is not configured. Please ensure a token is provided.",
+		)
+		return
+	}
+
+	// Get owner, falling back to authenticated user if not set
+	owner, err := r.getOwner(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Missing Owner",
+			fmt.Sprintf("Unable to determine owner: %v. Please set provider-level `owner` configuration or ensure authentication is working.", err),
+		)
+		return
+	}
+
+	repoName := state.Repository.ValueString()
+	branch := state.Branch.ValueString()
+
+	hashes := make(map[string]string, len(state.FileHashes.Elements()))
+	resp.Diagnostics.Append(state.FileHashes.ElementsAs(ctx, &hashes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(hashes) == 0 {
+		return
+	}
+
+	changes := make([]gitTreeChange, 0, len(hashes))
+	for filePath := range hashes {
+		changes = append(changes, gitTreeChange{Path: filePath, Delete: true})
+	}
+
+	commit := r.buildCommit(&state, "Delete", changes, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	log.Printf("[DEBUG] Deleting %d files from %s/%s (%s)", len(changes), owner, repoName, branch)
+	_, err = commitTreeChanges(ctx, r.client, owner, repoName, branch, changes, commit, r.signer, nil)
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[INFO] Branch %s/%s (%s) no longer exists, removing directory from state", owner, repoName, branch)
+			return
+		}
+		addGitHubError(&resp.Diagnostics,
+			"Error deleting files",
+			fmt.Sprintf("Unable to delete files from repository %s/%s (%s): %v", owner, repoName, branch, err),
+			err,
+		)
+		return
+	}
+}
+
+// ImportState imports the resource into Terraform state.
+func (r *repositoryDirectoryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Parse the import ID (format: repository:branch[:path])
+	parts := strings.SplitN(req.ID, ":", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Import ID must be in format 'repository:branch' or 'repository:branch:path'.",
+		)
+		return
+	}
+
+	dirPath := ""
+	if len(parts) == 3 {
+		dirPath = strings.Trim(parts[2], "/")
+	}
+
+	// Every file under the path is treated as managed on the next refresh
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), repositoryDirectoryID(parts[0], parts[1], dirPath))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repository"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("branch"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("path"), dirPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("delete_unmanaged"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("autocreate_branch"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("autocreate_branch_source_branch"), "main")...)
+}
+
+// Helper methods
+
+// getOwner gets the owner, falling back to authenticated user if not set.
+func (r *repositoryDirectoryResource) getOwner(ctx context.Context) (string, error) {
+	if r.owner != "" {
+		return r.owner, nil
+	}
+	// Try to get authenticated user
+	user, _, err := r.client.Users.Get(ctx, "")
+	if err != nil {
+		return "", fmt.Errorf("unable to determine owner: provider-level `owner` is not set and unable to fetch authenticated user: %v", err)
+	}
+	if user == nil || user.Login == nil {
+		return "", fmt.Errorf("unable to determine owner: provider-level `owner` is not set and authenticated user information is unavailable")
+	}
+	return user.GetLogin(), nil
+}
+
+// filters returns the include and exclude patterns of the model.
+func (r *repositoryDirectoryResource) filters(ctx context.Context, model *repositoryDirectoryResourceModel, diags *diag.Diagnostics) ([]string, []string) {
+	var include, exclude []string
+	if !model.Include.IsNull() && !model.Include.IsUnknown() {
+		diags.Append(model.Include.ElementsAs(ctx, &include, false)...)
+	}
+	if !model.Exclude.IsNull() && !model.Exclude.IsUnknown() {
+		diags.Append(model.Exclude.ElementsAs(ctx, &exclude, false)...)
+	}
+	return include, exclude
+}
+
+// localFiles reads the files to mirror from the source directory, keyed by their
+// path in the repository.
+func (r *repositoryDirectoryResource) localFiles(ctx context.Context, model *repositoryDirectoryResourceModel, diags *diag.Diagnostics) map[string][]byte {
+	include, exclude := r.filters(ctx, model, diags)
+	if diags.HasError() {
+		return nil
+	}
+
+	source := model.Source.ValueString()
+	files, err := readLocalDirectory(source, include, exclude)
+	if err != nil {
+		diags.AddError(
+			"Error reading source directory",
+			fmt.Sprintf("Unable to read local directory %s: %v", source, err),
+		)
+		return nil
+	}
+
+	dirPath := model.Path.ValueString()
+	mirrored := make(map[string][]byte, len(files))
+	for relPath, content := range files {
+		mirrored[joinRepositoryPath(dirPath, relPath)] = content
+	}
+	return mirrored
+}
+
+// syncDirectory commits the difference between the source directory and the branch, then
+// refreshes the model. Files in the prior state that are no longer in the source directory
+// are deleted, as are all other matching files under path if delete_unmanaged is set.
+func (r *repositoryDirectoryResource) syncDirectory(ctx context.Context, owner, repoName string, plan, state *repositoryDirectoryResourceModel, verb string, diags *diag.Diagnostics) {
+	branch := plan.Branch.ValueString()
+	dirPath := plan.Path.ValueString()
+
+	files := r.localFiles(ctx, plan, diags)
+	include, exclude := r.filters(ctx, plan, diags)
+	managed := make(map[string]string)
+	if state != nil && !state.FileHashes.IsNull() && !state.FileHashes.IsUnknown() {
+		diags.Append(state.FileHashes.ElementsAs(ctx, &managed, false)...)
+	}
+	if diags.HasError() {
+		return
+	}
+
+	ref, _, err := r.client.Git.GetRef(ctx, owner, repoName, "refs/heads/"+branch)
+	if err != nil {
+		addGitHubError(diags,
+			"Error reading branch",
+			fmt.Sprintf("Unable to read branch %s/%s (%s): %v", owner, repoName, branch, err),
+			err,
+		)
+		return
+	}
+	headSHA := ref.GetObject().GetSHA()
+
+	remote, err := remoteDirectoryBlobSHAs(ctx, r.client, owner, repoName, headSHA, dirPath)
+	if err != nil {
+		addGitHubError(diags,
+			"Error reading files",
+			fmt.Sprintf("Unable to read directory %q from repository %s/%s (%s): %v", dirPath, owner, repoName, branch, err),
+			err,
+		)
+		return
+	}
+
+	var changes []gitTreeChange
+	for filePath, content := range files {
+		if remote[filePath] != gitBlobSHA(content) {
+			changes = append(changes, gitTreeChange{Path: filePath, Content: content})
+		}
+	}
+	for filePath := range remote {
+		if _, ok := files[filePath]; ok {
+			continue
+		}
+		_, isManaged := managed[filePath]
+		if isManaged || (plan.DeleteUnmanaged.ValueBool() && matchesGlobFilters(relativeRepositoryPath(dirPath, filePath), include, exclude)) {
+			changes = append(changes, gitTreeChange{Path: filePath, Delete: true})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+
+	if len(changes) > 0 {
+		commit := r.buildCommit(plan, verb, changes, diags)
+		if diags.HasError() {
+			return
+		}
+
+		commitSHA, err := commitTreeChanges(ctx, r.client, owner, repoName, branch, changes, commit, r.signer, nil)
+		if err != nil {
+			addGitHubError(diags,
+				"Error committing files",
+				fmt.Sprintf("Unable to commit files to repository %s/%s (%s): %v", owner, repoName, branch, err),
+				err,
+			)
+			return
+		}
+		plan.CommitSHA = types.StringValue(commitSHA)
+	} else {
+		log.Printf("[DEBUG] Directory %q in %s/%s (%s) is already up to date", dirPath, owner, repoName, branch)
+	}
+
+	if plan.CommitSHA.IsNull() || plan.CommitSHA.IsUnknown() {
+		plan.CommitSHA = types.StringValue(headSHA)
+	}
+	if !plan.AutocreateBranch.ValueBool() || plan.AutocreateBranchSourceSHA.IsUnknown() {
+		plan.AutocreateBranchSourceSHA = types.StringNull()
+	}
+
+	r.readDirectory(ctx, owner, repoName, plan, diags)
+}
+
+// readDirectory reads the mirrored files from the branch and populates the model. Files
+// changed outside of Terraform get their remote blob SHA, and files deleted outside of
+// Terraform are dropped, so that the next plan restores them.
+func (r *repositoryDirectoryResource) readDirectory(ctx context.Context, owner, repoName string, model *repositoryDirectoryResourceModel, diags *diag.Diagnostics) {
+	branch := model.Branch.ValueString()
+	dirPath := model.Path.ValueString()
+
+	ref, _, err := r.client.Git.GetRef(ctx, owner, repoName, "refs/heads/"+branch)
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[INFO] Removing repository directory %s/%s (%s) from state because the branch no longer exists in GitHub",
+				owner, repoName, branch)
+			model.ID = types.StringValue("")
+			return
+		}
+		addGitHubError(diags,
+			"Error reading branch",
+			fmt.Sprintf("Unable to read branch %s/%s (%s): %v", owner, repoName, branch, err),
+			err,
+		)
+		return
+	}
+
+	remote, err := remoteDirectoryBlobSHAs(ctx, r.client, owner, repoName, ref.GetObject().GetSHA(), dirPath)
+	if err != nil {
+		addGitHubError(diags,
+			"Error reading files",
+			fmt.Sprintf("Unable to read directory %q from repository %s/%s (%s): %v", dirPath, owner, repoName, branch, err),
+			err,
+		)
+		return
+	}
+
+	include, exclude := r.filters(ctx, model, diags)
+	if diags.HasError() {
+		return
+	}
+
+	// Imported directories have no known files yet, so every matching file is managed
+	trackAll := model.DeleteUnmanaged.ValueBool() || model.FileHashes.IsNull() || model.FileHashes.IsUnknown()
+	managed := make(map[string]string)
+	if !model.FileHashes.IsNull() && !model.FileHashes.IsUnknown() {
+		diags.Append(model.FileHashes.ElementsAs(ctx, &managed, false)...)
+		if diags.HasError() {
+			return
+		}
+	}
+
+	hashes := make(map[string]string)
+	for filePath, sha := range remote {
+		if _, ok := managed[filePath]; ok || (trackAll && matchesGlobFilters(relativeRepositoryPath(dirPath, filePath), include, exclude)) {
+			hashes[filePath] = sha
+		}
+	}
+	for filePath := range managed {
+		if _, ok := hashes[filePath]; !ok {
+			log.Printf("[INFO] File %s no longer exists in %s/%s (%s)", filePath, owner, repoName, branch)
+		}
+	}
+
+	hashesMap, mapDiags := types.MapValueFrom(ctx, types.StringType, hashes)
+	diags.Append(mapDiags...)
+
+	model.FileHashes = hashesMap
+	model.Repository = types.StringValue(repoName)
+	model.ID = types.StringValue(repositoryDirectoryID(repoName, branch, dirPath))
+	if model.CommitSHA.IsNull() || model.CommitSHA.IsUnknown() {
+		model.CommitSHA = types.StringValue(ref.GetObject().GetSHA())
+	}
+}
+
+// buildCommit builds the commit message and author for a set of changes. The
+// message defaults to "<verb> <n> files in <path>".
+func (r *repositoryDirectoryResource) buildCommit(model *repositoryDirectoryResourceModel, verb string, changes []gitTreeChange, diags *diag.Diagnostics) *github.Commit {
+	message := model.CommitMessage.ValueString()
+	if message == "" {
+		dirPath := model.Path.ValueString()
+		if dirPath == "" {
+			dirPath = "/"
+		}
+		if len(changes) == 1 {
+			message = fmt.Sprintf("%s %s", verb, changes[0].Path)
+		} else {
+			message = fmt.Sprintf("%s %d files in %s", verb, len(changes), dirPath)
+		}
+	}
+
+	commit := &github.Commit{Message: github.String(message)}
+
+	author := commitAuthor(model.CommitAuthor, model.CommitEmail, diags)
+	if diags.HasError() {
+		return nil
+	}
+	commit.Author = author
+	commit.Committer = author
+
+	return commit
+}
+
+// repositoryDirectoryID builds the ID of a mirrored directory.
+func repositoryDirectoryID(repoName, branch, dirPath string) string {
+	if dirPath == "" {
+		return buildTwoPartID(repoName, branch)
+	}
+	return fmt.Sprintf("%s:%s:%s", repoName, branch, dirPath)
+}
+
+// joinRepositoryPath joins a directory and a relative path in the repository.
+func joinRepositoryPath(dirPath, relPath string) string {
+	if dirPath == "" {
+		return relPath
+	}
+	return dirPath + "/" + relPath
+}
+
+// relativeRepositoryPath returns filePath relative to the directory dirPath.
+func relativeRepositoryPath(dirPath, filePath string) string {
+	if dirPath == "" {
+		return filePath
+	}
+	return strings.TrimPrefix(filePath, dirPath+"/")
+}
+
+// readLocalDirectory returns the contents of the regular files under root that match the
+// include and exclude patterns, keyed by their slash-separated path relative to root.
+func readLocalDirectory(root string, include, exclude []string) (map[string][]byte, error) {
+	for _, pattern := range append(append([]string{}, include...), exclude...) {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid glob pattern %q: %w", pattern, err)
+		}
+	}
+
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", root)
+	}
+
+	files := make(map[string][]byte)
+	err = filepath.WalkDir(root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(root, filePath)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+		if !matchesGlobFilters(relPath, include, exclude) {
+			return nil
+		}
+
+		info, err := os.Stat(filePath)
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			log.Printf("[DEBUG] Skipping %s because it is not a regular file", filePath)
+			return nil
+		}
+
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		files[relPath] = content
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// remoteDirectoryBlobSHAs returns the blob SHAs of the files under dirPath at commitSHA,
+// keyed by their path in the repository. A missing directory has no files.
+func remoteDirectoryBlobSHAs(ctx context.Context, client *github.Client, owner, repoName, commitSHA, dirPath string) (map[string]string, error) {
+	shas := make(map[string]string)
+
+	tree, _, err := client.Git.GetTree(ctx, owner, repoName, commitSHA, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if !tree.GetTruncated() {
+		for _, entry := range tree.Entries {
+			if entry.GetType() == "blob" && (dirPath == "" || strings.HasPrefix(entry.GetPath(), dirPath+"/")) {
+				shas[entry.GetPath()] = entry.GetSHA()
+			}
+		}
+		return shas, nil
+	}
+
+	// The recursive tree of the repository is too large to be returned in full, so walk
+	// down to the directory and read its tree instead
+	log.Printf("[DEBUG] Tree for %s/%s at %s is truncated, reading directory %q on its own", owner, repoName, commitSHA, dirPath)
+	treeSHA := tree.GetSHA()
+	if dirPath != "" {
+		for _, segment := range strings.Split(dirPath, "/") {
+			tree, _, err = client.Git.GetTree(ctx, owner, repoName, treeSHA, false)
+			if err != nil {
+				return nil, err
+			}
+			treeSHA = ""
+			for _, entry := range tree.Entries {
+				if entry.GetType() == "tree" && entry.GetPath() == segment {
+					treeSHA = entry.GetSHA()
+					break
+				}
+			}
+			if treeSHA == "" {
+				return shas, nil
+			}
+		}
+	}
+
+	tree, _, err = client.Git.GetTree(ctx, owner, repoName, treeSHA, true)
+	if err != nil {
+		return nil, err
+	}
+	if tree.GetTruncated() {
+		return nil, fmt.Errorf("directory %q has too many files to be read in a single request", dirPath)
+	}
+	for _, entry := range tree.Entries {
+		if entry.GetType() == "blob" {
+			shas[joinRepositoryPath(dirPath, entry.GetPath())] = entry.GetSHA()
+		}
+	}
+	return shas, nil
+}
+
+// matchesGlobFilters reports whether a slash-separated relative path matches any of the
+// include patterns (or include is empty) and none of the exclude patterns.
+func matchesGlobFilters(relPath string, include, exclude []string) bool {
+	for _, pattern := range exclude {
+		if matchGlob(pattern, relPath) {
+			return false
+		}
+	}
+	if len(include) == 0 {
+		return true
+	}
+	for _, pattern := range include {
+		if matchGlob(pattern, relPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlob reports whether a slash-separated path matches a glob pattern. Each path
+// segment is matched with filepath.Match, and a "**" segment matches any number of
+// directories.
+func matchGlob(pattern, name string) bool {
+	return matchGlobSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchGlobSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchGlobSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := filepath.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
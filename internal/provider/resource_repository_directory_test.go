@@ -0,0 +1,361 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepositoryDirectoryResource_Metadata(t *testing.T) {
+	r := NewRepositoryDirectoryResource()
+	req := resource.MetadataRequest{
+		ProviderTypeName: "githubx",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(t.Context(), req, resp)
+
+	assert.Equal(t, "githubx_repository_directory", resp.TypeName)
+}
+
+func TestRepositoryDirectoryResource_Schema(t *testing.T) {
+	r := NewRepositoryDirectoryResource()
+	req := resource.SchemaRequest{}
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(t.Context(), req, resp)
+
+	assert.NotNil(t, resp.Schema)
+	assert.Contains(t, resp.Schema.Description, "local directory")
+
+	// Check required attributes
+	for _, name := range []string{"repository", "source"} {
+		attr, ok := resp.Schema.Attributes[name]
+		assert.True(t, ok, name)
+		assert.True(t, attr.IsRequired(), name)
+	}
+
+	// Check optional attributes
+	for _, name := range []string{
+		"branch",
+		"path",
+		"include",
+		"exclude",
+		"delete_unmanaged",
+		"commit_message",
+		"commit_author",
+		"commit_email",
+		"autocreate_branch",
+		"autocreate_branch_source_branch",
+		"autocreate_branch_source_sha",
+	} {
+		attr, ok := resp.Schema.Attributes[name]
+		assert.True(t, ok, name)
+		assert.True(t, attr.IsOptional(), name)
+	}
+
+	// Check computed attributes
+	for _, name := range []string{"file_hashes", "commit_sha", "id"} {
+		attr, ok := resp.Schema.Attributes[name]
+		assert.True(t, ok, name)
+		assert.True(t, attr.IsComputed(), name)
+	}
+}
+
+func TestRepositoryDirectoryResource_PlanBranch(t *testing.T) {
+	r := NewRepositoryDirectoryResource()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(t.Context(), resource.SchemaRequest{}, schemaResp)
+
+	state := map[string]interface{}{
+		"repository": "test-repo",
+		"source":     "./files",
+		"branch":     "main",
+	}
+
+	// Changing the source of a resource without a configured branch keeps the branch
+	resp := planStringAttribute(t, schemaResp.Schema, "branch", state, map[string]interface{}{
+		"repository": "test-repo",
+		"source":     "./other",
+	})
+	assert.False(t, resp.RequiresReplace)
+	assert.Equal(t, types.StringValue("main"), resp.PlanValue)
+
+	// Changing the configured branch still replaces the resource
+	resp = planStringAttribute(t, schemaResp.Schema, "branch", state, map[string]interface{}{
+		"repository": "test-repo",
+		"source":     "./files",
+		"branch":     "develop",
+	})
+	assert.True(t, resp.RequiresReplace)
+}
+
+func TestRepositoryDirectoryResource_Configure(t *testing.T) {
+	tests := []struct {
+		name          string
+		providerData  interface{}
+		expectError   bool
+		errorContains string
+	}{
+		{
+			name: "valid githubxClientData",
+			providerData: githubxClientData{
+				Client: github.NewClient(nil),
+				Owner:  "test-owner",
+			},
+			expectError: false,
+		},
+		{
+			name:          "invalid provider data type",
+			providerData:  "invalid",
+			expectError:   true,
+			errorContains: "Unexpected Resource Configure Type",
+		},
+		{
+			name:         "nil provider data",
+			providerData: nil,
+			expectError:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := &repositoryDirectoryResource{}
+			req := resource.ConfigureRequest{
+				ProviderData: tt.providerData,
+			}
+			resp := &resource.ConfigureResponse{}
+
+			rs.Configure(t.Context(), req, resp)
+
+			if tt.expectError {
+				assert.True(t, resp.Diagnostics.HasError())
+				if tt.errorContains != "" {
+					assert.Contains(t, resp.Diagnostics.Errors()[0].Summary(), tt.errorContains)
+				}
+			} else {
+				assert.False(t, resp.Diagnostics.HasError())
+				if tt.providerData != nil {
+					clientData, ok := tt.providerData.(githubxClientData)
+					if ok {
+						assert.Equal(t, clientData.Client, rs.client)
+						assert.Equal(t, clientData.Owner, rs.owner)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestMatchesGlobFilters(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		include  []string
+		exclude  []string
+		expected bool
+	}{
+		{name: "no filters", path: "a/b.yml", expected: true},
+		{name: "single segment wildcard", path: "b.yml", include: []string{"*.yml"}, expected: true},
+		{name: "wildcard does not cross directories", path: "a/b.yml", include: []string{"*.yml"}, expected: false},
+		{name: "double star matches nested", path: "a/b/c.yml", include: []string{"**/*.yml"}, expected: true},
+		{name: "double star matches top level", path: "c.yml", include: []string{"**/*.yml"}, expected: true},
+		{name: "double star suffix", path: "workflows/ci/lint.yml", include: []string{"workflows/**"}, expected: true},
+		{name: "exclude wins", path: "a/secret.yml", include: []string{"**"}, exclude: []string{"**/secret.yml"}, expected: false},
+		{name: "not included", path: "README.md", include: []string{"**/*.yml"}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, matchesGlobFilters(tt.path, tt.include, tt.exclude))
+		})
+	}
+}
+
+func TestReadLocalDirectory(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "ci.yml"), "ci\n")
+	writeTestFile(t, filepath.Join(root, "nested", "lint.yml"), "lint\n")
+	writeTestFile(t, filepath.Join(root, "nested", "notes.txt"), "notes\n")
+
+	files, err := readLocalDirectory(root, []string{"**/*.yml"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"ci.yml": []byte("ci\n"), "nested/lint.yml": []byte("lint\n")}, files)
+
+	_, err = readLocalDirectory(filepath.Join(root, "missing"), nil, nil)
+	assert.Error(t, err)
+
+	_, err = readLocalDirectory(root, []string{"[a-"}, nil)
+	assert.ErrorContains(t, err, "invalid glob pattern")
+}
+
+func writeTestFile(t *testing.T, name, content string) {
+	t.Helper()
+	assert.NoError(t, os.MkdirAll(filepath.Dir(name), 0o755))
+	assert.NoError(t, os.WriteFile(name, []byte(content), 0o600))
+}
+
+// planRepositoryDirectory runs ModifyPlan for a proposed new state, as Terraform does
+// during a plan.
+func planRepositoryDirectory(t *testing.T, rs *repositoryDirectoryResource, proposed tftypes.Value, state tfsdk.State) tfsdk.Plan {
+	t.Helper()
+
+	plan := tfsdk.Plan{Schema: state.Schema, Raw: proposed}
+	resp := &resource.ModifyPlanResponse{Plan: plan}
+	rs.ModifyPlan(t.Context(), resource.ModifyPlanRequest{Plan: plan, State: state}, resp)
+	assert.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+	return resp.Plan
+}
+
+func TestRepositoryDirectoryResource_Lifecycle(t *testing.T) {
+	fake := newFakeGitRepository(map[string]string{
+		"README.md":          "# test-repo\n",
+		"ci/old.yml":         "old\n",
+		"ci/local-notes.txt": "keep\n",
+	})
+	rs := &repositoryDirectoryResource{
+		client: newTestGitHubClient(t, fake),
+		owner:  "test-owner",
+	}
+
+	source := t.TempDir()
+	writeTestFile(t, filepath.Join(source, "build.yml"), "build\n")
+	writeTestFile(t, filepath.Join(source, "jobs", "lint.yml"), "lint\n")
+	writeTestFile(t, filepath.Join(source, "jobs", "skip.txt"), "skip\n")
+
+	schemaResp := &resource.SchemaResponse{}
+	rs.Schema(t.Context(), resource.SchemaRequest{}, schemaResp)
+	objType := schemaResp.Schema.Type().TerraformType(t.Context())
+	emptyState := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objType, nil)}
+
+	proposed := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objType, nil)}
+	diags := proposed.SetAttribute(t.Context(), path.Root("repository"), "test-repo")
+	diags.Append(proposed.SetAttribute(t.Context(), path.Root("source"), source)...)
+	diags.Append(proposed.SetAttribute(t.Context(), path.Root("path"), "ci")...)
+	diags.Append(proposed.SetAttribute(t.Context(), path.Root("include"), []string{"**/*.yml"})...)
+	diags.Append(proposed.SetAttribute(t.Context(), path.Root("delete_unmanaged"), true)...)
+	diags.Append(proposed.SetAttribute(t.Context(), path.Root("autocreate_branch"), false)...)
+	diags.Append(proposed.SetAttribute(t.Context(), path.Root("autocreate_branch_source_branch"), "main")...)
+	diags.Append(proposed.SetAttribute(t.Context(), path.Root("autocreate_branch_source_sha"), types.StringUnknown())...)
+	diags.Append(proposed.SetAttribute(t.Context(), path.Root("branch"), types.StringUnknown())...)
+	diags.Append(proposed.SetAttribute(t.Context(), path.Root("file_hashes"), types.MapUnknown(types.StringType))...)
+	diags.Append(proposed.SetAttribute(t.Context(), path.Root("commit_sha"), types.StringUnknown())...)
+	diags.Append(proposed.SetAttribute(t.Context(), path.Root("id"), types.StringUnknown())...)
+	assert.False(t, diags.HasError())
+
+	// The plan lists the hash of every file that will be mirrored
+	plan := planRepositoryDirectory(t, rs, proposed.Raw, emptyState)
+	var planned map[string]string
+	diags.Append(plan.GetAttribute(t.Context(), path.Root("file_hashes"), &planned)...)
+	assert.Equal(t, map[string]string{
+		"ci/build.yml":     gitBlobSHA([]byte("build\n")),
+		"ci/jobs/lint.yml": gitBlobSHA([]byte("lint\n")),
+	}, planned)
+
+	// Create mirrors the directory and removes unmanaged matching files in a single commit
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw.Copy()}}
+	rs.Create(t.Context(), resource.CreateRequest{Plan: plan}, createResp)
+	assert.False(t, createResp.Diagnostics.HasError(), "unexpected diagnostics: %v", createResp.Diagnostics)
+
+	assert.Equal(t, map[string]string{
+		"README.md":          "# test-repo\n",
+		"ci/build.yml":       "build\n",
+		"ci/jobs/lint.yml":   "lint\n",
+		"ci/local-notes.txt": "keep\n",
+	}, fake.head())
+	assert.Len(t, fake.commits, 2)
+	assert.Equal(t, "Add 3 files in ci", fake.commits[fake.refs["heads/main"]].Message)
+
+	var model repositoryDirectoryResourceModel
+	createResp.Diagnostics.Append(createResp.State.Get(t.Context(), &model)...)
+	assert.Equal(t, "test-repo:main:ci", model.ID.ValueString())
+	assert.Equal(t, "main", model.Branch.ValueString())
+	assert.Equal(t, fake.refs["heads/main"], model.CommitSHA.ValueString())
+
+	var stateHashes map[string]string
+	createResp.Diagnostics.Append(createResp.State.GetAttribute(t.Context(), path.Root("file_hashes"), &stateHashes)...)
+	assert.Equal(t, planned, stateHashes)
+
+	// Re-planning an unchanged directory produces no changes
+	replan := planRepositoryDirectory(t, rs, createResp.State.Raw.Copy(), createResp.State)
+	assert.True(t, replan.Raw.Equal(createResp.State.Raw))
+
+	// A local change shows up as a changed hash and is committed on update
+	writeTestFile(t, filepath.Join(source, "build.yml"), "build v2\n")
+	assert.NoError(t, os.Remove(filepath.Join(source, "jobs", "lint.yml")))
+
+	updatePlan := planRepositoryDirectory(t, rs, createResp.State.Raw.Copy(), createResp.State)
+	var updatedHashes map[string]string
+	diags.Append(updatePlan.GetAttribute(t.Context(), path.Root("file_hashes"), &updatedHashes)...)
+	assert.Equal(t, map[string]string{"ci/build.yml": gitBlobSHA([]byte("build v2\n"))}, updatedHashes)
+	var plannedCommitSHA types.String
+	diags.Append(updatePlan.GetAttribute(t.Context(), path.Root("commit_sha"), &plannedCommitSHA)...)
+	assert.True(t, plannedCommitSHA.IsUnknown())
+
+	commitsBefore := len(fake.commits)
+	updateResp := &resource.UpdateResponse{State: createResp.State}
+	rs.Update(t.Context(), resource.UpdateRequest{Plan: updatePlan, State: createResp.State}, updateResp)
+	assert.False(t, updateResp.Diagnostics.HasError(), "unexpected diagnostics: %v", updateResp.Diagnostics)
+	assert.Equal(t, map[string]string{
+		"README.md":          "# test-repo\n",
+		"ci/build.yml":       "build v2\n",
+		"ci/local-notes.txt": "keep\n",
+	}, fake.head())
+	assert.Len(t, fake.commits, commitsBefore+1)
+
+	// Delete removes the mirrored files only
+	rs.Delete(t.Context(), resource.DeleteRequest{State: updateResp.State}, &resource.DeleteResponse{})
+	assert.Equal(t, map[string]string{
+		"README.md":          "# test-repo\n",
+		"ci/local-notes.txt": "keep\n",
+	}, fake.head())
+}
+
+func TestRepositoryDirectoryResource_Read_Drift(t *testing.T) {
+	fake := newFakeGitRepository(map[string]string{
+		"ci/build.yml": "changed outside terraform\n",
+		"ci/extra.yml": "extra\n",
+	})
+	rs := &repositoryDirectoryResource{
+		client: newTestGitHubClient(t, fake),
+		owner:  "test-owner",
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	rs.Schema(t.Context(), resource.SchemaRequest{}, schemaResp)
+	objType := schemaResp.Schema.Type().TerraformType(t.Context())
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objType, nil)}
+	diags := state.SetAttribute(t.Context(), path.Root("id"), "test-repo:main:ci")
+	diags.Append(state.SetAttribute(t.Context(), path.Root("repository"), "test-repo")...)
+	diags.Append(state.SetAttribute(t.Context(), path.Root("branch"), "main")...)
+	diags.Append(state.SetAttribute(t.Context(), path.Root("path"), "ci")...)
+	diags.Append(state.SetAttribute(t.Context(), path.Root("delete_unmanaged"), false)...)
+	diags.Append(state.SetAttribute(t.Context(), path.Root("file_hashes"), map[string]string{
+		"ci/build.yml":   gitBlobSHA([]byte("build\n")),
+		"ci/deleted.yml": gitBlobSHA([]byte("deleted\n")),
+	})...)
+	assert.False(t, diags.HasError())
+
+	resp := &resource.ReadResponse{State: state}
+	rs.Read(t.Context(), resource.ReadRequest{State: state}, resp)
+	assert.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+
+	// Modified files get their remote hash, deleted files are dropped and unmanaged files are ignored
+	var hashes map[string]string
+	resp.Diagnostics.Append(resp.State.GetAttribute(t.Context(), path.Root("file_hashes"), &hashes)...)
+	assert.Equal(t, map[string]string{"ci/build.yml": gitBlobSHA([]byte("changed outside terraform\n"))}, hashes)
+}
+
+// Note: Tests for Create(), Read(), Update(), and Delete() methods that require GitHub API calls
+// should be implemented as acceptance tests with TF_ACC=1 environment variable set.
+// These unit tests verify the schema, metadata, and configuration validation
+// without making API calls.
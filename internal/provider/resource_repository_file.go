@@ -1,30 +1,47 @@
 package provider
 
 import (
+	"bytes"
 	"context"
-	"errors"
+	"encoding/base64"
 	"fmt"
 	"log"
-	"net/http"
 	"net/url"
+	"os"
+	"regexp"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/google/go-github/v60/github"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Behaviours of a repository file on destroy.
+const (
+	onDestroyDeleteFile                = "delete_file"
+	onDestroyRetain                    = "retain"
+	onDestroyDeleteBranchIfAutocreated = "delete_branch_if_autocreated"
 )
 
 var (
 	_ resource.Resource                = &repositoryFileResource{}
 	_ resource.ResourceWithConfigure   = &repositoryFileResource{}
 	_ resource.ResourceWithImportState = &repositoryFileResource{}
+	_ resource.ResourceWithModifyPlan  = &repositoryFileResource{}
 )
 
 func NewRepositoryFileResource() resource.Resource {
@@ -32,28 +49,58 @@ func NewRepositoryFileResource() resource.Resource {
 }
 
 type repositoryFileResource struct {
-	client *github.Client
-	owner  string
+	client                *github.Client
+	owner                 string
+	signer                github.MessageSigner
+	commitMessageTemplate string
+	commitTrailers        []string
 }
 
 type repositoryFileResourceModel struct {
 	Repository                types.String `tfsdk:"repository"`
 	File                      types.String `tfsdk:"file"`
 	Content                   types.String `tfsdk:"content"`
+	ContentBase64             types.String `tfsdk:"content_base64"`
+	Source                    types.String `tfsdk:"source"`
+	ContentTemplate           types.String `tfsdk:"content_template"`
+	TemplateVars              types.Map    `tfsdk:"template_vars"`
+	RenderedContent           types.String `tfsdk:"rendered_content"`
 	Branch                    types.String `tfsdk:"branch"`
 	Ref                       types.String `tfsdk:"ref"`
 	CommitSHA                 types.String `tfsdk:"commit_sha"`
 	CommitMessage             types.String `tfsdk:"commit_message"`
+	CommitMessageTemplate     types.String `tfsdk:"commit_message_template"`
+	CommitTrailers            types.List   `tfsdk:"commit_trailers"`
 	CommitAuthor              types.String `tfsdk:"commit_author"`
 	CommitEmail               types.String `tfsdk:"commit_email"`
 	SHA                       types.String `tfsdk:"sha"`
+	Verified                  types.Bool   `tfsdk:"verified"`
 	OverwriteOnCreate         types.Bool   `tfsdk:"overwrite_on_create"`
 	AutocreateBranch          types.Bool   `tfsdk:"autocreate_branch"`
 	AutocreateBranchSource    types.String `tfsdk:"autocreate_branch_source_branch"`
 	AutocreateBranchSourceSHA types.String `tfsdk:"autocreate_branch_source_sha"`
+	BranchAutocreated         types.Bool   `tfsdk:"branch_autocreated"`
+	OnDestroy                 types.String `tfsdk:"on_destroy"`
+	NormalizeLineEndings      types.Bool   `tfsdk:"normalize_line_endings"`
+	EnsureTrailingNewline     types.Bool   `tfsdk:"ensure_trailing_newline"`
+	LifecycleMode             types.String `tfsdk:"lifecycle_mode"`
+	BlockMarker               types.String `tfsdk:"block_marker"`
+	PullRequest               types.Object `tfsdk:"pull_request"`
+	PullRequestNumber         types.Int64  `tfsdk:"pull_request_number"`
+	PullRequestURL            types.String `tfsdk:"pull_request_url"`
+	Pending                   types.Bool   `tfsdk:"pending"`
 	ID                        types.String `tfsdk:"id"`
 }
 
+// repositoryFilePullRequestModel represents the `pull_request` block of a repository file.
+type repositoryFilePullRequestModel struct {
+	HeadBranch  types.String `tfsdk:"head_branch"`
+	Title       types.String `tfsdk:"title"`
+	Body        types.String `tfsdk:"body"`
+	AutoMerge   types.Bool   `tfsdk:"auto_merge"`
+	MergeMethod types.String `tfsdk:"merge_method"`
+}
+
 func (r *repositoryFileResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_repository_file"
 }
@@ -77,8 +124,42 @@ func (r *repositoryFileResource) Schema(_ context.Context, _ resource.SchemaRequ
 				},
 			},
 			"content": schema.StringAttribute{
-				Description: "The file's content.",
-				Required:    true,
+				Description: "The file's content. Exactly one of `content`, `content_base64`, `source` or `content_template` must be set.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(
+						path.MatchRoot("content_base64"),
+						path.MatchRoot("source"),
+						path.MatchRoot("content_template"),
+					),
+				},
+			},
+			"content_base64": schema.StringAttribute{
+				Description: "The file's content, base64 encoded. Use this for binary files such as images or archives.",
+				Optional:    true,
+			},
+			"source": schema.StringAttribute{
+				Description: "The path of a local file to upload. Changes to the local file are detected through its git blob SHA.",
+				Optional:    true,
+			},
+			"content_template": schema.StringAttribute{
+				Description: "A Go text/template rendered into the file's content. Templates can use `.Vars` (the `template_vars` map), `.Repository` (`Name`, `Owner`, `FullName`, `Description`, `Homepage`, `DefaultBranch`, `Visibility`, `Topics`, `Private` and `Archived`, read from GitHub), `.Branch` and `.Path`, and the functions `lower`, `upper`, `title`, `trim`, `replace`, `contains`, `join`, `split`, `indent`, `quote`, `default` and `json`. Referencing a missing variable is an error.",
+				Optional:    true,
+				Validators: []validator.String{
+					contentTemplateValidator{},
+				},
+			},
+			"template_vars": schema.MapAttribute{
+				Description: "Variables available to `content_template` as `.Vars`.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.AlsoRequires(path.MatchRoot("content_template")),
+				},
+			},
+			"rendered_content": schema.StringAttribute{
+				Description: "The content rendered from `content_template`, which is what gets committed. Plans show changes to it as a text diff.",
+				Computed:    true,
 			},
 			"branch": schema.StringAttribute{
 				Description: "The branch name, defaults to the repository's default branch.",
@@ -103,6 +184,21 @@ func (r *repositoryFileResource) Schema(_ context.Context, _ resource.SchemaRequ
 				Optional:    true,
 				Computed:    true,
 			},
+			"commit_message_template": schema.StringAttribute{
+				Description: "The commit message template used when `commit_message` is not set, overriding the provider-level `commit_message_template`. See the provider documentation for the available fields and functions.",
+				Optional:    true,
+				Validators: []validator.String{
+					commitMessageTemplateValidator{},
+				},
+			},
+			"commit_trailers": schema.ListAttribute{
+				Description: "Trailers appended to the commit message after the provider-level `commit_trailers`, such as `Co-authored-by: Jane Doe <jane@example.com>`.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(commitTrailerPattern, "must be a trailer in the form 'Token: value'")),
+				},
+			},
 			"commit_author": schema.StringAttribute{
 				Description: "The commit author name, defaults to the authenticated user's name. GitHub app users may omit author and email information so GitHub can verify commits as the GitHub App.",
 				Optional:    true,
@@ -112,7 +208,11 @@ func (r *repositoryFileResource) Schema(_ context.Context, _ resource.SchemaRequ
 				Optional:    true,
 			},
 			"sha": schema.StringAttribute{
-				Description: "The blob SHA of the file.",
+				Description: "The blob SHA of the file. Changes made outside of Terraform are detected by comparing it with the blob SHA of the configured content. If `lifecycle_mode` is `append_block`, it is the blob SHA of the managed section's content.",
+				Computed:    true,
+			},
+			"verified": schema.BoolAttribute{
+				Description: "Whether GitHub verified the signature of the commit that last modified the file. Commits are signed when provider-level `commit_signing` is configured, or by GitHub when authenticating as a GitHub App.",
 				Computed:    true,
 			},
 			"overwrite_on_create": schema.BoolAttribute{
@@ -138,6 +238,93 @@ func (r *repositoryFileResource) Schema(_ context.Context, _ resource.SchemaRequ
 				Optional:    true,
 				Computed:    true,
 			},
+			"branch_autocreated": schema.BoolAttribute{
+				Description: "Whether this resource created `branch` through `autocreate_branch`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"on_destroy": schema.StringAttribute{
+				Description: "What happens to the file when the resource is destroyed. `delete_file` deletes the file with a commit. `retain` leaves the file in the repository and only removes it from state. `delete_branch_if_autocreated` deletes `branch` if this resource created it through `autocreate_branch`, and otherwise deletes the file. Defaults to \"delete_file\".",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(onDestroyDeleteFile),
+				Validators: []validator.String{
+					stringvalidator.OneOf(onDestroyDeleteFile, onDestroyRetain, onDestroyDeleteBranchIfAutocreated),
+				},
+			},
+			"normalize_line_endings": schema.BoolAttribute{
+				Description: "Convert CRLF and CR line endings in text content to LF before it is written, and when comparing it with the file in the repository. Binary content is left alone. Defaults to \"false\".",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"ensure_trailing_newline": schema.BoolAttribute{
+				Description: "End non-empty text content with a newline before it is written, and when comparing it with the file in the repository. Binary content is left alone. Defaults to \"false\".",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"lifecycle_mode": schema.StringAttribute{
+				Description: "How the file is managed. `managed` keeps the whole file in line with the configuration. `create_only` writes the file when the resource is created and then leaves it alone, so changes made outside of Terraform and later changes to the content are not pushed. `append_block` manages only a section of the file between two marker lines, adding it to the end of the file if it is missing and leaving the rest of the file untouched. Defaults to \"managed\".",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(lifecycleModeManaged),
+				Validators: []validator.String{
+					stringvalidator.OneOf(lifecycleModeManaged, lifecycleModeCreateOnly, lifecycleModeAppendBlock),
+				},
+			},
+			"block_marker": schema.StringAttribute{
+				Description: "The marker line template of the managed section, if `lifecycle_mode` is `append_block`. `{mark}` is replaced by BEGIN and END. Use a comment syntax that suits the file, such as `<!-- {mark} MANAGED BY TERRAFORM -->` for Markdown. Defaults to \"# {mark} MANAGED BY TERRAFORM\".",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(defaultBlockMarker),
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`\{mark\}`), "must contain {mark}"),
+				},
+			},
+			"pull_request": schema.SingleNestedAttribute{
+				Description: "Propose changes through a pull request instead of committing to `branch` directly, for branches that are protected against direct pushes. Changes are committed to a head branch, created from `branch` when it does not exist, and a pull request is opened or updated against `branch`. `overwrite_on_create` does not apply, as the change is reviewed in the pull request.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"head_branch": schema.StringAttribute{
+						Description: "The branch to commit changes to. Defaults to `githubx/<file>`, with characters that are not allowed in branch names replaced by `-`.",
+						Optional:    true,
+					},
+					"title": schema.StringAttribute{
+						Description: "The title of the pull request. Defaults to the commit message.",
+						Optional:    true,
+					},
+					"body": schema.StringAttribute{
+						Description: "The body of the pull request.",
+						Optional:    true,
+					},
+					"auto_merge": schema.BoolAttribute{
+						Description: "Enable auto-merge on the pull request, so GitHub merges it once its requirements are met. Pull requests that can be merged straight away are merged during apply. Defaults to \"false\".",
+						Optional:    true,
+					},
+					"merge_method": schema.StringAttribute{
+						Description: "The merge method used with `auto_merge`: merge, squash or rebase. Defaults to \"merge\".",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("merge", "squash", "rebase"),
+						},
+					},
+				},
+			},
+			"pull_request_number": schema.Int64Attribute{
+				Description: "The number of the pull request proposing the last change, in `pull_request` mode.",
+				Computed:    true,
+			},
+			"pull_request_url": schema.StringAttribute{
+				Description: "The URL of the pull request proposing the last change, in `pull_request` mode.",
+				Computed:    true,
+			},
+			"pending": schema.BoolAttribute{
+				Description: "Whether the last change is waiting for its pull request to be merged, in `pull_request` mode. While pending, the file is read from the head branch.",
+				Computed:    true,
+			},
 			"id": schema.StringAttribute{
 				Description: "The Terraform state ID (repository:file).",
 				Computed:    true,
@@ -165,6 +352,74 @@ func (r *repositoryFileResource) Configure(ctx context.Context, req resource.Con
 
 	r.client = clientData.Client
 	r.owner = clientData.Owner
+	r.signer = clientData.Signer
+	r.commitMessageTemplate = clientData.CommitMessageTemplate
+	r.commitTrailers = clientData.CommitTrailers
+}
+
+// ModifyPlan sets the planned blob SHA from the configured content so that changes made
+// outside of Terraform, or to a local `source` file, show up as a difference.
+func (r *repositoryFileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan repositoryFileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Content.IsUnknown() || plan.ContentBase64.IsUnknown() || plan.Source.IsUnknown() ||
+		plan.ContentTemplate.IsUnknown() || plan.TemplateVars.IsUnknown() {
+		return
+	}
+
+	// Render the template during plan so that the diff shows the content. It is left
+	// unknown until apply if the repository does not exist yet.
+	if !plan.ContentTemplate.IsNull() {
+		if r.client == nil || plan.Repository.IsUnknown() || plan.Branch.IsUnknown() {
+			return
+		}
+		owner, err := r.getOwner(ctx)
+		if err != nil {
+			return
+		}
+		if err := r.renderContent(ctx, owner, &plan); err != nil {
+			if isNotFound(err) {
+				return
+			}
+			resp.Diagnostics.AddError(
+				"Invalid Content Template",
+				err.Error(),
+			)
+			return
+		}
+	}
+	plan.RenderedContent = renderedContentOrNull(&plan)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("rendered_content"), plan.RenderedContent)...)
+
+	// Once created, the remote file is left alone in create_only mode
+	if plan.LifecycleMode.ValueString() == lifecycleModeCreateOnly && !req.State.Raw.IsNull() {
+		var state repositoryFileResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("sha"), state.SHA)...)
+		return
+	}
+
+	content, err := fileContentFromModel(&plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid File Content",
+			err.Error(),
+		)
+		return
+	}
+	if plan.LifecycleMode.ValueString() == lifecycleModeAppendBlock {
+		content = managedBlockContent(content)
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("sha"), gitBlobSHA(content))...)
 }
 
 func (r *repositoryFileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -194,25 +449,52 @@ func (r *repositoryFileResource) Create(ctx context.Context, req resource.Create
 
 	repoName := plan.Repository.ValueString()
 	filePath := plan.File.ValueString()
-	content := plan.Content.ValueString()
+	if !r.renderPlannedContent(ctx, owner, &plan, &resp.Diagnostics) {
+		return
+	}
+	content, err := fileContentFromModel(&plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid File Content",
+			err.Error(),
+		)
+		return
+	}
 
+	plan.BranchAutocreated = types.BoolValue(false)
 	if !r.checkAndCreateBranchIfNeeded(ctx, owner, repoName, &plan, &resp.Diagnostics) {
 		return
 	}
 
+	// A managed block is added to the file if it already exists
+	appendBlock := plan.LifecycleMode.ValueString() == lifecycleModeAppendBlock
+	var existingSHA string
+	if appendBlock {
+		content, existingSHA, err = r.contentWithBlock(ctx, owner, repoName, filePath, &plan, content)
+		if err != nil {
+			addGitHubError(&resp.Diagnostics,
+				"Error reading file",
+				fmt.Sprintf("Unable to read file %s from repository %s/%s: %v", filePath, owner, repoName, err),
+				err,
+			)
+			return
+		}
+	}
+	overwrite := plan.OverwriteOnCreate.ValueBool() || appendBlock
+
 	opts, diags := r.buildFileOptions(ctx, &plan, content)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
 		return
 	}
 
-	if opts.Message == nil || *opts.Message == "" {
-		msg := fmt.Sprintf("Add %s", filePath)
-		opts.Message = &msg
-		plan.CommitMessage = types.StringValue(msg)
+	if !r.setCommitMessage(ctx, owner, repoName, filePath, commitActionCreate, &plan, opts, &resp.Diagnostics) {
+		return
 	}
 
-	if plan.OverwriteOnCreate.ValueBool() {
+	if existingSHA != "" {
+		opts.SHA = github.String(existingSHA)
+	} else if plan.OverwriteOnCreate.ValueBool() {
 		checkOpts := &github.RepositoryContentGetOptions{}
 		if !plan.Branch.IsNull() && !plan.Branch.IsUnknown() {
 			checkOpts.Ref = plan.Branch.ValueString()
@@ -223,48 +505,77 @@ func (r *repositoryFileResource) Create(ctx context.Context, req resource.Create
 		}
 	}
 
-	var create *github.RepositoryContentResponse
-	maxRetries := 5
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		create, _, err = r.client.Repositories.CreateFile(ctx, owner, repoName, filePath, opts)
-		if err == nil {
-			break
+	if !plan.PullRequest.IsNull() {
+		if !r.proposeFileChange(ctx, owner, repoName, filePath, opts, false, &plan, &resp.Diagnostics) {
+			return
 		}
-		var ghErr *github.ErrorResponse
-		if errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusConflict {
-			checkOpts := &github.RepositoryContentGetOptions{}
-			if !plan.Branch.IsNull() && !plan.Branch.IsUnknown() {
-				checkOpts.Ref = plan.Branch.ValueString()
+	} else if r.signer != nil {
+		var checkExisting func(existing map[string]string) error
+		if !overwrite {
+			checkExisting = func(existing map[string]string) error {
+				if _, ok := existing[filePath]; ok {
+					return fmt.Errorf("file %s already exists in repository %s/%s. Set 'overwrite_on_create' to true to overwrite it", filePath, owner, repoName)
+				}
+				return nil
 			}
-			fc, _, _, readErr := r.client.Repositories.GetContents(ctx, owner, repoName, filePath, checkOpts)
-			if readErr == nil && fc != nil {
-				if plan.OverwriteOnCreate.ValueBool() {
-					opts.SHA = github.String(fc.GetSHA())
-					continue
-				} else {
-					resp.Diagnostics.AddError(
-						"File Already Exists",
-						fmt.Sprintf("File %s already exists in repository %s/%s. Set 'overwrite_on_create' to true to overwrite it.", filePath, owner, repoName),
-					)
-					return
+		}
+		commitSHA, err := r.commitSignedFile(ctx, owner, repoName, filePath, opts, false, checkExisting)
+		if err != nil {
+			addGitHubError(&resp.Diagnostics,
+				"Error creating file",
+				fmt.Sprintf("Unable to create file %s in repository %s/%s: %v", filePath, owner, repoName, err),
+				err,
+			)
+			return
+		}
+		plan.CommitSHA = types.StringValue(commitSHA)
+	} else {
+		var create *github.RepositoryContentResponse
+		maxRetries := 5
+		for attempt := 0; attempt < maxRetries; attempt++ {
+			create, _, err = r.client.Repositories.CreateFile(ctx, owner, repoName, filePath, opts)
+			if err == nil {
+				break
+			}
+			if isConflict(err) {
+				checkOpts := &github.RepositoryContentGetOptions{}
+				if !plan.Branch.IsNull() && !plan.Branch.IsUnknown() {
+					checkOpts.Ref = plan.Branch.ValueString()
+				}
+				fc, _, _, readErr := r.client.Repositories.GetContents(ctx, owner, repoName, filePath, checkOpts)
+				if readErr == nil && fc != nil {
+					if overwrite {
+						opts.SHA = github.String(fc.GetSHA())
+						continue
+					} else {
+						resp.Diagnostics.AddError(
+							"File Already Exists",
+							fmt.Sprintf("File %s already exists in repository %s/%s. Set 'overwrite_on_create' to true to overwrite it.", filePath, owner, repoName),
+						)
+						return
+					}
 				}
+				continue
 			}
-			continue
+			break
+		}
+		if err != nil {
+			addGitHubError(&resp.Diagnostics,
+				"Error creating file",
+				fmt.Sprintf("Unable to create file %s in repository %s/%s after %d attempts: %v", filePath, owner, repoName, maxRetries, err),
+				err,
+			)
+			return
+		}
+		if create != nil {
+			plan.CommitSHA = types.StringValue(create.GetSHA())
 		}
-		break
 	}
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating file",
-			fmt.Sprintf("Unable to create file %s in repository %s/%s after %d attempts: %v", filePath, owner, repoName, maxRetries, err),
-		)
-		return
+	if plan.PullRequest.IsNull() {
+		resetPullRequestStatus(&plan)
 	}
 
 	plan.ID = types.StringValue(fmt.Sprintf("%s:%s", repoName, filePath))
-	if create != nil {
-		plan.CommitSHA = types.StringValue(create.GetSHA())
-	}
 
 	r.readFile(ctx, owner, repoName, filePath, &plan, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
@@ -312,8 +623,7 @@ func (r *repositoryFileResource) Read(ctx context.Context, req resource.ReadRequ
 	if id == "" {
 		// ID is empty, resource should be removed from state
 		log.Printf("[INFO] Resource ID is empty, removing from state")
-		state.ID = types.StringValue("")
-		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
@@ -332,22 +642,38 @@ func (r *repositoryFileResource) Read(ctx context.Context, req resource.ReadRequ
 	if !state.Branch.IsNull() && !state.Branch.IsUnknown() {
 		branchName := state.Branch.ValueString()
 		if err := r.checkRepositoryBranchExists(ctx, owner, repoName, branchName); err != nil {
+			if !isNotFound(err) {
+				addGitHubError(&resp.Diagnostics,
+					"Error reading branch",
+					fmt.Sprintf("Unable to read branch %s of repository %s/%s: %v", branchName, owner, repoName, err),
+					err,
+				)
+				return
+			}
 			if state.AutocreateBranch.ValueBool() {
 				state.Branch = state.AutocreateBranchSource
 			} else {
 				log.Printf("[INFO] Removing repository file %s/%s/%s from state because the branch no longer exists in GitHub",
 					owner, repoName, filePath)
-				state.ID = types.StringValue("")
-				resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+				resp.State.RemoveResource(ctx)
 				return
 			}
 		}
 	}
 
+	r.readPullRequestStatus(ctx, owner, repoName, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	r.readFile(ctx, owner, repoName, filePath, &state, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if state.ID.ValueString() == "" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 
 	if !state.AutocreateBranch.ValueBool() {
 		state.AutocreateBranchSourceSHA = types.StringNull()
@@ -402,59 +728,123 @@ func (r *repositoryFileResource) Update(ctx context.Context, req resource.Update
 
 	repoName := parts[0]
 	filePath := parts[1]
-	content := plan.Content.ValueString()
+	if !r.renderPlannedContent(ctx, owner, &plan, &resp.Diagnostics) {
+		return
+	}
+	content, err := fileContentFromModel(&plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid File Content",
+			err.Error(),
+		)
+		return
+	}
 
 	if !r.checkAndCreateBranchIfNeeded(ctx, owner, repoName, &plan, &resp.Diagnostics) {
 		return
 	}
 
+	if plan.LifecycleMode.ValueString() == lifecycleModeCreateOnly {
+		// The file was written when it was created and is not updated afterwards
+		plan.ID = state.ID
+		plan.CommitSHA = state.CommitSHA
+		plan.PullRequestNumber = state.PullRequestNumber
+		plan.PullRequestURL = state.PullRequestURL
+		plan.Pending = state.Pending
+
+		r.readFile(ctx, owner, repoName, filePath, &plan, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		return
+	}
+
+	var existingSHA string
+	if plan.LifecycleMode.ValueString() == lifecycleModeAppendBlock {
+		content, existingSHA, err = r.contentWithBlock(ctx, owner, repoName, filePath, &plan, content)
+		if err != nil {
+			addGitHubError(&resp.Diagnostics,
+				"Error reading file",
+				fmt.Sprintf("Unable to read file %s from repository %s/%s: %v", filePath, owner, repoName, err),
+				err,
+			)
+			return
+		}
+	}
+
 	opts, diags := r.buildFileOptions(ctx, &plan, content)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
 		return
 	}
 
-	if !state.SHA.IsNull() && !state.SHA.IsUnknown() {
+	if plan.LifecycleMode.ValueString() == lifecycleModeAppendBlock {
+		// The SHA in state is the managed block's, not the file's
+		opts.SHA = nil
+		if existingSHA != "" {
+			opts.SHA = github.String(existingSHA)
+		}
+	} else if !state.SHA.IsNull() && !state.SHA.IsUnknown() {
 		opts.SHA = github.String(state.SHA.ValueString())
 	}
 
-	if opts.Message == nil || *opts.Message == "" || *opts.Message == fmt.Sprintf("Add %s", filePath) {
-		msg := fmt.Sprintf("Update %s", filePath)
-		opts.Message = &msg
-		plan.CommitMessage = types.StringValue(msg)
+	if !r.setCommitMessage(ctx, owner, repoName, filePath, commitActionUpdate, &plan, opts, &resp.Diagnostics) {
+		return
 	}
 
-	var create *github.RepositoryContentResponse
-	maxRetries := 5
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		create, _, err = r.client.Repositories.CreateFile(ctx, owner, repoName, filePath, opts)
-		if err == nil {
-			break
+	if !plan.PullRequest.IsNull() {
+		if !r.proposeFileChange(ctx, owner, repoName, filePath, opts, false, &plan, &resp.Diagnostics) {
+			return
+		}
+	} else if r.signer != nil {
+		commitSHA, err := r.commitSignedFile(ctx, owner, repoName, filePath, opts, false, nil)
+		if err != nil {
+			addGitHubError(&resp.Diagnostics,
+				"Error updating file",
+				fmt.Sprintf("Unable to update file %s in repository %s/%s: %v", filePath, owner, repoName, err),
+				err,
+			)
+			return
 		}
-		var ghErr *github.ErrorResponse
-		if errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusConflict {
-			updateOpts := &github.RepositoryContentGetOptions{}
-			if !plan.Branch.IsNull() && !plan.Branch.IsUnknown() {
-				updateOpts.Ref = plan.Branch.ValueString()
+		plan.CommitSHA = types.StringValue(commitSHA)
+	} else {
+		var create *github.RepositoryContentResponse
+		maxRetries := 5
+		for attempt := 0; attempt < maxRetries; attempt++ {
+			create, _, err = r.client.Repositories.CreateFile(ctx, owner, repoName, filePath, opts)
+			if err == nil {
+				break
 			}
-			fc, _, _, retryErr := r.client.Repositories.GetContents(ctx, owner, repoName, filePath, updateOpts)
-			if retryErr == nil && fc != nil {
-				opts.SHA = github.String(fc.GetSHA())
-				continue
+			if isConflict(err) {
+				updateOpts := &github.RepositoryContentGetOptions{}
+				if !plan.Branch.IsNull() && !plan.Branch.IsUnknown() {
+					updateOpts.Ref = plan.Branch.ValueString()
+				}
+				fc, _, _, retryErr := r.client.Repositories.GetContents(ctx, owner, repoName, filePath, updateOpts)
+				if retryErr == nil && fc != nil {
+					opts.SHA = github.String(fc.GetSHA())
+					continue
+				}
 			}
+			break
 		}
-		break
+		if err != nil {
+			addGitHubError(&resp.Diagnostics,
+				"Error updating file",
+				fmt.Sprintf("Unable to update file %s in repository %s/%s after %d attempts: %v", filePath, owner, repoName, maxRetries, err),
+				err,
+			)
+			return
+		}
+		plan.CommitSHA = types.StringValue(create.GetSHA())
 	}
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating file",
-			fmt.Sprintf("Unable to update file %s in repository %s/%s after %d attempts: %v", filePath, owner, repoName, maxRetries, err),
-		)
-		return
+	if plan.PullRequest.IsNull() {
+		resetPullRequestStatus(&plan)
 	}
 
 	plan.ID = state.ID
-	plan.CommitSHA = types.StringValue(create.GetSHA())
 
 	r.readFile(ctx, owner, repoName, filePath, &plan, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
@@ -508,59 +898,111 @@ func (r *repositoryFileResource) Delete(ctx context.Context, req resource.Delete
 	repoName := parts[0]
 	filePath := parts[1]
 
+	switch state.OnDestroy.ValueString() {
+	case onDestroyRetain:
+		log.Printf("[INFO] Leaving repository file %s/%s/%s in place, as 'on_destroy' is set to retain", owner, repoName, filePath)
+		return
+	case onDestroyDeleteBranchIfAutocreated:
+		if state.BranchAutocreated.ValueBool() {
+			r.deleteAutocreatedBranch(ctx, owner, repoName, state.Branch.ValueString(), &resp.Diagnostics)
+			return
+		}
+	}
+
 	if !r.checkAndCreateBranchIfNeeded(ctx, owner, repoName, &state, &resp.Diagnostics) {
 		return
 	}
 
-	opts, diags := r.buildFileOptions(ctx, &state, "")
+	opts, diags := r.buildFileOptions(ctx, &state, nil)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
 		return
 	}
 
-	if opts.Message == nil || *opts.Message == "" || *opts.Message == fmt.Sprintf("Add %s", filePath) {
-		msg := fmt.Sprintf("Delete %s", filePath)
-		opts.Message = &msg
-	}
-
 	if !state.SHA.IsNull() && !state.SHA.IsUnknown() {
 		opts.SHA = github.String(state.SHA.ValueString())
 	}
 
+	if state.LifecycleMode.ValueString() == lifecycleModeAppendBlock && !state.Pending.ValueBool() {
+		// Only the managed block is removed, unless nothing else is left in the file
+		current, sha, err := r.currentFileContent(ctx, owner, repoName, filePath, r.contentRef(ctx, filePath, &state))
+		if err != nil {
+			addGitHubError(&resp.Diagnostics,
+				"Error reading file",
+				fmt.Sprintf("Unable to read file %s from repository %s/%s: %v", filePath, owner, repoName, err),
+				err,
+			)
+			return
+		}
+		if sha == "" {
+			return
+		}
+		opts.SHA = github.String(sha)
+		if remaining := removeManagedBlock(current, state.BlockMarker.ValueString()); len(bytes.TrimSpace(remaining)) > 0 {
+			if !r.setCommitMessage(ctx, owner, repoName, filePath, commitActionUpdate, &state, opts, &resp.Diagnostics) {
+				return
+			}
+			opts.Content = remaining
+			r.writeFile(ctx, owner, repoName, filePath, opts, &state, &resp.Diagnostics)
+			return
+		}
+	}
+
+	if !r.setCommitMessage(ctx, owner, repoName, filePath, commitActionDelete, &state, opts, &resp.Diagnostics) {
+		return
+	}
+
+	if !state.PullRequest.IsNull() {
+		// Withdraw a change that has not been merged yet, otherwise propose the deletion
+		if state.Pending.ValueBool() {
+			r.closePullRequest(ctx, owner, repoName, filePath, &state, &resp.Diagnostics)
+			return
+		}
+		r.proposeFileChange(ctx, owner, repoName, filePath, opts, true, &state, &resp.Diagnostics)
+		return
+	}
+
+	if r.signer != nil {
+		if _, err := r.commitSignedFile(ctx, owner, repoName, filePath, opts, true, nil); err != nil && !isNotFound(err) {
+			addGitHubError(&resp.Diagnostics,
+				"Error deleting file",
+				fmt.Sprintf("Unable to delete file %s from repository %s/%s: %v", filePath, owner, repoName, err),
+				err,
+			)
+		}
+		return
+	}
+
 	maxRetries := 5
 	for attempt := 0; attempt < maxRetries; attempt++ {
 		_, _, err = r.client.Repositories.DeleteFile(ctx, owner, repoName, filePath, opts)
 		if err == nil {
 			return
 		}
-		var ghErr *github.ErrorResponse
-		if errors.As(err, &ghErr) && ghErr.Response != nil {
-			if ghErr.Response.StatusCode == http.StatusNotFound {
+		if isNotFound(err) {
+			return
+		}
+		if isConflict(err) {
+			getOpts := &github.RepositoryContentGetOptions{}
+			if !state.Branch.IsNull() && !state.Branch.IsUnknown() {
+				getOpts.Ref = state.Branch.ValueString()
+			}
+			fc, _, _, readErr := r.client.Repositories.GetContents(ctx, owner, repoName, filePath, getOpts)
+			if isNotFound(readErr) {
 				return
-			} else if ghErr.Response.StatusCode == http.StatusConflict {
-				getOpts := &github.RepositoryContentGetOptions{}
-				if !state.Branch.IsNull() && !state.Branch.IsUnknown() {
-					getOpts.Ref = state.Branch.ValueString()
-				}
-				fc, _, _, readErr := r.client.Repositories.GetContents(ctx, owner, repoName, filePath, getOpts)
-				if readErr != nil {
-					var readGhErr *github.ErrorResponse
-					if errors.As(readErr, &readGhErr) && readGhErr.Response != nil && readGhErr.Response.StatusCode == http.StatusNotFound {
-						return
-					}
-				}
-				if fc != nil {
-					opts.SHA = github.String(fc.GetSHA())
-					continue
-				}
+			}
+			if fc != nil {
+				opts.SHA = github.String(fc.GetSHA())
+				continue
 			}
 		}
 		break
 	}
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addGitHubError(&resp.Diagnostics,
 			"Error deleting file",
 			fmt.Sprintf("Unable to delete file %s from repository %s/%s after %d attempts: %v", filePath, owner, repoName, maxRetries, err),
+			err,
 		)
 		return
 	}
@@ -599,9 +1041,10 @@ func (r *repositoryFileResource) ImportState(ctx context.Context, req resource.I
 
 	fc, _, _, err := r.client.Repositories.GetContents(ctx, owner, repoName, filePath, opts)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addGitHubError(&resp.Diagnostics,
 			"Error importing file",
 			fmt.Sprintf("Unable to read file %s from repository %s/%s: %v", filePath, owner, repoName, err),
+			err,
 		)
 		return
 	}
@@ -618,6 +1061,12 @@ func (r *repositoryFileResource) ImportState(ctx context.Context, req resource.I
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repository"), repoName)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("file"), filePath)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("overwrite_on_create"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("normalize_line_endings"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("ensure_trailing_newline"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("lifecycle_mode"), lifecycleModeManaged)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("on_destroy"), onDestroyDeleteFile)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("branch_autocreated"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("block_marker"), defaultBlockMarker)...)
 	if branch != "" {
 		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("branch"), branch)...)
 	}
@@ -649,67 +1098,105 @@ func (r *repositoryFileResource) checkAndCreateBranchIfNeeded(ctx context.Contex
 		return true
 	}
 
-	branchName := model.Branch.ValueString()
-	if err := r.checkRepositoryBranchExists(ctx, owner, repo, branchName); err != nil {
-		if !model.AutocreateBranch.ValueBool() {
-			diags.AddError(
-				"Branch Not Found",
-				fmt.Sprintf("Branch %s not found in repository %s/%s. Set 'autocreate_branch' to true to automatically create it.", branchName, owner, repo),
-			)
+	if model.AutocreateBranch.ValueBool() && r.checkRepositoryBranchExists(ctx, owner, repo, model.Branch.ValueString()) != nil {
+		if !ensureBranch(ctx, r.client, owner, repo, model.Branch.ValueString(), true,
+			model.AutocreateBranchSource.ValueString(), &model.AutocreateBranchSourceSHA, diags) {
 			return false
 		}
+		model.BranchAutocreated = types.BoolValue(true)
+		return true
+	}
 
-		branchRefName := "refs/heads/" + branchName
-		sourceBranchName := model.AutocreateBranchSource.ValueString()
-		if sourceBranchName == "" {
-			sourceBranchName = "main"
-		}
-		sourceBranchRefName := "refs/heads/" + sourceBranchName
-
-		var sourceBranchSHA string
-		if !model.AutocreateBranchSourceSHA.IsNull() && !model.AutocreateBranchSourceSHA.IsUnknown() && model.AutocreateBranchSourceSHA.ValueString() != "" {
-			sourceBranchSHA = model.AutocreateBranchSourceSHA.ValueString()
-		} else {
-			ref, _, err := r.client.Git.GetRef(ctx, owner, repo, sourceBranchRefName)
-			if err != nil {
-				diags.AddError(
-					"Error querying source branch",
-					fmt.Sprintf("Unable to query GitHub branch reference %s/%s (%s): %v", owner, repo, sourceBranchRefName, err),
-				)
-				return false
-			}
-			if ref.Object != nil && ref.Object.SHA != nil {
-				sourceBranchSHA = *ref.Object.SHA
-				model.AutocreateBranchSourceSHA = types.StringValue(sourceBranchSHA)
-			} else {
-				diags.AddError(
-					"Invalid source branch",
-					fmt.Sprintf("Source branch %s does not have a valid SHA", sourceBranchName),
-				)
-				return false
-			}
+	return ensureBranch(ctx, r.client, owner, repo, model.Branch.ValueString(), model.AutocreateBranch.ValueBool(),
+		model.AutocreateBranchSource.ValueString(), &model.AutocreateBranchSourceSHA, diags)
+}
+
+// deleteAutocreatedBranch deletes a branch that the resource created, which removes the
+// file along with it.
+func (r *repositoryFileResource) deleteAutocreatedBranch(ctx context.Context, owner, repoName, branch string, diags *diag.Diagnostics) {
+	_, err := r.client.Git.DeleteRef(ctx, owner, repoName, "refs/heads/"+branch)
+	if err != nil {
+		if isReferenceNotFound(err) {
+			log.Printf("[INFO] Branch %s of repository %s/%s no longer exists", branch, owner, repoName)
+			return
 		}
+		addGitHubError(diags,
+			"Error deleting branch",
+			fmt.Sprintf("Unable to delete branch %s from repository %s/%s: %v", branch, owner, repoName, err),
+			err,
+		)
+		return
+	}
+	log.Printf("[INFO] Deleted branch %s of repository %s/%s", branch, owner, repoName)
+}
 
-		_, _, err := r.client.Git.CreateRef(ctx, owner, repo, &github.Reference{
-			Ref:    &branchRefName,
-			Object: &github.GitObject{SHA: &sourceBranchSHA},
-		})
+// ensureBranch checks that a branch exists and, if autocreate is set, creates it when it does not.
+// The new branch starts from sourceSHA when it is set, otherwise from the tip of sourceBranch
+// (defaulting to "main"), and sourceSHA is updated with the commit it was created from.
+func ensureBranch(ctx context.Context, client *github.Client, owner, repo, branchName string, autocreate bool, sourceBranchName string, sourceSHA *types.String, diags *diag.Diagnostics) bool {
+	branchRefName := "refs/heads/" + branchName
+	if _, _, err := client.Git.GetRef(ctx, owner, repo, branchRefName); err == nil {
+		return true
+	}
+
+	if !autocreate {
+		diags.AddError(
+			"Branch Not Found",
+			fmt.Sprintf("Branch %s not found in repository %s/%s. Set 'autocreate_branch' to true to automatically create it.", branchName, owner, repo),
+		)
+		return false
+	}
+
+	if sourceBranchName == "" {
+		sourceBranchName = "main"
+	}
+	sourceBranchRefName := "refs/heads/" + sourceBranchName
+
+	var sourceBranchSHA string
+	if !sourceSHA.IsNull() && !sourceSHA.IsUnknown() && sourceSHA.ValueString() != "" {
+		sourceBranchSHA = sourceSHA.ValueString()
+	} else {
+		ref, _, err := client.Git.GetRef(ctx, owner, repo, sourceBranchRefName)
 		if err != nil {
+			addGitHubError(diags,
+				"Error querying source branch",
+				fmt.Sprintf("Unable to query GitHub branch reference %s/%s (%s): %v", owner, repo, sourceBranchRefName, err),
+				err,
+			)
+			return false
+		}
+		if ref.Object != nil && ref.Object.SHA != nil {
+			sourceBranchSHA = *ref.Object.SHA
+			*sourceSHA = types.StringValue(sourceBranchSHA)
+		} else {
 			diags.AddError(
-				"Error creating branch",
-				fmt.Sprintf("Unable to create GitHub branch reference %s/%s (%s): %v", owner, repo, branchRefName, err),
+				"Invalid source branch",
+				fmt.Sprintf("Source branch %s does not have a valid SHA", sourceBranchName),
 			)
 			return false
 		}
 	}
+
+	_, _, err := client.Git.CreateRef(ctx, owner, repo, &github.Reference{
+		Ref:    &branchRefName,
+		Object: &github.GitObject{SHA: &sourceBranchSHA},
+	})
+	if err != nil {
+		addGitHubError(diags,
+			"Error creating branch",
+			fmt.Sprintf("Unable to create GitHub branch reference %s/%s (%s): %v", owner, repo, branchRefName, err),
+			err,
+		)
+		return false
+	}
 	return true
 }
 
-func (r *repositoryFileResource) buildFileOptions(_ context.Context, model *repositoryFileResourceModel, content string) (*github.RepositoryContentFileOptions, diag.Diagnostics) {
+func (r *repositoryFileResource) buildFileOptions(_ context.Context, model *repositoryFileResourceModel, content []byte) (*github.RepositoryContentFileOptions, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	opts := &github.RepositoryContentFileOptions{
-		Content: []byte(content),
+		Content: content,
 	}
 
 	if !model.Branch.IsNull() && !model.Branch.IsUnknown() {
@@ -721,63 +1208,33 @@ func (r *repositoryFileResource) buildFileOptions(_ context.Context, model *repo
 		opts.Message = &msg
 	}
 
-	hasCommitAuthor := !model.CommitAuthor.IsNull() && !model.CommitAuthor.IsUnknown()
-	hasCommitEmail := !model.CommitEmail.IsNull() && !model.CommitEmail.IsUnknown()
-
-	if hasCommitAuthor && !hasCommitEmail {
-		diags.AddError(
-			"Invalid Commit Author Configuration",
-			"Cannot set commit_author without setting commit_email",
-		)
-		return nil, diags
-	}
-
-	if hasCommitEmail && !hasCommitAuthor {
-		diags.AddError(
-			"Invalid Commit Author Configuration",
-			"Cannot set commit_email without setting commit_author",
-		)
+	author := commitAuthor(model.CommitAuthor, model.CommitEmail, &diags)
+	if diags.HasError() {
 		return nil, diags
 	}
-
-	if hasCommitAuthor && hasCommitEmail {
-		name := model.CommitAuthor.ValueString()
-		email := model.CommitEmail.ValueString()
-		opts.Author = &github.CommitAuthor{Name: &name, Email: &email}
-		opts.Committer = &github.CommitAuthor{Name: &name, Email: &email}
-	}
+	opts.Author = author
+	opts.Committer = author
 
 	return opts, diags
 }
 
 func (r *repositoryFileResource) readFile(ctx context.Context, owner, repoName, filePath string, model *repositoryFileResourceModel, diags *diag.Diagnostics) {
-	opts := &github.RepositoryContentGetOptions{}
-
-	if !model.Branch.IsNull() && !model.Branch.IsUnknown() {
-		opts.Ref = model.Branch.ValueString()
+	opts := &github.RepositoryContentGetOptions{
+		Ref: r.contentRef(ctx, filePath, model),
 	}
 
 	fc, _, _, err := r.client.Repositories.GetContents(ctx, owner, repoName, filePath, opts)
 	if err != nil {
-		var ghErr *github.ErrorResponse
-		if errors.As(err, &ghErr) {
-			if ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusTooManyRequests {
-				diags.AddError(
-					"Rate Limit Exceeded",
-					fmt.Sprintf("GitHub API rate limit exceeded: %v", err),
-				)
-				return
-			}
-			if ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusNotFound {
-				log.Printf("[INFO] Removing repository file %s/%s/%s from state because it no longer exists in GitHub",
-					owner, repoName, filePath)
-				model.ID = types.StringValue("")
-				return
-			}
+		if isNotFound(err) {
+			log.Printf("[INFO] Removing repository file %s/%s/%s from state because it no longer exists in GitHub",
+				owner, repoName, filePath)
+			model.ID = types.StringValue("")
+			return
 		}
-		diags.AddError(
+		addGitHubError(diags,
 			"Error reading file",
 			fmt.Sprintf("Unable to read file %s from repository %s/%s: %v", filePath, owner, repoName, err),
+			err,
 		)
 		return
 	}
@@ -789,19 +1246,54 @@ func (r *repositoryFileResource) readFile(ctx context.Context, owner, repoName,
 		return
 	}
 
-	content, err := fc.GetContent()
-	if err != nil {
-		diags.AddError(
-			"Error reading file content",
-			fmt.Sprintf("Unable to get content from file %s/%s/%s: %v", owner, repoName, filePath, err),
-		)
-		return
+	blobSHA := fc.GetSHA()
+	switch model.LifecycleMode.ValueString() {
+	case lifecycleModeCreateOnly:
+		// Changes made outside of Terraform are left alone once the file exists
+	case lifecycleModeAppendBlock:
+		content, err := repositoryFileContent(ctx, r.client, owner, repoName, fc)
+		if err != nil {
+			addGitHubError(diags,
+				"Error reading file content",
+				fmt.Sprintf("Unable to get content from file %s/%s/%s: %v", owner, repoName, filePath, err),
+				err,
+			)
+			return
+		}
+		block, _ := extractManagedBlock(content, model.BlockMarker.ValueString())
+		block = normalizeFileContent(block, model.NormalizeLineEndings.ValueBool(), model.EnsureTrailingNewline.ValueBool())
+		blobSHA = gitBlobSHA(block)
+		expected, err := fileContentFromModel(model)
+		if err != nil || gitBlobSHA(managedBlockContent(expected)) != blobSHA {
+			setFileContent(model, block)
+		}
+	default:
+		// Only read the content back when it no longer matches, so binary files and
+		// local sources do not produce a difference on every refresh
+		expected, err := fileContentFromModel(model)
+		if err != nil || gitBlobSHA(expected) != blobSHA {
+			content, readErr := repositoryFileContent(ctx, r.client, owner, repoName, fc)
+			if readErr != nil {
+				addGitHubError(diags,
+					"Error reading file content",
+					fmt.Sprintf("Unable to get content from file %s/%s/%s: %v", owner, repoName, filePath, readErr),
+					readErr,
+				)
+				return
+			}
+			// Content that only differs in line endings or a trailing newline is in sync
+			content = normalizeFileContent(content, model.NormalizeLineEndings.ValueBool(), model.EnsureTrailingNewline.ValueBool())
+			if err == nil && bytes.Equal(content, expected) {
+				blobSHA = gitBlobSHA(expected)
+			} else {
+				setFileContent(model, content)
+			}
+		}
 	}
 
-	model.Content = types.StringValue(content)
 	model.Repository = types.StringValue(repoName)
 	model.File = types.StringValue(filePath)
-	model.SHA = types.StringValue(fc.GetSHA())
+	model.SHA = types.StringValue(blobSHA)
 
 	parsedURL, err := url.Parse(fc.GetURL())
 	if err != nil {
@@ -826,8 +1318,8 @@ func (r *repositoryFileResource) readFile(ctx context.Context, owner, repoName,
 	}
 
 	ref := model.Ref.ValueString()
-	if ref == "" && !model.Branch.IsNull() && !model.Branch.IsUnknown() {
-		ref = model.Branch.ValueString()
+	if ref == "" {
+		ref = opts.Ref
 	}
 
 	if ref != "" {
@@ -844,6 +1336,7 @@ func (r *repositoryFileResource) readFile(ctx context.Context, owner, repoName,
 			)
 		} else {
 			model.CommitSHA = types.StringValue(commit.GetSHA())
+			model.Verified = types.BoolValue(commit.GetCommit().GetVerification().GetVerified())
 
 			if commit.Commit != nil {
 				// Only set commit message if not already set to preserve user-provided value
@@ -867,6 +1360,390 @@ func (r *repositoryFileResource) readFile(ctx context.Context, owner, repoName,
 			}
 		}
 	}
+
+	if model.Verified.IsUnknown() {
+		model.Verified = types.BoolNull()
+	}
+}
+
+// setCommitMessage sets the commit message of opts and appends the configured trailers.
+// Unless `commit_message` is set, the message is rendered from the resource or provider
+// commit message template, or defaults to "Add", "Update" or "Delete" and the file path.
+func (r *repositoryFileResource) setCommitMessage(ctx context.Context, owner, repoName, filePath, action string, model *repositoryFileResourceModel, opts *github.RepositoryContentFileOptions, diags *diag.Diagnostics) bool {
+	message := opts.GetMessage()
+
+	// The message generated on create is kept in state, so it is not reused for later commits
+	generated := message == ""
+	if !generated && action != commitActionCreate {
+		createMessage, err := r.generateCommitMessage(ctx, owner, repoName, filePath, commitActionCreate, model)
+		generated = err == nil && message == createMessage
+	}
+	if generated {
+		var err error
+		message, err = r.generateCommitMessage(ctx, owner, repoName, filePath, action, model)
+		if err != nil {
+			diags.AddError(
+				"Invalid Commit Message Template",
+				err.Error(),
+			)
+			return false
+		}
+		if action != commitActionDelete {
+			model.CommitMessage = types.StringValue(message)
+		}
+	}
+
+	trailers := append([]string{}, r.commitTrailers...)
+	if !model.CommitTrailers.IsNull() && !model.CommitTrailers.IsUnknown() {
+		var resourceTrailers []string
+		diags.Append(model.CommitTrailers.ElementsAs(ctx, &resourceTrailers, false)...)
+		if diags.HasError() {
+			return false
+		}
+		trailers = append(trailers, resourceTrailers...)
+	}
+
+	opts.Message = github.String(appendCommitTrailers(message, trailers))
+	return true
+}
+
+// generateCommitMessage returns the commit message for an action when `commit_message`
+// is not set.
+func (r *repositoryFileResource) generateCommitMessage(ctx context.Context, owner, repoName, filePath, action string, model *repositoryFileResourceModel) (string, error) {
+	text := r.commitMessageTemplate
+	if !model.CommitMessageTemplate.IsNull() && !model.CommitMessageTemplate.IsUnknown() {
+		text = model.CommitMessageTemplate.ValueString()
+	}
+	if text == "" {
+		verbs := map[string]string{
+			commitActionCreate: "Add",
+			commitActionUpdate: "Update",
+			commitActionDelete: "Delete",
+		}
+		return fmt.Sprintf("%s %s", verbs[action], filePath), nil
+	}
+
+	branch := model.Branch.ValueString()
+	if branch == "" {
+		repo, _, err := r.client.Repositories.Get(ctx, owner, repoName)
+		if err != nil {
+			return "", fmt.Errorf("unable to determine the default branch for the commit message: %w", err)
+		}
+		branch = repo.GetDefaultBranch()
+	}
+
+	return renderCommitMessage(text, commitMessageData{
+		Action:     action,
+		Path:       filePath,
+		Repository: repoName,
+		Branch:     branch,
+	})
+}
+
+// commitSignedFile writes or deletes the file in a signed commit through the Git Data API,
+// as commits made through the contents API cannot be signed.
+func (r *repositoryFileResource) commitSignedFile(ctx context.Context, owner, repoName, filePath string, opts *github.RepositoryContentFileOptions, deleteFile bool, checkExisting func(existing map[string]string) error) (string, error) {
+	branch := opts.GetBranch()
+	if branch == "" {
+		repo, _, err := r.client.Repositories.Get(ctx, owner, repoName)
+		if err != nil {
+			return "", fmt.Errorf("unable to determine the default branch: %w", err)
+		}
+		branch = repo.GetDefaultBranch()
+	}
+
+	commit := &github.Commit{
+		Message:   opts.Message,
+		Author:    opts.Author,
+		Committer: opts.Committer,
+	}
+	change := gitTreeChange{Path: filePath, Content: opts.Content, Delete: deleteFile}
+	return commitTreeChanges(ctx, r.client, owner, repoName, branch, []gitTreeChange{change}, commit, r.signer, checkExisting)
+}
+
+// currentFileContent returns the content and blob SHA of the file at ref, or an empty
+// SHA if the file does not exist.
+func (r *repositoryFileResource) currentFileContent(ctx context.Context, owner, repoName, filePath, ref string) ([]byte, string, error) {
+	fc, _, _, err := r.client.Repositories.GetContents(ctx, owner, repoName, filePath, &github.RepositoryContentGetOptions{Ref: ref})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, "", nil
+		}
+		return nil, "", err
+	}
+	if fc == nil {
+		return nil, "", nil
+	}
+
+	content, err := repositoryFileContent(ctx, r.client, owner, repoName, fc)
+	if err != nil {
+		return nil, "", err
+	}
+	return content, fc.GetSHA(), nil
+}
+
+// contentWithBlock returns the current content of the file with its managed block set to
+// block, and the blob SHA of the current file if it exists.
+func (r *repositoryFileResource) contentWithBlock(ctx context.Context, owner, repoName, filePath string, model *repositoryFileResourceModel, block []byte) ([]byte, string, error) {
+	current, sha, err := r.currentFileContent(ctx, owner, repoName, filePath, r.contentRef(ctx, filePath, model))
+	if err != nil {
+		return nil, "", err
+	}
+	return replaceManagedBlock(current, block, model.BlockMarker.ValueString()), sha, nil
+}
+
+// writeFile commits the file content in opts the same way Create and Update do: through
+// a pull request, a signed commit or the contents API.
+func (r *repositoryFileResource) writeFile(ctx context.Context, owner, repoName, filePath string, opts *github.RepositoryContentFileOptions, model *repositoryFileResourceModel, diags *diag.Diagnostics) bool {
+	var err error
+	switch {
+	case !model.PullRequest.IsNull():
+		return r.proposeFileChange(ctx, owner, repoName, filePath, opts, false, model, diags)
+	case r.signer != nil:
+		_, err = r.commitSignedFile(ctx, owner, repoName, filePath, opts, false, nil)
+	default:
+		_, _, err = r.client.Repositories.CreateFile(ctx, owner, repoName, filePath, opts)
+	}
+	if err != nil {
+		addGitHubError(diags,
+			"Error updating file",
+			fmt.Sprintf("Unable to update file %s in repository %s/%s: %v", filePath, owner, repoName, err),
+			err,
+		)
+		return false
+	}
+	return true
+}
+
+// contentRef returns the ref the file is read from: the pull request head branch while a
+// change is pending, otherwise `branch`.
+func (r *repositoryFileResource) contentRef(ctx context.Context, filePath string, model *repositoryFileResourceModel) string {
+	if model.Pending.ValueBool() && !model.PullRequest.IsNull() {
+		var config repositoryFilePullRequestModel
+		if diags := model.PullRequest.As(ctx, &config, basetypes.ObjectAsOptions{}); !diags.HasError() {
+			return pullRequestHeadBranch(&config, filePath)
+		}
+	}
+	if !model.Branch.IsNull() && !model.Branch.IsUnknown() {
+		return model.Branch.ValueString()
+	}
+	return ""
+}
+
+// proposeFileChange commits the change to the pull request head branch and opens or
+// updates the pull request against the target branch, recording it in the model.
+func (r *repositoryFileResource) proposeFileChange(ctx context.Context, owner, repoName, filePath string, opts *github.RepositoryContentFileOptions, deleteFile bool, model *repositoryFileResourceModel, diags *diag.Diagnostics) bool {
+	var config repositoryFilePullRequestModel
+	diags.Append(model.PullRequest.As(ctx, &config, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return false
+	}
+
+	baseBranch := opts.GetBranch()
+	if baseBranch == "" {
+		repo, _, err := r.client.Repositories.Get(ctx, owner, repoName)
+		if err != nil {
+			addGitHubError(diags,
+				"Error reading repository",
+				fmt.Sprintf("Unable to determine the default branch of repository %s/%s: %v", owner, repoName, err),
+				err,
+			)
+			return false
+		}
+		baseBranch = repo.GetDefaultBranch()
+	}
+	headBranch := pullRequestHeadBranch(&config, filePath)
+
+	existing, err := findPullRequest(ctx, r.client, owner, repoName, baseBranch, headBranch, "open")
+	if err != nil {
+		addGitHubError(diags,
+			"Error reading pull requests",
+			fmt.Sprintf("Unable to list pull requests from %s into %s in repository %s/%s: %v", headBranch, baseBranch, owner, repoName, err),
+			err,
+		)
+		return false
+	}
+
+	headSourceSHA := types.StringNull()
+	if !ensureBranch(ctx, r.client, owner, repoName, headBranch, true, baseBranch, &headSourceSHA, diags) {
+		return false
+	}
+	if existing == nil && headSourceSHA.IsNull() {
+		// The head branch is left over from an earlier pull request, so start it again
+		// from the target branch. Generated head branches belong to this resource and are
+		// reset even if they have commits of their own.
+		if err := r.resetHeadBranch(ctx, owner, repoName, baseBranch, headBranch, config.HeadBranch.IsNull()); err != nil {
+			addGitHubError(diags,
+				"Error updating branch",
+				fmt.Sprintf("Unable to update branch %s from %s in repository %s/%s: %v", headBranch, baseBranch, owner, repoName, err),
+				err,
+			)
+			return false
+		}
+	}
+
+	commit := &github.Commit{
+		Message:   opts.Message,
+		Author:    opts.Author,
+		Committer: opts.Committer,
+	}
+	change := gitTreeChange{Path: filePath, Content: opts.Content, Delete: deleteFile}
+	commitSHA, err := commitTreeChanges(ctx, r.client, owner, repoName, headBranch, []gitTreeChange{change}, commit, r.signer, nil)
+	if err != nil {
+		addGitHubError(diags,
+			"Error committing file",
+			fmt.Sprintf("Unable to commit file %s to branch %s of repository %s/%s: %v", filePath, headBranch, owner, repoName, err),
+			err,
+		)
+		return false
+	}
+	model.CommitSHA = types.StringValue(commitSHA)
+
+	title := config.Title.ValueString()
+	if title == "" {
+		title = strings.SplitN(opts.GetMessage(), "\n", 2)[0]
+	}
+	pr, err := openOrUpdatePullRequest(ctx, r.client, owner, repoName, baseBranch, headBranch, title, config.Body.ValueString())
+	if err != nil {
+		addGitHubError(diags,
+			"Error opening pull request",
+			fmt.Sprintf("Unable to open a pull request from %s into %s in repository %s/%s: %v", headBranch, baseBranch, owner, repoName, err),
+			err,
+		)
+		return false
+	}
+	if pr == nil {
+		// The target branch already has the change
+		resetPullRequestStatus(model)
+		return true
+	}
+
+	model.PullRequestNumber = types.Int64Value(int64(pr.GetNumber()))
+	model.PullRequestURL = types.StringValue(pr.GetHTMLURL())
+	model.Pending = types.BoolValue(true)
+
+	if config.AutoMerge.ValueBool() {
+		merged, err := enablePullRequestAutoMerge(ctx, r.client, owner, repoName, pr, config.MergeMethod.ValueString())
+		if err != nil {
+			diags.AddError(
+				"Error enabling auto-merge",
+				err.Error(),
+			)
+			return false
+		}
+		model.Pending = types.BoolValue(!merged)
+	}
+	return true
+}
+
+// resetHeadBranch moves the head branch to the tip of the target branch. Unless force is
+// set, a head branch with commits that are not on the target branch is left alone.
+func (r *repositoryFileResource) resetHeadBranch(ctx context.Context, owner, repoName, baseBranch, headBranch string, force bool) error {
+	if !force {
+		comparison, _, err := r.client.Repositories.CompareCommits(ctx, owner, repoName, baseBranch, headBranch, nil)
+		if err != nil {
+			return err
+		}
+		if comparison.GetAheadBy() > 0 {
+			return nil
+		}
+	}
+
+	base, _, err := r.client.Git.GetRef(ctx, owner, repoName, "refs/heads/"+baseBranch)
+	if err != nil {
+		return err
+	}
+	_, _, err = r.client.Git.UpdateRef(ctx, owner, repoName, &github.Reference{
+		Ref:    github.String("refs/heads/" + headBranch),
+		Object: &github.GitObject{SHA: base.GetObject().SHA},
+	}, true)
+	return err
+}
+
+// readPullRequestStatus refreshes whether the last change is still waiting for its pull
+// request to be merged.
+func (r *repositoryFileResource) readPullRequestStatus(ctx context.Context, owner, repoName string, model *repositoryFileResourceModel, diags *diag.Diagnostics) {
+	if model.PullRequest.IsNull() {
+		resetPullRequestStatus(model)
+		return
+	}
+	if model.PullRequestNumber.IsNull() || model.PullRequestNumber.IsUnknown() {
+		model.Pending = types.BoolValue(false)
+		return
+	}
+
+	number := int(model.PullRequestNumber.ValueInt64())
+	pr, _, err := r.client.PullRequests.Get(ctx, owner, repoName, number)
+	if err != nil {
+		if isNotFound(err) {
+			model.Pending = types.BoolValue(false)
+			return
+		}
+		addGitHubError(diags,
+			"Error reading pull request",
+			fmt.Sprintf("Unable to read pull request #%d in repository %s/%s: %v", number, owner, repoName, err),
+			err,
+		)
+		return
+	}
+
+	// A pull request closed without merging leaves the target branch unchanged, which
+	// shows up as a difference and is proposed again on the next apply
+	model.PullRequestURL = types.StringValue(pr.GetHTMLURL())
+	model.Pending = types.BoolValue(pr.GetState() == "open")
+}
+
+// closePullRequest closes the pull request of a pending change, and deletes the head
+// branch when it was generated by this resource.
+func (r *repositoryFileResource) closePullRequest(ctx context.Context, owner, repoName, filePath string, model *repositoryFileResourceModel, diags *diag.Diagnostics) {
+	var config repositoryFilePullRequestModel
+	diags.Append(model.PullRequest.As(ctx, &config, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return
+	}
+
+	number := int(model.PullRequestNumber.ValueInt64())
+	_, _, err := r.client.PullRequests.Edit(ctx, owner, repoName, number, &github.PullRequest{
+		State: github.String("closed"),
+	})
+	if err != nil && !isNotFound(err) {
+		addGitHubError(diags,
+			"Error closing pull request",
+			fmt.Sprintf("Unable to close pull request #%d in repository %s/%s: %v", number, owner, repoName, err),
+			err,
+		)
+		return
+	}
+
+	if config.HeadBranch.IsNull() {
+		headBranch := pullRequestHeadBranch(&config, filePath)
+		if _, err := r.client.Git.DeleteRef(ctx, owner, repoName, "refs/heads/"+headBranch); err != nil {
+			log.Printf("[WARN] Failed to delete branch %s: %v", headBranch, err)
+		}
+	}
+}
+
+// resetPullRequestStatus records that no change is waiting for a pull request.
+func resetPullRequestStatus(model *repositoryFileResourceModel) {
+	model.PullRequestNumber = types.Int64Null()
+	model.PullRequestURL = types.StringNull()
+	model.Pending = types.BoolValue(false)
+}
+
+// invalidBranchNameCharacters matches the characters replaced in generated branch names.
+var invalidBranchNameCharacters = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// pullRequestHeadBranch returns the configured head branch, or one generated from the
+// file path.
+func pullRequestHeadBranch(config *repositoryFilePullRequestModel, filePath string) string {
+	if config.HeadBranch.ValueString() != "" {
+		return config.HeadBranch.ValueString()
+	}
+
+	name := invalidBranchNameCharacters.ReplaceAllString(filePath, "-")
+	name = strings.ReplaceAll(name, "..", "-")
+	name = strings.TrimLeft(name, ".-")
+	return "githubx/" + name
 }
 
 func (r *repositoryFileResource) getFileCommit(ctx context.Context, owner, repo, file, ref string) (*github.RepositoryCommit, error) {
@@ -895,3 +1772,133 @@ func (r *repositoryFileResource) getFileCommit(ctx context.Context, owner, repo,
 
 	return commit, nil
 }
+
+// renderContent renders `content_template` into `rendered_content`, with the repository
+// metadata read from GitHub. The branch defaults to the repository's default branch.
+func (r *repositoryFileResource) renderContent(ctx context.Context, owner string, model *repositoryFileResourceModel) error {
+	repoName := model.Repository.ValueString()
+	repo, _, err := r.client.Repositories.Get(ctx, owner, repoName)
+	if err != nil {
+		return fmt.Errorf("unable to read repository %s/%s for `content_template`: %w", owner, repoName, err)
+	}
+
+	vars := map[string]string{}
+	if !model.TemplateVars.IsNull() {
+		if diags := model.TemplateVars.ElementsAs(ctx, &vars, false); diags.HasError() {
+			return fmt.Errorf("unable to read `template_vars`")
+		}
+	}
+
+	branch := model.Branch.ValueString()
+	if branch == "" {
+		branch = repo.GetDefaultBranch()
+	}
+
+	content, err := renderContentTemplate(model.ContentTemplate.ValueString(), contentTemplateData{
+		Vars:       vars,
+		Repository: newContentTemplateRepository(repo),
+		Branch:     branch,
+		Path:       model.File.ValueString(),
+	})
+	if err != nil {
+		return err
+	}
+	model.RenderedContent = types.StringValue(content)
+	return nil
+}
+
+// renderPlannedContent renders `content_template` during apply if it could not be
+// rendered during plan.
+func (r *repositoryFileResource) renderPlannedContent(ctx context.Context, owner string, model *repositoryFileResourceModel, diags *diag.Diagnostics) bool {
+	if model.RenderedContent.IsUnknown() && !model.ContentTemplate.IsNull() {
+		if err := r.renderContent(ctx, owner, model); err != nil {
+			diags.AddError(
+				"Invalid Content Template",
+				err.Error(),
+			)
+			return false
+		}
+	}
+	model.RenderedContent = renderedContentOrNull(model)
+	return true
+}
+
+// renderedContentOrNull returns `rendered_content`, or null if `content_template` is not set.
+func renderedContentOrNull(model *repositoryFileResourceModel) types.String {
+	if model.ContentTemplate.IsNull() {
+		return types.StringNull()
+	}
+	return model.RenderedContent
+}
+
+// fileContentFromModel returns the configured file content from `content`, `content_base64`,
+// `source` or the rendered `content_template`, normalized as configured.
+func fileContentFromModel(model *repositoryFileResourceModel) ([]byte, error) {
+	var content []byte
+	switch {
+	case !model.ContentBase64.IsNull():
+		decoded, err := base64.StdEncoding.DecodeString(model.ContentBase64.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode `content_base64`: %v", err)
+		}
+		content = decoded
+	case !model.Source.IsNull():
+		read, err := os.ReadFile(model.Source.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("unable to read `source` file: %v", err)
+		}
+		content = read
+	case !model.ContentTemplate.IsNull():
+		content = []byte(model.RenderedContent.ValueString())
+	default:
+		content = []byte(model.Content.ValueString())
+	}
+	return normalizeFileContent(content, model.NormalizeLineEndings.ValueBool(), model.EnsureTrailingNewline.ValueBool()), nil
+}
+
+// normalizeFileContent converts line endings to LF and adds a missing trailing newline
+// to text content. Binary content is returned unchanged.
+func normalizeFileContent(content []byte, normalizeLineEndings, ensureTrailingNewline bool) []byte {
+	if (!normalizeLineEndings && !ensureTrailingNewline) || !utf8.Valid(content) {
+		return content
+	}
+
+	if normalizeLineEndings {
+		content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+		content = bytes.ReplaceAll(content, []byte("\r"), []byte("\n"))
+	}
+	if ensureTrailingNewline && len(content) > 0 && content[len(content)-1] != '\n' {
+		content = append(append([]byte{}, content...), '\n')
+	}
+	return content
+}
+
+// setFileContent stores content read from GitHub in whichever attribute the model
+// uses. A `source` file is left alone, since the blob SHA already shows the difference.
+func setFileContent(model *repositoryFileResourceModel, content []byte) {
+	switch {
+	case !model.Source.IsNull():
+		return
+	case !model.ContentTemplate.IsNull():
+		model.RenderedContent = types.StringValue(string(content))
+	case !model.ContentBase64.IsNull() || (model.Content.IsNull() && !utf8.Valid(content)):
+		model.ContentBase64 = types.StringValue(base64.StdEncoding.EncodeToString(content))
+	default:
+		model.Content = types.StringValue(string(content))
+	}
+}
+
+// repositoryFileContent returns the raw content of a file. Files larger than 1 MB
+// are returned without content by the contents API, so they are read as a blob.
+func repositoryFileContent(ctx context.Context, client *github.Client, owner, repoName string, fc *github.RepositoryContent) ([]byte, error) {
+	if fc.GetEncoding() == "none" || (fc.Content == nil && fc.GetSize() > 0) {
+		content, _, err := client.Git.GetBlobRaw(ctx, owner, repoName, fc.GetSHA())
+		return content, err
+	}
+
+	content, err := fc.GetContent()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(content), nil
+}
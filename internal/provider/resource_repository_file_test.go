@@ -1,10 +1,18 @@
 package provider
 
 import (
+	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-github/v60/github"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -39,11 +47,31 @@ func TestRepositoryFileResource_Schema(t *testing.T) {
 	assert.True(t, ok)
 	assert.True(t, fileAttr.IsRequired())
 
+	// Check optional attributes
 	contentAttr, ok := resp.Schema.Attributes["content"]
 	assert.True(t, ok)
-	assert.True(t, contentAttr.IsRequired())
+	assert.True(t, contentAttr.IsOptional())
+
+	contentBase64Attr, ok := resp.Schema.Attributes["content_base64"]
+	assert.True(t, ok)
+	assert.True(t, contentBase64Attr.IsOptional())
+
+	sourceAttr, ok := resp.Schema.Attributes["source"]
+	assert.True(t, ok)
+	assert.True(t, sourceAttr.IsOptional())
+
+	contentTemplateAttr, ok := resp.Schema.Attributes["content_template"]
+	assert.True(t, ok)
+	assert.True(t, contentTemplateAttr.IsOptional())
+
+	templateVarsAttr, ok := resp.Schema.Attributes["template_vars"]
+	assert.True(t, ok)
+	assert.True(t, templateVarsAttr.IsOptional())
+
+	renderedContentAttr, ok := resp.Schema.Attributes["rendered_content"]
+	assert.True(t, ok)
+	assert.True(t, renderedContentAttr.IsComputed())
 
-	// Check optional attributes
 	branchAttr, ok := resp.Schema.Attributes["branch"]
 	assert.True(t, ok)
 	assert.True(t, branchAttr.IsOptional())
@@ -81,7 +109,43 @@ func TestRepositoryFileResource_Schema(t *testing.T) {
 	assert.True(t, autocreateBranchSourceSHAAttr.IsOptional())
 	assert.True(t, autocreateBranchSourceSHAAttr.IsComputed())
 
+	for _, name := range []string{"normalize_line_endings", "ensure_trailing_newline"} {
+		attr, ok := resp.Schema.Attributes[name]
+		assert.True(t, ok, name)
+		assert.True(t, attr.IsOptional(), name)
+		assert.True(t, attr.IsComputed(), name)
+	}
+
+	onDestroyAttr, ok := resp.Schema.Attributes["on_destroy"]
+	assert.True(t, ok)
+	assert.True(t, onDestroyAttr.IsOptional())
+	assert.True(t, onDestroyAttr.IsComputed())
+
+	branchAutocreatedAttr, ok := resp.Schema.Attributes["branch_autocreated"]
+	assert.True(t, ok)
+	assert.True(t, branchAutocreatedAttr.IsComputed())
+
+	lifecycleModeAttr, ok := resp.Schema.Attributes["lifecycle_mode"]
+	assert.True(t, ok)
+	assert.True(t, lifecycleModeAttr.IsOptional())
+	assert.True(t, lifecycleModeAttr.IsComputed())
+
+	blockMarkerAttr, ok := resp.Schema.Attributes["block_marker"]
+	assert.True(t, ok)
+	assert.True(t, blockMarkerAttr.IsOptional())
+	assert.True(t, blockMarkerAttr.IsComputed())
+
+	pullRequestAttr, ok := resp.Schema.Attributes["pull_request"]
+	assert.True(t, ok)
+	assert.True(t, pullRequestAttr.IsOptional())
+
 	// Check computed attributes
+	for _, name := range []string{"pull_request_number", "pull_request_url", "pending"} {
+		attr, ok := resp.Schema.Attributes[name]
+		assert.True(t, ok, name)
+		assert.True(t, attr.IsComputed(), name)
+	}
+
 	idAttr, ok := resp.Schema.Attributes["id"]
 	assert.True(t, ok)
 	assert.True(t, idAttr.IsComputed())
@@ -94,6 +158,10 @@ func TestRepositoryFileResource_Schema(t *testing.T) {
 	assert.True(t, ok)
 	assert.True(t, shaAttr.IsComputed())
 
+	verifiedAttr, ok := resp.Schema.Attributes["verified"]
+	assert.True(t, ok)
+	assert.True(t, verifiedAttr.IsComputed())
+
 	commitSHAAttr, ok := resp.Schema.Attributes["commit_sha"]
 	assert.True(t, ok)
 	assert.True(t, commitSHAAttr.IsComputed())
@@ -157,6 +225,275 @@ func TestRepositoryFileResource_Configure(t *testing.T) {
 	}
 }
 
+func TestRepositoryFileResource_FileContent(t *testing.T) {
+	binary := []byte{0x89, 'P', 'N', 'G', 0x00, 0xff}
+	source := filepath.Join(t.TempDir(), "logo.png")
+	assert.NoError(t, os.WriteFile(source, binary, 0o600))
+
+	tests := []struct {
+		name          string
+		model         repositoryFileResourceModel
+		expected      []byte
+		expectError   bool
+		remote        []byte
+		expectContent types.String
+		expectBase64  types.String
+	}{
+		{
+			name:          "content",
+			model:         repositoryFileResourceModel{Content: types.StringValue("hello\n"), ContentBase64: types.StringNull(), Source: types.StringNull()},
+			expected:      []byte("hello\n"),
+			remote:        []byte("changed\n"),
+			expectContent: types.StringValue("changed\n"),
+			expectBase64:  types.StringNull(),
+		},
+		{
+			name:          "content_base64",
+			model:         repositoryFileResourceModel{Content: types.StringNull(), ContentBase64: types.StringValue("iVBORwD/"), Source: types.StringNull()},
+			expected:      binary,
+			remote:        []byte("text"),
+			expectContent: types.StringNull(),
+			expectBase64:  types.StringValue("dGV4dA=="),
+		},
+		{
+			name:        "invalid content_base64",
+			model:       repositoryFileResourceModel{Content: types.StringNull(), ContentBase64: types.StringValue("not base64!"), Source: types.StringNull()},
+			expectError: true,
+		},
+		{
+			name:          "source",
+			model:         repositoryFileResourceModel{Content: types.StringNull(), ContentBase64: types.StringNull(), Source: types.StringValue(source)},
+			expected:      binary,
+			remote:        []byte("changed\n"),
+			expectContent: types.StringNull(),
+			expectBase64:  types.StringNull(),
+		},
+		{
+			name:        "missing source",
+			model:       repositoryFileResourceModel{Content: types.StringNull(), ContentBase64: types.StringNull(), Source: types.StringValue(filepath.Join(t.TempDir(), "missing"))},
+			expectError: true,
+		},
+		{
+			name: "normalized content",
+			model: repositoryFileResourceModel{
+				Content:               types.StringValue("line 1\r\nline 2"),
+				ContentBase64:         types.StringNull(),
+				Source:                types.StringNull(),
+				NormalizeLineEndings:  types.BoolValue(true),
+				EnsureTrailingNewline: types.BoolValue(true),
+			},
+			expected:      []byte("line 1\nline 2\n"),
+			remote:        []byte("changed\n"),
+			expectContent: types.StringValue("changed\n"),
+			expectBase64:  types.StringNull(),
+		},
+		{
+			name:          "imported binary file",
+			model:         repositoryFileResourceModel{Content: types.StringNull(), ContentBase64: types.StringNull(), Source: types.StringNull()},
+			expected:      []byte(""),
+			remote:        binary,
+			expectContent: types.StringNull(),
+			expectBase64:  types.StringValue("iVBORwD/"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := fileContentFromModel(&tt.model)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, content)
+
+			setFileContent(&tt.model, tt.remote)
+			assert.Equal(t, tt.expectContent, tt.model.Content)
+			assert.Equal(t, tt.expectBase64, tt.model.ContentBase64)
+		})
+	}
+}
+
+func TestNormalizeFileContent(t *testing.T) {
+	binary := []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0xff}
+
+	tests := []struct {
+		name                  string
+		content               []byte
+		normalizeLineEndings  bool
+		ensureTrailingNewline bool
+		expected              []byte
+	}{
+		{name: "disabled", content: []byte("a\r\nb"), expected: []byte("a\r\nb")},
+		{name: "line endings", content: []byte("a\r\nb\rc\n"), normalizeLineEndings: true, expected: []byte("a\nb\nc\n")},
+		{name: "trailing newline", content: []byte("a\r\nb"), ensureTrailingNewline: true, expected: []byte("a\r\nb\n")},
+		{name: "trailing newline already present", content: []byte("a\n"), ensureTrailingNewline: true, expected: []byte("a\n")},
+		{name: "empty content", content: []byte{}, ensureTrailingNewline: true, expected: []byte{}},
+		{name: "binary content", content: binary, normalizeLineEndings: true, ensureTrailingNewline: true, expected: binary},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, normalizeFileContent(tt.content, tt.normalizeLineEndings, tt.ensureTrailingNewline))
+		})
+	}
+}
+
+func TestRepositoryFileResource_SetCommitMessage(t *testing.T) {
+	trailers := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("Co-authored-by: John Doe <john@example.com>")})
+
+	tests := []struct {
+		name             string
+		providerTemplate string
+		providerTrailers []string
+		model            repositoryFileResourceModel
+		action           string
+		expected         string
+		expectState      string
+	}{
+		{
+			name:        "default create",
+			model:       repositoryFileResourceModel{CommitMessage: types.StringNull()},
+			action:      commitActionCreate,
+			expected:    "Add README.md",
+			expectState: "Add README.md",
+		},
+		{
+			name:        "create message from state is not reused",
+			model:       repositoryFileResourceModel{CommitMessage: types.StringValue("Add README.md")},
+			action:      commitActionUpdate,
+			expected:    "Update README.md",
+			expectState: "Update README.md",
+		},
+		{
+			name:             "provider template",
+			providerTemplate: "docs({{ .Branch }}): {{ .Action }} {{ .Path }}",
+			model:            repositoryFileResourceModel{CommitMessage: types.StringNull(), Branch: types.StringValue("main")},
+			action:           commitActionDelete,
+			expected:         "docs(main): delete README.md",
+			expectState:      "",
+		},
+		{
+			name:             "resource template overrides provider template",
+			providerTemplate: "docs: {{ .Action }} {{ .Path }}",
+			model:            repositoryFileResourceModel{CommitMessage: types.StringValue("ci: create README.md"), CommitMessageTemplate: types.StringValue("ci: {{ .Action }} {{ .Path }}"), Branch: types.StringValue("main")},
+			action:           commitActionUpdate,
+			expected:         "ci: update README.md",
+			expectState:      "ci: update README.md",
+		},
+		{
+			name:             "commit message with trailers",
+			providerTemplate: "docs: {{ .Action }} {{ .Path }}",
+			providerTrailers: []string{"Signed-off-by: Jane Doe <jane@example.com>"},
+			model:            repositoryFileResourceModel{CommitMessage: types.StringValue("Refresh the readme"), CommitTrailers: trailers, Branch: types.StringValue("main")},
+			action:           commitActionUpdate,
+			expected:         "Refresh the readme\n\nSigned-off-by: Jane Doe <jane@example.com>\nCo-authored-by: John Doe <john@example.com>",
+			expectState:      "Refresh the readme",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repositoryFileResource{commitMessageTemplate: tt.providerTemplate, commitTrailers: tt.providerTrailers}
+			if tt.model.CommitTrailers.IsNull() {
+				tt.model.CommitTrailers = types.ListNull(types.StringType)
+			}
+			if tt.model.CommitMessageTemplate.IsNull() {
+				tt.model.CommitMessageTemplate = types.StringNull()
+			}
+
+			opts, diags := r.buildFileOptions(t.Context(), &tt.model, nil)
+			assert.False(t, diags.HasError())
+			assert.True(t, r.setCommitMessage(t.Context(), "test-owner", "test-repo", "README.md", tt.action, &tt.model, opts, &diags))
+			assert.Equal(t, tt.expected, opts.GetMessage())
+			assert.Equal(t, tt.expectState, tt.model.CommitMessage.ValueString())
+		})
+	}
+}
+
+func TestRepositoryFileResource_Delete_OnDestroy(t *testing.T) {
+	tests := []struct {
+		name              string
+		onDestroy         string
+		branchAutocreated bool
+		expectRequests    []string
+	}{
+		{
+			name:           "retain",
+			onDestroy:      onDestroyRetain,
+			expectRequests: nil,
+		},
+		{
+			name:              "delete autocreated branch",
+			onDestroy:         onDestroyDeleteBranchIfAutocreated,
+			branchAutocreated: true,
+			expectRequests:    []string{"DELETE /repos/test-owner/test-repo/git/refs/heads/feature"},
+		},
+		{
+			name:           "delete file when the branch already existed",
+			onDestroy:      onDestroyDeleteBranchIfAutocreated,
+			expectRequests: []string{"GET /repos/test-owner/test-repo/git/ref/heads/feature", "DELETE /repos/test-owner/test-repo/contents/README.md"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method+" "+r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				switch r.Method {
+				case http.MethodGet:
+					_, _ = w.Write([]byte(`{"ref": "refs/heads/feature", "object": {"sha": "abc"}}`))
+				case http.MethodDelete:
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte(`{}`))
+				}
+			})
+			rs := &repositoryFileResource{client: newTestGitHubClient(t, handler), owner: "test-owner"}
+
+			schemaResp := &resource.SchemaResponse{}
+			rs.Schema(t.Context(), resource.SchemaRequest{}, schemaResp)
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(t.Context()), nil)}
+			diags := state.SetAttribute(t.Context(), path.Root("id"), "test-repo:README.md")
+			diags.Append(state.SetAttribute(t.Context(), path.Root("repository"), "test-repo")...)
+			diags.Append(state.SetAttribute(t.Context(), path.Root("file"), "README.md")...)
+			diags.Append(state.SetAttribute(t.Context(), path.Root("content"), "# test-repo\n")...)
+			diags.Append(state.SetAttribute(t.Context(), path.Root("branch"), "feature")...)
+			diags.Append(state.SetAttribute(t.Context(), path.Root("sha"), gitBlobSHA([]byte("# test-repo\n")))...)
+			diags.Append(state.SetAttribute(t.Context(), path.Root("on_destroy"), tt.onDestroy)...)
+			diags.Append(state.SetAttribute(t.Context(), path.Root("branch_autocreated"), tt.branchAutocreated)...)
+			assert.False(t, diags.HasError())
+
+			resp := &resource.DeleteResponse{State: state}
+			rs.Delete(t.Context(), resource.DeleteRequest{State: state}, resp)
+			assert.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+			assert.Equal(t, tt.expectRequests, requests)
+		})
+	}
+}
+
+func TestPullRequestHeadBranch(t *testing.T) {
+	tests := []struct {
+		name       string
+		headBranch types.String
+		file       string
+		expected   string
+	}{
+		{name: "configured", headBranch: types.StringValue("update-readme"), file: "README.md", expected: "update-readme"},
+		{name: "generated", headBranch: types.StringNull(), file: "README.md", expected: "githubx/README.md"},
+		{name: "nested path", headBranch: types.StringNull(), file: "docs/getting started.md", expected: "githubx/docs-getting-started.md"},
+		{name: "hidden directory", headBranch: types.StringNull(), file: ".github/workflows/ci.yml", expected: "githubx/github-workflows-ci.yml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &repositoryFilePullRequestModel{HeadBranch: tt.headBranch}
+			assert.Equal(t, tt.expected, pullRequestHeadBranch(config, tt.file))
+		})
+	}
+}
+
 // Note: Tests for Create(), Read(), Update(), and Delete() methods that require GitHub API calls
 // should be implemented as acceptance tests with TF_ACC=1 environment variable set.
 // These unit tests verify the schema, metadata, and configuration validation
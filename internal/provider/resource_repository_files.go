@@ -0,0 +1,836 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &repositoryFilesResource{}
+	_ resource.ResourceWithConfigure   = &repositoryFilesResource{}
+	_ resource.ResourceWithImportState = &repositoryFilesResource{}
+)
+
+// NewRepositoryFilesResource is a helper function to simplify the provider implementation.
+func NewRepositoryFilesResource() resource.Resource {
+	return &repositoryFilesResource{}
+}
+
+// repositoryFilesResource is the resource implementation.
+type repositoryFilesResource struct {
+	client *github.Client
+	owner  string
+	signer github.MessageSigner
+}
+
+// repositoryFilesResourceModel maps the resource schema data.
+type repositoryFilesResourceModel struct {
+	Repository        types.String `tfsdk:"repository"`
+	Branch            types.String `tfsdk:"branch"`
+	Files             types.Map    `tfsdk:"files"`
+	CommitMessage     types.String `tfsdk:"commit_message"`
+	CommitAuthor      types.String `tfsdk:"commit_author"`
+	CommitEmail       types.String `tfsdk:"commit_email"`
+	CoAuthors         types.List   `tfsdk:"co_authors"`
+	OverwriteOnCreate types.Bool   `tfsdk:"overwrite_on_create"`
+	CommitSHA         types.String `tfsdk:"commit_sha"`
+	FileSHAs          types.Map    `tfsdk:"file_shas"`
+	ID                types.String `tfsdk:"id"`
+}
+
+// gitTreeChange is a file to write to, or remove from, a tree in a Git Data API commit.
+type gitTreeChange struct {
+	Path    string
+	Content []byte
+	Delete  bool
+}
+
+// coAuthorPattern matches a `Name <email>` co-author.
+var coAuthorPattern = regexp.MustCompile(`^[^<>]+ <[^<>\s]+@[^<>\s]+>$`)
+
+// Metadata returns the resource type name.
+func (r *repositoryFilesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repository_files"
+}
+
+// Schema defines the schema for the resource.
+func (r *repositoryFilesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Creates and manages a set of files in a GitHub repository. All changes are written in a single commit using the Git Data API.",
+		Attributes: map[string]schema.Attribute{
+			"repository": schema.StringAttribute{
+				Description: "The GitHub repository name.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"branch": schema.StringAttribute{
+				Description: "The branch name, defaults to the repository's default branch.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"files": schema.MapAttribute{
+				Description: "A map of file paths to file contents. Files removed from the map are deleted from the branch.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+					mapvalidator.KeysAre(
+						stringvalidator.RegexMatches(
+							regexp.MustCompile(`^[^/].*[^/]$|^[^/]$`),
+							"must be a relative file path without leading or trailing slashes",
+						),
+					),
+				},
+			},
+			"commit_message": schema.StringAttribute{
+				Description: "The commit message when creating, updating or deleting the files. Defaults to a message listing the changed files.",
+				Optional:    true,
+			},
+			"commit_author": schema.StringAttribute{
+				Description: "The commit author name, defaults to the authenticated user's name. GitHub app users may omit author and email information so GitHub can verify commits as the GitHub App.",
+				Optional:    true,
+			},
+			"commit_email": schema.StringAttribute{
+				Description: "The commit author email address, defaults to the authenticated user's email address. GitHub app users may omit author and email information so GitHub can verify commits as the GitHub App.",
+				Optional:    true,
+			},
+			"co_authors": schema.ListAttribute{
+				Description: "Co-authors to credit in the commit, in the form 'Name <email>'. Each one is added as a 'Co-authored-by' trailer.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(coAuthorPattern, "must be in the form 'Name <email>'"),
+					),
+				},
+			},
+			"overwrite_on_create": schema.BoolAttribute{
+				Description: "Enable overwriting existing files, defaults to \"false\".",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"commit_sha": schema.StringAttribute{
+				Description: "The SHA of the last commit that modified the files.",
+				Computed:    true,
+			},
+			"file_shas": schema.MapAttribute{
+				Description: "A map of file paths to the blob SHA of each file, used to detect changes made outside of Terraform.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"id": schema.StringAttribute{
+				Description: "The Terraform state ID (repository:branch).",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure enables provider-level data or clients to be set in the
+// provider-defined resource type.
+func (r *repositoryFilesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(githubxClientData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected githubxClientData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = clientData.Client
+	r.owner = clientData.Owner
+	r.signer = clientData.Signer
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *repositoryFilesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan repositoryFilesResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			"GitHub client is not configured. Please ensure a token is provided.",
+		)
+		return
+	}
+
+	// Get owner, falling back to authenticated user if not set
+	owner, err := r.getOwner(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Missing Owner",
+			fmt.Sprintf("Unable to determine owner: %v. Please set provider-level `owner` configuration or ensure authentication is working.", err),
+		)
+		return
+	}
+
+	repoName := plan.Repository.ValueString()
+
+	if plan.Branch.IsNull() || plan.Branch.IsUnknown() || plan.Branch.ValueString() == "" {
+		repo, _, err := r.client.Repositories.Get(ctx, owner, repoName)
+		if err != nil {
+			addGitHubError(&resp.Diagnostics,
+				"Error reading repository",
+				fmt.Sprintf("Unable to read repository %s/%s to determine the default branch: %v", owner, repoName, err),
+				err,
+			)
+			return
+		}
+		plan.Branch = types.StringValue(repo.GetDefaultBranch())
+	}
+	branch := plan.Branch.ValueString()
+
+	files := make(map[string]string, len(plan.Files.Elements()))
+	resp.Diagnostics.Append(plan.Files.ElementsAs(ctx, &files, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	changes := make([]gitTreeChange, 0, len(files))
+	for filePath, content := range files {
+		changes = append(changes, gitTreeChange{Path: filePath, Content: []byte(content)})
+	}
+
+	commit := r.buildCommit(ctx, &plan, "Add", changes, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var checkExisting func(existing map[string]string) error
+	if !plan.OverwriteOnCreate.ValueBool() {
+		checkExisting = func(existing map[string]string) error {
+			for _, change := range changes {
+				if sha, ok := existing[change.Path]; ok && sha != gitBlobSHA(change.Content) {
+					return fmt.Errorf("file %s already exists in repository %s/%s. Set 'overwrite_on_create' to true to overwrite it", change.Path, owner, repoName)
+				}
+			}
+			return nil
+		}
+	}
+
+	commitSHA, err := commitTreeChanges(ctx, r.client, owner, repoName, branch, changes, commit, r.signer, checkExisting)
+	if err != nil {
+		addGitHubError(&resp.Diagnostics,
+			"Error committing files",
+			fmt.Sprintf("Unable to commit files to repository %s/%s (%s): %v", owner, repoName, branch, err),
+			err,
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(buildTwoPartID(repoName, branch))
+	plan.CommitSHA = types.StringValue(commitSHA)
+
+	r.readFiles(ctx, owner, repoName, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *repositoryFilesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state repositoryFilesResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			"GitHub client is not configured. Please ensure a token is provided.",
+		)
+		return
+	}
+
+	// Get owner, falling back to authenticated user if not set
+	owner, err := r.getOwner(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Missing Owner",
+			fmt.Sprintf("Unable to determine owner: %v. Please set provider-level `owner` configuration or ensure authentication is working.", err),
+		)
+		return
+	}
+
+	r.readFiles(ctx, owner, state.Repository.ValueString(), &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.ID.ValueString() == "" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *repositoryFilesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state repositoryFilesResourceModel
+
+	// Read Terraform plan and state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			"GitHub client is not configured. Please ensure a token is provided.",
+		)
+		return
+	}
+
+	// Get owner, falling back to authenticated user if not set
+	owner, err := r.getOwner(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Missing Owner",
+			fmt.Sprintf("Unable to determine owner: %v. Please set provider-level `owner` configuration or ensure authentication is working.", err),
+		)
+		return
+	}
+
+	repoName := plan.Repository.ValueString()
+	branch := plan.Branch.ValueString()
+
+	planFiles := make(map[string]string, len(plan.Files.Elements()))
+	resp.Diagnostics.Append(plan.Files.ElementsAs(ctx, &planFiles, false)...)
+	stateFiles := make(map[string]string, len(state.Files.Elements()))
+	resp.Diagnostics.Append(state.Files.ElementsAs(ctx, &stateFiles, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var changes []gitTreeChange
+	for filePath, content := range planFiles {
+		if existing, ok := stateFiles[filePath]; !ok || existing != content {
+			changes = append(changes, gitTreeChange{Path: filePath, Content: []byte(content)})
+		}
+	}
+	for filePath := range stateFiles {
+		if _, ok := planFiles[filePath]; !ok {
+			changes = append(changes, gitTreeChange{Path: filePath, Delete: true})
+		}
+	}
+
+	plan.ID = state.ID
+	plan.CommitSHA = state.CommitSHA
+
+	if len(changes) > 0 {
+		commit := r.buildCommit(ctx, &plan, "Update", changes, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		commitSHA, err := commitTreeChanges(ctx, r.client, owner, repoName, branch, changes, commit, r.signer, nil)
+		if err != nil {
+			addGitHubError(&resp.Diagnostics,
+				"Error committing files",
+				fmt.Sprintf("Unable to commit files to repository %s/%s (%s): %v", owner, repoName, branch, err),
+				err,
+			)
+			return
+		}
+		plan.CommitSHA = types.StringValue(commitSHA)
+	}
+
+	r.readFiles(ctx, owner, repoName, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *repositoryFilesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state repositoryFilesResourceModel
+
+	// Read Terraform state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			"GitHub client is not configured. Please ensure a token is provided.",
+		)
+		return
+	}
+
+	// Get owner, falling back to authenticated user if not set
+	owner, err := r.getOwner(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Missing Owner",
+			fmt.Sprintf("Unable to determine owner: %v. Please set provider-level `owner` configuration or ensure authentication is working.", err),
+		)
+		return
+	}
+
+	repoName := state.Repository.ValueString()
+	branch := state.Branch.ValueString()
+
+	stateFiles := make(map[string]string, len(state.Files.Elements()))
+	resp.Diagnostics.Append(state.Files.ElementsAs(ctx, &stateFiles, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	changes := make([]gitTreeChange, 0, len(stateFiles))
+	for filePath := range stateFiles {
+		changes = append(changes, gitTreeChange{Path: filePath, Delete: true})
+	}
+
+	commit := r.buildCommit(ctx, &state, "Delete", changes, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	log.Printf("[DEBUG] Deleting %d files from %s/%s (%s)", len(changes), owner, repoName, branch)
+	_, err = commitTreeChanges(ctx, r.client, owner, repoName, branch, changes, commit, r.signer, nil)
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[INFO] Branch %s/%s (%s) no longer exists, removing files from state", owner, repoName, branch)
+			return
+		}
+		addGitHubError(&resp.Diagnostics,
+			"Error deleting files",
+			fmt.Sprintf("Unable to delete files from repository %s/%s (%s): %v", owner, repoName, branch, err),
+			err,
+		)
+		return
+	}
+}
+
+// ImportState imports the resource into Terraform state.
+func (r *repositoryFilesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Parse the import ID (format: repository:branch:file[,file...])
+	parts := strings.SplitN(req.ID, ":", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Import ID must be in format 'repository:branch:file[,file...]'.",
+		)
+		return
+	}
+
+	repoName := parts[0]
+	branch := parts[1]
+
+	// Contents are read from the branch on the next refresh
+	files := make(map[string]string)
+	for _, filePath := range strings.Split(parts[2], ",") {
+		files[strings.TrimSpace(filePath)] = ""
+	}
+	filesMap, diags := types.MapValueFrom(ctx, types.StringType, files)
+	resp.Diagnostics.Append(diags...)
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), buildTwoPartID(repoName, branch))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repository"), repoName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("branch"), branch)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("files"), filesMap)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("overwrite_on_create"), false)...)
+}
+
+// Helper methods
+
+// getOwner gets the owner, falling back to authenticated user if not set.
+func (r *repositoryFilesResource) getOwner(ctx context.Context) (string, error) {
+	if r.owner != "" {
+		return r.owner, nil
+	}
+	// Try to get authenticated user
+	user, _, err := r.client.Users.Get(ctx, "")
+	if err != nil {
+		return "", fmt.Errorf("unable to determine owner: provider-level `owner` is not set and unable to fetch authenticated user: %v", err)
+	}
+	if user == nil || user.Login == nil {
+		return "", fmt.Errorf("unable to determine owner: provider-level `owner` is not set and authenticated user information is unavailable")
+	}
+	return user.GetLogin(), nil
+}
+
+// buildCommit builds the commit message and author for a set of changes. The
+// message defaults to "<verb> <file>" for a single file and "<verb> <n> files" otherwise.
+func (r *repositoryFilesResource) buildCommit(ctx context.Context, model *repositoryFilesResourceModel, verb string, changes []gitTreeChange, diags *diag.Diagnostics) *github.Commit {
+	message := model.CommitMessage.ValueString()
+	if message == "" {
+		if len(changes) == 1 {
+			message = fmt.Sprintf("%s %s", verb, changes[0].Path)
+		} else {
+			message = fmt.Sprintf("%s %d files", verb, len(changes))
+		}
+	}
+
+	if !model.CoAuthors.IsNull() && !model.CoAuthors.IsUnknown() {
+		var coAuthors []string
+		diags.Append(model.CoAuthors.ElementsAs(ctx, &coAuthors, false)...)
+		if len(coAuthors) > 0 {
+			message = strings.TrimRight(message, "\n") + "\n"
+			for _, coAuthor := range coAuthors {
+				message += "\nCo-authored-by: " + coAuthor
+			}
+		}
+	}
+
+	commit := &github.Commit{Message: github.String(message)}
+
+	author := commitAuthor(model.CommitAuthor, model.CommitEmail, diags)
+	if diags.HasError() {
+		return nil
+	}
+	commit.Author = author
+	commit.Committer = author
+
+	return commit
+}
+
+// readFiles reads the managed files from the branch and populates the model. Files
+// whose blob SHA no longer matches state are re-read so that the drift shows up in the plan.
+func (r *repositoryFilesResource) readFiles(ctx context.Context, owner, repoName string, model *repositoryFilesResourceModel, diags *diag.Diagnostics) {
+	branch := model.Branch.ValueString()
+
+	ref, _, err := r.client.Git.GetRef(ctx, owner, repoName, "refs/heads/"+branch)
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[INFO] Removing repository files %s/%s (%s) from state because the branch no longer exists in GitHub",
+				owner, repoName, branch)
+			model.ID = types.StringValue("")
+			return
+		}
+		addGitHubError(diags,
+			"Error reading branch",
+			fmt.Sprintf("Unable to read branch %s/%s (%s): %v", owner, repoName, branch, err),
+			err,
+		)
+		return
+	}
+	headSHA := ref.GetObject().GetSHA()
+
+	files := make(map[string]string, len(model.Files.Elements()))
+	diags.Append(model.Files.ElementsAs(ctx, &files, false)...)
+	knownSHAs := make(map[string]string)
+	if !model.FileSHAs.IsNull() && !model.FileSHAs.IsUnknown() {
+		diags.Append(model.FileSHAs.ElementsAs(ctx, &knownSHAs, false)...)
+	}
+	if diags.HasError() {
+		return
+	}
+
+	paths := make([]string, 0, len(files))
+	for filePath := range files {
+		paths = append(paths, filePath)
+	}
+
+	remoteSHAs, err := existingBlobSHAs(ctx, r.client, owner, repoName, headSHA, paths)
+	if err != nil {
+		addGitHubError(diags,
+			"Error reading files",
+			fmt.Sprintf("Unable to read files from repository %s/%s (%s): %v", owner, repoName, branch, err),
+			err,
+		)
+		return
+	}
+
+	fileSHAs := make(map[string]string, len(remoteSHAs))
+	for _, filePath := range paths {
+		sha, ok := remoteSHAs[filePath]
+		if !ok {
+			log.Printf("[INFO] File %s no longer exists in %s/%s (%s)", filePath, owner, repoName, branch)
+			delete(files, filePath)
+			continue
+		}
+		fileSHAs[filePath] = sha
+
+		if known, ok := knownSHAs[filePath]; ok && known == sha {
+			continue
+		}
+		if gitBlobSHA([]byte(files[filePath])) == sha {
+			continue
+		}
+
+		content, _, err := r.client.Git.GetBlobRaw(ctx, owner, repoName, sha)
+		if err != nil {
+			addGitHubError(diags,
+				"Error reading file content",
+				fmt.Sprintf("Unable to read file %s from repository %s/%s: %v", filePath, owner, repoName, err),
+				err,
+			)
+			return
+		}
+		log.Printf("[INFO] File %s in %s/%s (%s) was changed outside of Terraform", filePath, owner, repoName, branch)
+		files[filePath] = string(content)
+	}
+
+	filesMap, mapDiags := types.MapValueFrom(ctx, types.StringType, files)
+	diags.Append(mapDiags...)
+	shasMap, mapDiags := types.MapValueFrom(ctx, types.StringType, fileSHAs)
+	diags.Append(mapDiags...)
+
+	model.Files = filesMap
+	model.FileSHAs = shasMap
+	model.Repository = types.StringValue(repoName)
+	model.ID = types.StringValue(buildTwoPartID(repoName, branch))
+	if model.CommitSHA.IsNull() || model.CommitSHA.IsUnknown() {
+		model.CommitSHA = types.StringValue(headSHA)
+	}
+}
+
+// commitAuthor returns the author and committer of a commit from the `commit_author` and
+// `commit_email` attributes, which must be set together. It returns nil if neither is set.
+func commitAuthor(name, email types.String, diags *diag.Diagnostics) *github.CommitAuthor {
+	hasName := !name.IsNull() && !name.IsUnknown()
+	hasEmail := !email.IsNull() && !email.IsUnknown()
+
+	switch {
+	case hasName && !hasEmail:
+		diags.AddError(
+			"Invalid Commit Author Configuration",
+			"Cannot set commit_author without setting commit_email",
+		)
+		return nil
+	case hasEmail && !hasName:
+		diags.AddError(
+			"Invalid Commit Author Configuration",
+			"Cannot set commit_email without setting commit_author",
+		)
+		return nil
+	case !hasName:
+		return nil
+	}
+	return &github.CommitAuthor{Name: github.String(name.ValueString()), Email: github.String(email.ValueString())}
+}
+
+// commitTreeChanges writes changes to a branch as a single commit using the Git Data API
+// and returns the new commit SHA. Blobs are created once, then the tree, commit and ref
+// update are retried if the branch moves in the meantime. checkExisting, when set, is
+// called with the blob SHAs of the changed paths that already exist on the branch. The
+// commit is signed with signer when it is set.
+func commitTreeChanges(ctx context.Context, client *github.Client, owner, repoName, branch string, changes []gitTreeChange, commit *github.Commit, signer github.MessageSigner, checkExisting func(existing map[string]string) error) (string, error) {
+	if signer != nil {
+		if err := prepareSignedCommit(ctx, client, commit); err != nil {
+			return "", err
+		}
+	}
+
+	paths := make([]string, 0, len(changes))
+	blobSHAs := make(map[string]string, len(changes))
+	for _, change := range changes {
+		paths = append(paths, change.Path)
+		if change.Delete {
+			continue
+		}
+		blob, _, err := client.Git.CreateBlob(ctx, owner, repoName, &github.Blob{
+			Content:  github.String(base64.StdEncoding.EncodeToString(change.Content)),
+			Encoding: github.String("base64"),
+		})
+		if err != nil {
+			return "", fmt.Errorf("unable to create blob for %s: %w", change.Path, err)
+		}
+		blobSHAs[change.Path] = blob.GetSHA()
+	}
+	sort.Strings(paths)
+
+	branchRefName := "refs/heads/" + branch
+	maxRetries := 5
+	var err error
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		var ref *github.Reference
+		ref, _, err = client.Git.GetRef(ctx, owner, repoName, branchRefName)
+		if err != nil {
+			return "", err
+		}
+		parentSHA := ref.GetObject().GetSHA()
+
+		var parent *github.Commit
+		parent, _, err = client.Git.GetCommit(ctx, owner, repoName, parentSHA)
+		if err != nil {
+			return "", err
+		}
+		baseTreeSHA := parent.GetTree().GetSHA()
+
+		var existing map[string]string
+		existing, err = existingBlobSHAs(ctx, client, owner, repoName, parentSHA, paths)
+		if err != nil {
+			return "", err
+		}
+		if checkExisting != nil {
+			if err = checkExisting(existing); err != nil {
+				return "", err
+			}
+		}
+
+		entries := make([]*github.TreeEntry, 0, len(paths))
+		for _, filePath := range paths {
+			sha, isWrite := blobSHAs[filePath]
+			if !isWrite {
+				// Removing a path that is not in the tree is rejected by the API
+				if _, ok := existing[filePath]; !ok {
+					continue
+				}
+				entries = append(entries, &github.TreeEntry{
+					Path: github.String(filePath),
+					Mode: github.String("100644"),
+					Type: github.String("blob"),
+				})
+				continue
+			}
+			entries = append(entries, &github.TreeEntry{
+				Path: github.String(filePath),
+				Mode: github.String("100644"),
+				Type: github.String("blob"),
+				SHA:  github.String(sha),
+			})
+		}
+		if len(entries) == 0 {
+			log.Printf("[DEBUG] No changes to commit to %s/%s (%s)", owner, repoName, branch)
+			return parentSHA, nil
+		}
+
+		var tree *github.Tree
+		tree, _, err = client.Git.CreateTree(ctx, owner, repoName, baseTreeSHA, entries)
+		if err != nil {
+			return "", err
+		}
+		if tree.GetSHA() == baseTreeSHA {
+			log.Printf("[DEBUG] Files in %s/%s (%s) are already up to date", owner, repoName, branch)
+			return parentSHA, nil
+		}
+
+		newCommit := &github.Commit{
+			Message:   commit.Message,
+			Author:    commit.Author,
+			Committer: commit.Committer,
+			Tree:      &github.Tree{SHA: tree.SHA},
+			Parents:   []*github.Commit{{SHA: github.String(parentSHA)}},
+		}
+		var created *github.Commit
+		created, _, err = client.Git.CreateCommit(ctx, owner, repoName, newCommit, &github.CreateCommitOptions{Signer: signer})
+		if err != nil {
+			return "", err
+		}
+
+		_, _, err = client.Git.UpdateRef(ctx, owner, repoName, &github.Reference{
+			Ref:    github.String(branchRefName),
+			Object: &github.GitObject{SHA: created.SHA},
+		}, false)
+		if err == nil {
+			log.Printf("[DEBUG] Committed %d files to %s/%s (%s): %s", len(entries), owner, repoName, branch, created.GetSHA())
+			return created.GetSHA(), nil
+		}
+
+		// The branch moved since it was read, so rebuild the commit on the new head
+		if isValidationFailed(err) || isConflict(err) {
+			log.Printf("[DEBUG] Branch %s/%s (%s) was updated concurrently, retrying commit (attempt %d/%d)", owner, repoName, branch, attempt+1, maxRetries)
+			continue
+		}
+		return "", err
+	}
+	return "", fmt.Errorf("branch was updated concurrently %d times: %w", maxRetries, err)
+}
+
+// existingBlobSHAs returns the blob SHAs of the given paths that exist at commitSHA.
+func existingBlobSHAs(ctx context.Context, client *github.Client, owner, repoName, commitSHA string, paths []string) (map[string]string, error) {
+	shas := make(map[string]string, len(paths))
+
+	tree, _, err := client.Git.GetTree(ctx, owner, repoName, commitSHA, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if !tree.GetTruncated() {
+		wanted := make(map[string]bool, len(paths))
+		for _, filePath := range paths {
+			wanted[filePath] = true
+		}
+		for _, entry := range tree.Entries {
+			if entry.GetType() == "blob" && wanted[entry.GetPath()] {
+				shas[entry.GetPath()] = entry.GetSHA()
+			}
+		}
+		return shas, nil
+	}
+
+	// The recursive tree is too large to be returned in full, so look up each path
+	log.Printf("[DEBUG] Tree for %s/%s at %s is truncated, reading files individually", owner, repoName, commitSHA)
+	for _, filePath := range paths {
+		fc, _, _, err := client.Repositories.GetContents(ctx, owner, repoName, filePath, &github.RepositoryContentGetOptions{Ref: commitSHA})
+		if err != nil {
+			if isNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if fc != nil {
+			shas[filePath] = fc.GetSHA()
+		}
+	}
+	return shas, nil
+}
+
+// gitBlobSHA returns the Git object ID of a blob with the given content.
+func gitBlobSHA(content []byte) string {
+	h := sha1.New()
+	h.Write([]byte(fmt.Sprintf("blob %d\x00", len(content))))
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -2,13 +2,9 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
-	"net/http"
-	"strconv"
 	"strings"
-	"time"
 
 	"github.com/google/go-github/v60/github"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -16,9 +12,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -27,6 +20,7 @@ var (
 	_ resource.Resource                = &repositoryPullRequestResource{}
 	_ resource.ResourceWithConfigure   = &repositoryPullRequestResource{}
 	_ resource.ResourceWithImportState = &repositoryPullRequestResource{}
+	_ resource.ResourceWithModifyPlan  = &repositoryPullRequestResource{}
 )
 
 // NewRepositoryPullRequestResource is a helper function to simplify the provider implementation.
@@ -42,128 +36,64 @@ type repositoryPullRequestResource struct {
 
 // repositoryPullRequestResourceModel maps the resource schema data.
 type repositoryPullRequestResourceModel struct {
-	Repository          types.String `tfsdk:"repository"`
-	BaseRef             types.String `tfsdk:"base_ref"`
-	HeadRef             types.String `tfsdk:"head_ref"`
-	Title               types.String `tfsdk:"title"`
-	Body                types.String `tfsdk:"body"`
-	MergeWhenReady      types.Bool   `tfsdk:"merge_when_ready"`
-	MergeMethod         types.String `tfsdk:"merge_method"`
-	WaitForChecks       types.Bool   `tfsdk:"wait_for_checks"`
-	AutoDeleteBranch    types.Bool   `tfsdk:"auto_delete_branch"`
-	MaintainerCanModify types.Bool   `tfsdk:"maintainer_can_modify"`
-	BaseSHA             types.String `tfsdk:"base_sha"`
-	HeadSHA             types.String `tfsdk:"head_sha"`
-	Number              types.Int64  `tfsdk:"number"`
-	State               types.String `tfsdk:"state"`
-	Merged              types.Bool   `tfsdk:"merged"`
-	MergedAt            types.String `tfsdk:"merged_at"`
-	MergeCommitSHA      types.String `tfsdk:"merge_commit_sha"`
-	ID                  types.String `tfsdk:"id"`
+	pullRequestModel
+	Draft          types.Bool  `tfsdk:"draft"`
+	Reviewers      types.Set   `tfsdk:"reviewers"`
+	TeamReviewers  types.Set   `tfsdk:"team_reviewers"`
+	Assignees      types.Set   `tfsdk:"assignees"`
+	Labels         types.Set   `tfsdk:"labels"`
+	Milestone      types.Int64 `tfsdk:"milestone"`
+	CloseOnDestroy types.Bool  `tfsdk:"close_on_destroy"`
 }
 
 // Metadata returns the resource type name.
 func (r *repositoryPullRequestResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
-	resp.TypeName = req.ProviderTypeName + "_repository_pull_request_auto_merge"
+	resp.TypeName = req.ProviderTypeName + "_repository_pull_request"
 }
 
 // Schema defines the schema for the resource.
 func (r *repositoryPullRequestResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := pullRequestSchemaAttributes()
+	attributes["draft"] = schema.BoolAttribute{
+		Description: "Whether the pull request is a draft. Changing it converts the pull request to a draft or marks it ready for review. Defaults to \"false\".",
+		Optional:    true,
+		Computed:    true,
+		Default:     booldefault.StaticBool(false),
+	}
+	attributes["reviewers"] = schema.SetAttribute{
+		Description: "The logins of users to request reviews from. Reviewers stay in the set once they have reviewed the pull request. If not set, review requests are not managed.",
+		Optional:    true,
+		ElementType: types.StringType,
+	}
+	attributes["team_reviewers"] = schema.SetAttribute{
+		Description: "The slugs of teams to request reviews from. Teams stay in the set once the pull request has been reviewed. If not set, team review requests are not managed.",
+		Optional:    true,
+		ElementType: types.StringType,
+	}
+	attributes["assignees"] = schema.SetAttribute{
+		Description: "The logins of users to assign to the pull request. Other assignees are removed. If not set, assignees are not managed.",
+		Optional:    true,
+		ElementType: types.StringType,
+	}
+	attributes["labels"] = schema.SetAttribute{
+		Description: "The names of the labels of the pull request. Other labels are removed. If not set, labels are not managed.",
+		Optional:    true,
+		ElementType: types.StringType,
+	}
+	attributes["milestone"] = schema.Int64Attribute{
+		Description: "The number of the milestone of the pull request. If not set, the milestone is not managed, unless it was set before, in which case it is removed.",
+		Optional:    true,
+	}
+	attributes["close_on_destroy"] = schema.BoolAttribute{
+		Description: "Close the pull request when the resource is destroyed. If false, the pull request is left open. Defaults to \"true\".",
+		Optional:    true,
+		Computed:    true,
+		Default:     booldefault.StaticBool(true),
+	}
+
 	resp.Schema = schema.Schema{
-		Description: "Creates and manages a GitHub pull request with optional auto-merge capabilities. Supports multiple files through branch-based commits.",
-		Attributes: map[string]schema.Attribute{
-			"repository": schema.StringAttribute{
-				Description: "The GitHub repository name.",
-				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
-			},
-			"base_ref": schema.StringAttribute{
-				Description: "The base branch name (e.g., 'main', 'develop').",
-				Required:    true,
-			},
-			"head_ref": schema.StringAttribute{
-				Description: "The head branch name (e.g., 'feature-branch').",
-				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
-			},
-			"title": schema.StringAttribute{
-				Description: "The title of the pull request.",
-				Required:    true,
-			},
-			"body": schema.StringAttribute{
-				Description: "The body/description of the pull request.",
-				Optional:    true,
-			},
-			"merge_when_ready": schema.BoolAttribute{
-				Description: "Wait for all checks and approvals to pass, then automatically merge.",
-				Optional:    true,
-				Computed:    true,
-				Default:     booldefault.StaticBool(false),
-			},
-			"merge_method": schema.StringAttribute{
-				Description: "The merge method to use when auto-merging. Options: 'merge', 'squash', 'rebase'. Defaults to 'merge'.",
-				Optional:    true,
-				Computed:    true,
-				Default:     stringdefault.StaticString("merge"),
-			},
-			"wait_for_checks": schema.BoolAttribute{
-				Description: "Wait for CI checks to pass before merging. Only applies when 'merge_when_ready' is true.",
-				Optional:    true,
-				Computed:    true,
-				Default:     booldefault.StaticBool(true),
-			},
-			"auto_delete_branch": schema.BoolAttribute{
-				Description: "Automatically delete the head branch after merge.",
-				Optional:    true,
-				Computed:    true,
-				Default:     booldefault.StaticBool(false),
-			},
-			"maintainer_can_modify": schema.BoolAttribute{
-				Description: "Allow maintainers to modify the pull request.",
-				Optional:    true,
-				Computed:    true,
-				Default:     booldefault.StaticBool(false),
-			},
-			"base_sha": schema.StringAttribute{
-				Description: "The SHA of the base branch.",
-				Computed:    true,
-			},
-			"head_sha": schema.StringAttribute{
-				Description: "The SHA of the head branch.",
-				Computed:    true,
-			},
-			"number": schema.Int64Attribute{
-				Description: "The pull request number.",
-				Computed:    true,
-			},
-			"state": schema.StringAttribute{
-				Description: "The state of the pull request (open, closed, merged).",
-				Computed:    true,
-			},
-			"merged": schema.BoolAttribute{
-				Description: "Whether the pull request has been merged.",
-				Computed:    true,
-			},
-			"merged_at": schema.StringAttribute{
-				Description: "The timestamp when the pull request was merged.",
-				Computed:    true,
-			},
-			"merge_commit_sha": schema.StringAttribute{
-				Description: "The SHA of the merge commit.",
-				Computed:    true,
-			},
-			"id": schema.StringAttribute{
-				Description: "The Terraform state ID (repository:number).",
-				Computed:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
-				},
-			},
-		},
+		Description: "Creates and manages a GitHub pull request, including its reviewers, assignees, labels, milestone and draft state. Pull requests are not merged; use `githubx_repository_pull_request_auto_merge` to merge them.",
+		Attributes:  attributes,
 	}
 }
 
@@ -187,6 +117,23 @@ func (r *repositoryPullRequestResource) Configure(ctx context.Context, req resou
 	r.owner = clientData.Owner
 }
 
+// ModifyPlan marks the computed attributes that an update changes as unknown.
+func (r *repositoryPullRequestResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state repositoryPullRequestResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planPullRequestUpdate(&plan.pullRequestModel, &state.pullRequestModel, false)
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *repositoryPullRequestResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan repositoryPullRequestResourceModel
@@ -214,116 +161,36 @@ func (r *repositoryPullRequestResource) Create(ctx context.Context, req resource
 	}
 
 	repoName := plan.Repository.ValueString()
-	baseRef := plan.BaseRef.ValueString()
-	headRef := plan.HeadRef.ValueString()
-	title := plan.Title.ValueString()
 
-	if baseRef == headRef {
-		resp.Diagnostics.AddError(
-			"Invalid Configuration",
-			fmt.Sprintf("Base branch '%s' and head branch '%s' cannot be the same. There must be a difference to create a pull request.", baseRef, headRef),
-		)
-		return
+	newPR := &github.NewPullRequest{
+		Title:               github.String(plan.Title.ValueString()),
+		Head:                github.String(plan.HeadRef.ValueString()),
+		Base:                github.String(plan.BaseRef.ValueString()),
+		MaintainerCanModify: github.Bool(plan.MaintainerCanModify.ValueBool()),
+		Draft:               github.Bool(plan.Draft.ValueBool()),
 	}
 
-	existingPR, err := r.findExistingPR(ctx, owner, repoName, baseRef, headRef)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error checking for existing pull request",
-			fmt.Sprintf("Unable to check for existing pull request in repository %s/%s: %v", owner, repoName, err),
-		)
-		return
+	if !plan.Body.IsNull() && !plan.Body.IsUnknown() {
+		newPR.Body = github.String(plan.Body.ValueString())
 	}
 
-	var pr *github.PullRequest
-	if existingPR != nil {
-		if existingPR.GetState() == "closed" && !existingPR.GetMerged() {
-			// Reopen the closed PR
-			log.Printf("[INFO] Reopening closed pull request #%d from '%s' to '%s' in repository %s/%s", existingPR.GetNumber(), headRef, baseRef, owner, repoName)
-			update := &github.PullRequest{
-				State: github.String("open"),
-			}
-			reopenedPR, _, err := r.client.PullRequests.Edit(ctx, owner, repoName, existingPR.GetNumber(), update)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Error reopening pull request",
-					fmt.Sprintf("Unable to reopen pull request #%d in repository %s/%s: %v", existingPR.GetNumber(), owner, repoName, err),
-				)
-				return
-			}
-			pr = reopenedPR
-		} else {
-			// Adopt the existing PR - this handles the case where PR was created outside Terraform
-			// or if terraform apply is run again after the PR was already created
-			log.Printf("[INFO] Adopting existing pull request #%d from '%s' to '%s' in repository %s/%s", existingPR.GetNumber(), headRef, baseRef, owner, repoName)
-			pr = existingPR
-		}
-		plan.ID = types.StringValue(fmt.Sprintf("%s:%d", repoName, pr.GetNumber()))
-		plan.Number = types.Int64Value(int64(pr.GetNumber()))
-	} else {
-		// No existing PR found, create a new one
-		baseSHA, headSHA, err := r.getBranchSHAs(ctx, owner, repoName, baseRef, headRef)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error checking branch differences",
-				fmt.Sprintf("Unable to get branch information: %v", err),
-			)
-			return
-		}
-
-		if baseSHA == headSHA {
-			resp.Diagnostics.AddError(
-				"No Differences",
-				fmt.Sprintf("Branches '%s' and '%s' are at the same commit (SHA: %s). There are no changes to create a pull request.", headRef, baseRef, headSHA),
-			)
-			return
-		}
-
-		newPR := &github.NewPullRequest{
-			Title:               github.String(title),
-			Head:                github.String(headRef),
-			Base:                github.String(baseRef),
-			MaintainerCanModify: github.Bool(plan.MaintainerCanModify.ValueBool()),
-		}
-
-		if !plan.Body.IsNull() && !plan.Body.IsUnknown() {
-			newPR.Body = github.String(plan.Body.ValueString())
-		}
-
-		pr, _, err = r.client.PullRequests.Create(ctx, owner, repoName, newPR)
-		if err != nil {
-			var ghErr *github.ErrorResponse
-			if errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusUnprocessableEntity {
-				if strings.Contains(err.Error(), "already exists") || strings.Contains(err.Error(), "No commits between") {
-					resp.Diagnostics.AddError(
-						"Pull Request Already Exists or No Changes",
-						fmt.Sprintf("Unable to create pull request: %v. A pull request may already exist, or there are no commits between '%s' and '%s'.", err, headRef, baseRef),
-					)
-					return
-				}
-			}
-			resp.Diagnostics.AddError(
-				"Error creating pull request",
-				fmt.Sprintf("Unable to create pull request in repository %s/%s: %v", owner, repoName, err),
-			)
-			return
-		}
-
-		plan.ID = types.StringValue(fmt.Sprintf("%s:%d", repoName, pr.GetNumber()))
-		plan.Number = types.Int64Value(int64(pr.GetNumber()))
+	pr := openPullRequest(ctx, r.client, owner, repoName, newPR, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	plan.ID = types.StringValue(fmt.Sprintf("%s:%d", repoName, pr.GetNumber()))
+	plan.Number = types.Int64Value(int64(pr.GetNumber()))
 
-	// Only attempt auto-merge if PR is open and not already merged
-	if pr.GetState() == "open" && !pr.GetMerged() {
-		if plan.MergeWhenReady.ValueBool() {
-			if err := r.handleAutoMerge(ctx, owner, repoName, pr.GetNumber(), &plan, &resp.Diagnostics); err != nil {
-				resp.Diagnostics.AddError(
-					"Error setting up auto-merge",
-					fmt.Sprintf("Unable to set up auto-merge for pull request #%d: %v", pr.GetNumber(), err),
-				)
-				return
-			}
-		}
+	// An adopted pull request may already have reviewers, labels and so on, so compare
+	// the plan with what it has now rather than with nothing
+	current := plan
+	r.readPullRequestDetails(ctx, owner, repoName, pr, &current, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.syncPullRequestDetails(ctx, owner, repoName, pr, &plan, &current, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	r.readPullRequest(ctx, owner, repoName, pr.GetNumber(), &plan, &resp.Diagnostics)
@@ -361,34 +228,29 @@ func (r *repositoryPullRequestResource) Read(ctx context.Context, req resource.R
 	}
 
 	id := state.ID.ValueString()
-	parts := strings.SplitN(id, ":", 2)
-	if len(parts) != 2 {
+	repoName, number, err := parsePullRequestID(id)
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Invalid ID",
-			fmt.Sprintf("Invalid ID format: %s. Expected 'repository:number'.", id),
+			fmt.Sprintf("Invalid ID format: %s. Expected 'repository:number'. Error: %v", id, err),
 		)
 		return
 	}
 
-	repoName := parts[0]
-	numberStr := parts[1]
-	number, err := strconv.Atoi(numberStr)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Invalid PR Number",
-			fmt.Sprintf("Invalid PR number in ID: %s", numberStr),
-		)
+	r.readPullRequest(ctx, owner, repoName, number, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	r.readPullRequest(ctx, owner, repoName, number, &state, &resp.Diagnostics)
-	if resp.Diagnostics.HasError() {
+	if state.ID.ValueString() == "" {
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
 	if state.State.ValueString() == "closed" && !state.Merged.ValueBool() {
 		log.Printf("[INFO] Pull request #%d is closed but not merged, removing from state", number)
-		state.ID = types.StringValue("")
+		resp.State.RemoveResource(ctx)
+		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -422,58 +284,32 @@ func (r *repositoryPullRequestResource) Update(ctx context.Context, req resource
 	}
 
 	id := state.ID.ValueString()
-	parts := strings.SplitN(id, ":", 2)
-	if len(parts) != 2 {
+	repoName, number, err := parsePullRequestID(id)
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Invalid ID",
-			fmt.Sprintf("Invalid ID format: %s. Expected 'repository:number'.", id),
+			fmt.Sprintf("Invalid ID format: %s. Expected 'repository:number'. Error: %v", id, err),
 		)
 		return
 	}
 
-	repoName := parts[0]
-	numberStr := parts[1]
-	number, err := strconv.Atoi(numberStr)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Invalid PR Number",
-			fmt.Sprintf("Invalid PR number in ID: %s", numberStr),
-		)
+	editPullRequest(ctx, r.client, owner, repoName, number, &plan.pullRequestModel, &state.pullRequestModel, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	update := &github.PullRequest{
-		Title:               github.String(plan.Title.ValueString()),
-		MaintainerCanModify: github.Bool(plan.MaintainerCanModify.ValueBool()),
-	}
-
-	if !plan.Body.IsNull() && !plan.Body.IsUnknown() {
-		update.Body = github.String(plan.Body.ValueString())
-	}
-
-	if !plan.BaseRef.Equal(state.BaseRef) {
-		update.Base = &github.PullRequestBranch{
-			Ref: github.String(plan.BaseRef.ValueString()),
-		}
-	}
-
-	_, _, err = r.client.PullRequests.Edit(ctx, owner, repoName, number, update)
+	pr, _, err := r.client.PullRequests.Get(ctx, owner, repoName, number)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating pull request",
-			fmt.Sprintf("Unable to update pull request #%d in repository %s/%s: %v", number, owner, repoName, err),
+		addGitHubError(&resp.Diagnostics,
+			"Error reading pull request",
+			fmt.Sprintf("Unable to read pull request #%d from repository %s/%s: %v", number, owner, repoName, err),
+			err,
 		)
 		return
 	}
-
-	if !plan.MergeWhenReady.Equal(state.MergeWhenReady) && plan.MergeWhenReady.ValueBool() {
-		if err := r.handleAutoMerge(ctx, owner, repoName, number, &plan, &resp.Diagnostics); err != nil {
-			resp.Diagnostics.AddError(
-				"Error setting up auto-merge",
-				fmt.Sprintf("Unable to set up auto-merge for pull request #%d: %v", number, err),
-			)
-			return
-		}
+	r.syncPullRequestDetails(ctx, owner, repoName, pr, &plan, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	r.readPullRequest(ctx, owner, repoName, number, &plan, &resp.Diagnostics)
@@ -493,6 +329,11 @@ func (r *repositoryPullRequestResource) Delete(ctx context.Context, req resource
 		return
 	}
 
+	if !state.CloseOnDestroy.ValueBool() {
+		log.Printf("[INFO] Leaving pull request %s open, as close_on_destroy is not set", state.ID.ValueString())
+		return
+	}
+
 	if r.client == nil {
 		resp.Diagnostics.AddError(
 			"Client Error",
@@ -511,67 +352,22 @@ func (r *repositoryPullRequestResource) Delete(ctx context.Context, req resource
 	}
 
 	id := state.ID.ValueString()
-	parts := strings.SplitN(id, ":", 2)
-	if len(parts) != 2 {
-		resp.Diagnostics.AddError(
-			"Invalid ID",
-			fmt.Sprintf("Invalid ID format: %s. Expected 'repository:number'.", id),
-		)
-		return
-	}
-
-	repoName := parts[0]
-	numberStr := parts[1]
-	number, err := strconv.Atoi(numberStr)
+	repoName, number, err := parsePullRequestID(id)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Invalid PR Number",
-			fmt.Sprintf("Invalid PR number in ID: %s", numberStr),
-		)
-		return
-	}
-
-	pr, _, err := r.client.PullRequests.Get(ctx, owner, repoName, number)
-	if err != nil {
-		var ghErr *github.ErrorResponse
-		if errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusNotFound {
-			log.Printf("[INFO] Pull request #%d not found, assuming already deleted", number)
-			return
-		}
-		resp.Diagnostics.AddError(
-			"Error reading pull request",
-			fmt.Sprintf("Unable to read pull request #%d from repository %s/%s: %v", number, owner, repoName, err),
+			"Invalid ID",
+			fmt.Sprintf("Invalid ID format: %s. Expected 'repository:number'. Error: %v", id, err),
 		)
 		return
 	}
 
-	// If PR is already merged, don't close it - just cleanup
-	if pr.GetMerged() {
-		log.Printf("[INFO] Pull request #%d is already merged, skipping close operation", number)
-		return
-	}
-
-	// Close the PR if it's still open
-	if pr.GetState() == "open" {
-		update := &github.PullRequest{State: github.String("closed")}
-		_, _, err = r.client.PullRequests.Edit(ctx, owner, repoName, number, update)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error closing pull request",
-				fmt.Sprintf("Unable to close pull request #%d in repository %s/%s: %v", number, owner, repoName, err),
-			)
-			return
-		}
-		log.Printf("[INFO] Closed pull request #%d", number)
-	} else {
-		log.Printf("[INFO] Pull request #%d is already closed", number)
-	}
+	closePullRequest(ctx, r.client, owner, repoName, number, &resp.Diagnostics)
 }
 
 // ImportState imports the resource.
 func (r *repositoryPullRequestResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	parts := strings.SplitN(req.ID, ":", 2)
-	if len(parts) != 2 {
+	repoName, number, err := parsePullRequestID(req.ID)
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Invalid Import ID",
 			"Import ID must be in format 'repository:number'.",
@@ -579,37 +375,9 @@ func (r *repositoryPullRequestResource) ImportState(ctx context.Context, req res
 		return
 	}
 
-	repoName := parts[0]
-	numberStr := parts[1]
-	number, err := strconv.Atoi(numberStr)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Invalid PR Number",
-			fmt.Sprintf("Invalid PR number: %s", numberStr),
-		)
-		return
-	}
-
-	owner, err := r.getOwner(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Missing Owner",
-			fmt.Sprintf("Unable to determine owner: %v", err),
-		)
-		return
-	}
-
-	pr, _, err := r.client.PullRequests.Get(ctx, owner, repoName, number)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error importing pull request",
-			fmt.Sprintf("Unable to read pull request #%d from repository %s/%s: %v", number, owner, repoName, err),
-		)
-		return
-	}
-
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), fmt.Sprintf("%s:%d", repoName, pr.GetNumber()))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), fmt.Sprintf("%s:%d", repoName, number))...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repository"), repoName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("close_on_destroy"), true)...)
 }
 
 func (r *repositoryPullRequestResource) getOwner(ctx context.Context) (string, error) {
@@ -624,299 +392,295 @@ func (r *repositoryPullRequestResource) getOwner(ctx context.Context) (string, e
 	return user.GetLogin(), nil
 }
 
+// readPullRequest reads the pull request and the attributes that are managed by this resource.
 func (r *repositoryPullRequestResource) readPullRequest(ctx context.Context, owner, repoName string, number int, model *repositoryPullRequestResourceModel, diags *diag.Diagnostics) {
-	pr, _, err := r.client.PullRequests.Get(ctx, owner, repoName, number)
-	if err != nil {
-		var ghErr *github.ErrorResponse
-		if errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusNotFound {
-			log.Printf("[INFO] Pull request #%d not found, removing from state", number)
-			model.ID = types.StringValue("")
-			return
-		}
-		diags.AddError(
-			"Error reading pull request",
-			fmt.Sprintf("Unable to read pull request #%d from repository %s/%s: %v", number, owner, repoName, err),
-		)
+	pr := readPullRequest(ctx, r.client, owner, repoName, number, &model.pullRequestModel, diags)
+	if pr == nil {
 		return
 	}
+	r.readPullRequestDetails(ctx, owner, repoName, pr, model, diags)
+}
 
-	model.Repository = types.StringValue(repoName)
-	model.Number = types.Int64Value(int64(pr.GetNumber()))
-	model.Title = types.StringValue(pr.GetTitle())
-	model.Body = types.StringValue(pr.GetBody())
-	model.State = types.StringValue(pr.GetState())
-	model.Merged = types.BoolValue(pr.GetMerged())
-	model.MaintainerCanModify = types.BoolValue(pr.GetMaintainerCanModify())
+// readPullRequestDetails populates the draft state of the model, and the reviewers,
+// assignees, labels and milestone that the model manages.
+func (r *repositoryPullRequestResource) readPullRequestDetails(ctx context.Context, owner, repoName string, pr *github.PullRequest, model *repositoryPullRequestResourceModel, diags *diag.Diagnostics) {
+	model.Draft = types.BoolValue(pr.GetDraft())
 
-	mergedAt := pr.GetMergedAt()
-	if !mergedAt.IsZero() && pr.GetMerged() {
-		model.MergedAt = types.StringValue(mergedAt.Format(time.RFC3339))
-	} else {
-		model.MergedAt = types.StringNull()
+	if !model.Assignees.IsNull() {
+		assignees := make([]string, 0, len(pr.Assignees))
+		for _, user := range pr.Assignees {
+			assignees = append(assignees, user.GetLogin())
+		}
+		model.Assignees = keepConfiguredCase(ctx, model.Assignees, assignees, diags)
 	}
 
-	mergeCommitSHA := pr.GetMergeCommitSHA()
-	if mergeCommitSHA != "" && pr.GetMerged() {
-		model.MergeCommitSHA = types.StringValue(mergeCommitSHA)
-	} else {
-		model.MergeCommitSHA = types.StringNull()
+	if !model.Labels.IsNull() {
+		labels := make([]string, 0, len(pr.Labels))
+		for _, label := range pr.Labels {
+			labels = append(labels, label.GetName())
+		}
+		model.Labels = keepConfiguredCase(ctx, model.Labels, labels, diags)
 	}
 
-	if head := pr.GetHead(); head != nil {
-		model.HeadRef = types.StringValue(head.GetRef())
-		model.HeadSHA = types.StringValue(head.GetSHA())
+	if !model.Milestone.IsNull() {
+		if pr.GetMilestone() != nil {
+			model.Milestone = types.Int64Value(int64(pr.GetMilestone().GetNumber()))
+		} else {
+			model.Milestone = types.Int64Null()
+		}
 	}
 
-	if base := pr.GetBase(); base != nil {
-		model.BaseRef = types.StringValue(base.GetRef())
-		model.BaseSHA = types.StringValue(base.GetSHA())
+	if model.Reviewers.IsNull() && model.TeamReviewers.IsNull() {
+		return
 	}
-}
 
-func (r *repositoryPullRequestResource) handleAutoMerge(ctx context.Context, owner, repoName string, number int, plan *repositoryPullRequestResourceModel, diags *diag.Diagnostics) error {
-	if plan.MergeWhenReady.ValueBool() {
-		return r.mergeWhenReady(ctx, owner, repoName, number, plan, diags)
+	// Review requests are removed once they are answered, so reviewers that have
+	// reviewed the pull request are kept as well
+	reviews, _, err := r.client.PullRequests.ListReviews(ctx, owner, repoName, pr.GetNumber(), &github.ListOptions{PerPage: 100})
+	if err != nil {
+		addGitHubError(diags,
+			"Error reading pull request reviews",
+			fmt.Sprintf("Unable to read the reviews of pull request #%d in repository %s/%s: %v", pr.GetNumber(), owner, repoName, err),
+			err,
+		)
+		return
 	}
 
-	return nil
-}
-
-func (r *repositoryPullRequestResource) mergeWhenReady(ctx context.Context, owner, repoName string, number int, plan *repositoryPullRequestResourceModel, _ *diag.Diagnostics) error {
-	maxAttempts := 30
-	attempt := 0
-
-	for attempt < maxAttempts {
-		pr, _, err := r.client.PullRequests.Get(ctx, owner, repoName, number)
-		if err != nil {
-			return fmt.Errorf("unable to get pull request: %w", err)
+	if !model.Reviewers.IsNull() {
+		reviewers := make([]string, 0, len(pr.RequestedReviewers)+len(reviews))
+		for _, user := range pr.RequestedReviewers {
+			reviewers = append(reviewers, user.GetLogin())
 		}
-
-		if pr.GetState() != "open" {
-			if pr.GetMerged() {
-				return nil
-			}
-			return fmt.Errorf("pull request is not open")
+		for _, review := range reviews {
+			reviewers = append(reviewers, review.GetUser().GetLogin())
 		}
+		model.Reviewers = keepConfiguredCase(ctx, model.Reviewers, intersectFold(setElements(ctx, model.Reviewers, diags), reviewers), diags)
+	}
 
-		// Check mergeability - GitHub API returns *bool (nil = not computed yet, false = not mergeable, true = mergeable)
-		mergeablePtr := pr.Mergeable
-		if mergeablePtr == nil {
-			log.Printf("[DEBUG] PR mergeability not yet computed, waiting... (attempt %d/%d)", attempt+1, maxAttempts)
-			attempt++
-			time.Sleep(5 * time.Second)
-			continue
+	if !model.TeamReviewers.IsNull() {
+		teams := make([]string, 0, len(pr.RequestedTeams))
+		for _, team := range pr.RequestedTeams {
+			teams = append(teams, team.GetSlug())
 		}
-
-		if !*mergeablePtr {
-			log.Printf("[DEBUG] PR is not mergeable (conflicts or other issues), waiting... (attempt %d/%d)", attempt+1, maxAttempts)
-			attempt++
-			time.Sleep(5 * time.Second)
-			continue
+		// Reviews do not say which team they answer for, so all teams count as answered
+		// once the pull request has been reviewed
+		if len(reviews) > 0 {
+			teams = append(teams, setElements(ctx, model.TeamReviewers, diags)...)
 		}
+		model.TeamReviewers = keepConfiguredCase(ctx, model.TeamReviewers, intersectFold(setElements(ctx, model.TeamReviewers, diags), teams), diags)
+	}
+}
 
-		// PR is mergeable, proceed with merge
-		if plan.WaitForChecks.ValueBool() {
-			if err := r.waitForChecks(ctx, owner, repoName, number); err != nil {
-				log.Printf("[DEBUG] Checks not ready, retrying... (attempt %d/%d)", attempt+1, maxAttempts)
-				attempt++
-				time.Sleep(5 * time.Second)
-				continue
-			}
-		}
+// syncPullRequestDetails updates the draft state, reviewers, assignees, labels and
+// milestone of the pull request from the prior model to the plan.
+func (r *repositoryPullRequestResource) syncPullRequestDetails(ctx context.Context, owner, repoName string, pr *github.PullRequest, plan, prior *repositoryPullRequestResourceModel, diags *diag.Diagnostics) {
+	number := pr.GetNumber()
 
-		_, _, err = r.client.PullRequests.CreateReview(ctx, owner, repoName, number, &github.PullRequestReviewRequest{
-			Event: github.String("APPROVE"),
-			Body:  github.String("Auto-approved by Terraform"),
-		})
-		if err != nil {
-			log.Printf("[WARN] Failed to approve PR: %v", err)
+	if plan.Draft.ValueBool() != pr.GetDraft() {
+		r.setDraft(ctx, owner, repoName, pr, plan.Draft.ValueBool(), diags)
+		if diags.HasError() {
+			return
 		}
+	}
 
-		mergeMethod := plan.MergeMethod.ValueString()
-		if mergeMethod == "" {
-			mergeMethod = "merge"
+	if !plan.Assignees.IsNull() {
+		add, remove := diffFold(setElements(ctx, plan.Assignees, diags), setElements(ctx, prior.Assignees, diags))
+		if len(add) > 0 {
+			if _, _, err := r.client.Issues.AddAssignees(ctx, owner, repoName, number, add); err != nil {
+				addGitHubError(diags,
+					"Error assigning pull request",
+					fmt.Sprintf("Unable to assign %s to pull request #%d in repository %s/%s: %v", strings.Join(add, ", "), number, owner, repoName, err),
+					err,
+				)
+				return
+			}
 		}
-
-		// Check repository merge settings to validate merge method
-		repo, _, err := r.client.Repositories.Get(ctx, owner, repoName)
-		if err == nil && repo != nil {
-			// Validate merge method against repository settings
-			switch mergeMethod {
-			case "squash":
-				if !repo.GetAllowSquashMerge() {
-					// Fall back to merge if squash not allowed
-					if repo.GetAllowMergeCommit() {
-						log.Printf("[WARN] Squash merge not allowed, falling back to merge commit")
-						mergeMethod = "merge"
-					} else if repo.GetAllowRebaseMerge() {
-						log.Printf("[WARN] Squash merge not allowed, falling back to rebase")
-						mergeMethod = "rebase"
-					} else {
-						return fmt.Errorf("squash merge is not allowed on this repository and no alternative merge methods are enabled")
-					}
-				}
-			case "rebase":
-				if !repo.GetAllowRebaseMerge() {
-					// Fall back to merge if rebase not allowed
-					if repo.GetAllowMergeCommit() {
-						log.Printf("[WARN] Rebase merge not allowed, falling back to merge commit")
-						mergeMethod = "merge"
-					} else if repo.GetAllowSquashMerge() {
-						log.Printf("[WARN] Rebase merge not allowed, falling back to squash")
-						mergeMethod = "squash"
-					} else {
-						return fmt.Errorf("rebase merge is not allowed on this repository and no alternative merge methods are enabled")
-					}
-				}
-			case "merge":
-				if !repo.GetAllowMergeCommit() {
-					// Fall back to squash if merge not allowed
-					if repo.GetAllowSquashMerge() {
-						log.Printf("[WARN] Merge commit not allowed, falling back to squash")
-						mergeMethod = "squash"
-					} else if repo.GetAllowRebaseMerge() {
-						log.Printf("[WARN] Merge commit not allowed, falling back to rebase")
-						mergeMethod = "rebase"
-					} else {
-						return fmt.Errorf("merge commit is not allowed on this repository and no alternative merge methods are enabled")
-					}
-				}
+		if len(remove) > 0 {
+			if _, _, err := r.client.Issues.RemoveAssignees(ctx, owner, repoName, number, remove); err != nil {
+				addGitHubError(diags,
+					"Error unassigning pull request",
+					fmt.Sprintf("Unable to unassign %s from pull request #%d in repository %s/%s: %v", strings.Join(remove, ", "), number, owner, repoName, err),
+					err,
+				)
+				return
 			}
 		}
+	}
 
-		_, _, err = r.client.PullRequests.Merge(ctx, owner, repoName, number, "", &github.PullRequestOptions{
-			MergeMethod: mergeMethod,
-		})
-		if err != nil {
-			// If merge fails due to method not allowed, try to find an allowed method
-			var ghErr *github.ErrorResponse
-			if errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusMethodNotAllowed {
-				if repo != nil {
-					// Try alternative methods
-					if mergeMethod != "merge" && repo.GetAllowMergeCommit() {
-						log.Printf("[WARN] %s merge failed, trying merge commit", mergeMethod)
-						_, _, err = r.client.PullRequests.Merge(ctx, owner, repoName, number, "", &github.PullRequestOptions{
-							MergeMethod: "merge",
-						})
-					} else if mergeMethod != "squash" && repo.GetAllowSquashMerge() {
-						log.Printf("[WARN] %s merge failed, trying squash", mergeMethod)
-						_, _, err = r.client.PullRequests.Merge(ctx, owner, repoName, number, "", &github.PullRequestOptions{
-							MergeMethod: "squash",
-						})
-					} else if mergeMethod != "rebase" && repo.GetAllowRebaseMerge() {
-						log.Printf("[WARN] %s merge failed, trying rebase", mergeMethod)
-						_, _, err = r.client.PullRequests.Merge(ctx, owner, repoName, number, "", &github.PullRequestOptions{
-							MergeMethod: "rebase",
-						})
-					}
-				}
+	if !plan.Labels.IsNull() {
+		add, remove := diffFold(setElements(ctx, plan.Labels, diags), setElements(ctx, prior.Labels, diags))
+		if len(add) > 0 {
+			if _, _, err := r.client.Issues.AddLabelsToIssue(ctx, owner, repoName, number, add); err != nil {
+				addGitHubError(diags,
+					"Error labeling pull request",
+					fmt.Sprintf("Unable to add labels %s to pull request #%d in repository %s/%s: %v", strings.Join(add, ", "), number, owner, repoName, err),
+					err,
+				)
+				return
 			}
-			if err != nil {
-				return fmt.Errorf("unable to merge pull request: %w", err)
+		}
+		for _, label := range remove {
+			if _, err := r.client.Issues.RemoveLabelForIssue(ctx, owner, repoName, number, label); err != nil && !isNotFound(err) {
+				addGitHubError(diags,
+					"Error unlabeling pull request",
+					fmt.Sprintf("Unable to remove label %s from pull request #%d in repository %s/%s: %v", label, number, owner, repoName, err),
+					err,
+				)
+				return
 			}
 		}
+	}
 
-		log.Printf("[INFO] Successfully merged pull request #%d", number)
-
-		if plan.AutoDeleteBranch.ValueBool() {
-			headRef := plan.HeadRef.ValueString()
-			ref := fmt.Sprintf("refs/heads/%s", headRef)
-			_, err = r.client.Git.DeleteRef(ctx, owner, repoName, ref)
-			if err != nil {
-				log.Printf("[WARN] Failed to delete branch %s: %v", headRef, err)
-			} else {
-				log.Printf("[INFO] Deleted branch %s", headRef)
+	if !plan.Milestone.Equal(prior.Milestone) {
+		if plan.Milestone.IsNull() {
+			if _, _, err := r.client.Issues.RemoveMilestone(ctx, owner, repoName, number); err != nil {
+				addGitHubError(diags,
+					"Error removing milestone",
+					fmt.Sprintf("Unable to remove the milestone of pull request #%d in repository %s/%s: %v", number, owner, repoName, err),
+					err,
+				)
+				return
+			}
+		} else {
+			milestone := int(plan.Milestone.ValueInt64())
+			if _, _, err := r.client.Issues.Edit(ctx, owner, repoName, number, &github.IssueRequest{Milestone: &milestone}); err != nil {
+				addGitHubError(diags,
+					"Error setting milestone",
+					fmt.Sprintf("Unable to set milestone %d on pull request #%d in repository %s/%s: %v", milestone, number, owner, repoName, err),
+					err,
+				)
+				return
 			}
 		}
-
-		return nil
 	}
 
-	return fmt.Errorf("pull request not ready to merge after %d attempts", maxAttempts)
-}
-
-func (r *repositoryPullRequestResource) findExistingPR(ctx context.Context, owner, repoName, baseRef, headRef string) (*github.PullRequest, error) {
-	opts := &github.PullRequestListOptions{
-		State:       "all",
-		Head:        fmt.Sprintf("%s:%s", owner, headRef),
-		Base:        baseRef,
-		ListOptions: github.ListOptions{PerPage: 100},
+	var request, unrequest github.ReviewersRequest
+	if !plan.Reviewers.IsNull() {
+		request.Reviewers, unrequest.Reviewers = diffFold(setElements(ctx, plan.Reviewers, diags), setElements(ctx, prior.Reviewers, diags))
 	}
-
-	prs, _, err := r.client.PullRequests.List(ctx, owner, repoName, opts)
-	if err != nil {
-		return nil, err
+	if !plan.TeamReviewers.IsNull() {
+		request.TeamReviewers, unrequest.TeamReviewers = diffFold(setElements(ctx, plan.TeamReviewers, diags), setElements(ctx, prior.TeamReviewers, diags))
 	}
-
-	for _, pr := range prs {
-		if pr.GetBase().GetRef() == baseRef && pr.GetHead().GetRef() == headRef {
-			return pr, nil
+	if len(request.Reviewers) > 0 || len(request.TeamReviewers) > 0 {
+		if _, _, err := r.client.PullRequests.RequestReviewers(ctx, owner, repoName, number, request); err != nil {
+			addGitHubError(diags,
+				"Error requesting reviewers",
+				fmt.Sprintf("Unable to request reviews for pull request #%d in repository %s/%s: %v", number, owner, repoName, err),
+				err,
+			)
+			return
+		}
+	}
+	if len(unrequest.Reviewers) > 0 || len(unrequest.TeamReviewers) > 0 {
+		// Requests that were answered already cannot be removed, which is not an error
+		if _, err := r.client.PullRequests.RemoveReviewers(ctx, owner, repoName, number, unrequest); err != nil && !isValidationFailed(err) {
+			addGitHubError(diags,
+				"Error removing reviewers",
+				fmt.Sprintf("Unable to remove review requests from pull request #%d in repository %s/%s: %v", number, owner, repoName, err),
+				err,
+			)
+			return
 		}
 	}
-
-	return nil, nil
 }
 
-func (r *repositoryPullRequestResource) getBranchSHAs(ctx context.Context, owner, repoName, baseRef, headRef string) (string, string, error) {
-	baseRefFull := fmt.Sprintf("refs/heads/%s", baseRef)
-	headRefFull := fmt.Sprintf("refs/heads/%s", headRef)
-
-	baseRefObj, _, err := r.client.Git.GetRef(ctx, owner, repoName, baseRefFull)
+// setDraft converts a pull request to a draft, or marks it ready for review. The REST API
+// cannot change the draft state of an existing pull request, so this uses GraphQL.
+func (r *repositoryPullRequestResource) setDraft(ctx context.Context, owner, repoName string, pr *github.PullRequest, draft bool, diags *diag.Diagnostics) {
+	mutation := `mutation($pullRequestId: ID!) {
+  markPullRequestReadyForReview(input: {pullRequestId: $pullRequestId}) {
+    clientMutationId
+  }
+}`
+	if draft {
+		mutation = `mutation($pullRequestId: ID!) {
+  convertPullRequestToDraft(input: {pullRequestId: $pullRequestId}) {
+    clientMutationId
+  }
+}`
+	}
+
+	err := graphQLRequest(ctx, r.client, mutation, map[string]interface{}{
+		"pullRequestId": pr.GetNodeID(),
+	}, nil)
 	if err != nil {
-		return "", "", fmt.Errorf("unable to get base branch %s: %w", baseRef, err)
+		addGitHubError(diags,
+			"Error changing draft state",
+			fmt.Sprintf("Unable to change the draft state of pull request #%d in repository %s/%s: %v", pr.GetNumber(), owner, repoName, err),
+			err,
+		)
+		return
 	}
+	log.Printf("[INFO] Set draft state of pull request #%d in %s/%s to %t", pr.GetNumber(), owner, repoName, draft)
+}
 
-	headRefObj, _, err := r.client.Git.GetRef(ctx, owner, repoName, headRefFull)
-	if err != nil {
-		return "", "", fmt.Errorf("unable to get head branch %s: %w", headRef, err)
+// setElements returns the elements of a set of strings, or nil if it is null or unknown.
+func setElements(ctx context.Context, set types.Set, diags *diag.Diagnostics) []string {
+	if set.IsNull() || set.IsUnknown() {
+		return nil
 	}
-
-	baseSHA := baseRefObj.GetObject().GetSHA()
-	headSHA := headRefObj.GetObject().GetSHA()
-
-	return baseSHA, headSHA, nil
+	var elements []string
+	diags.Append(set.ElementsAs(ctx, &elements, false)...)
+	return elements
 }
 
-func (r *repositoryPullRequestResource) waitForChecks(ctx context.Context, owner, repoName string, number int) error {
-	maxAttempts := 60
-	attempt := 0
-
-	for attempt < maxAttempts {
-		pr, _, err := r.client.PullRequests.Get(ctx, owner, repoName, number)
-		if err != nil {
-			return fmt.Errorf("unable to get pull request: %w", err)
-		}
-
-		headSHA := pr.GetHead().GetSHA()
-		statuses, _, err := r.client.Repositories.ListStatuses(ctx, owner, repoName, headSHA, nil)
-		if err != nil {
-			return fmt.Errorf("unable to get status checks: %w", err)
-		}
-
-		allPassed := true
-		for _, status := range statuses {
-			state := status.GetState()
-			if state == "pending" {
-				allPassed = false
+// keepConfiguredCase returns values as a set, spelled as in the configured set where they
+// only differ in case, as GitHub logins, team slugs and label names are case-insensitive.
+func keepConfiguredCase(ctx context.Context, configured types.Set, values []string, diags *diag.Diagnostics) types.Set {
+	configuredValues := setElements(ctx, configured, diags)
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		for _, c := range configuredValues {
+			if strings.EqualFold(c, value) {
+				value = c
 				break
 			}
-			if state == "error" || state == "failure" {
-				return fmt.Errorf("status check %s failed", status.GetContext())
-			}
 		}
-
-		if allPassed && len(statuses) > 0 {
-			return nil
+		if !seen[strings.ToLower(value)] {
+			seen[strings.ToLower(value)] = true
+			result = append(result, value)
 		}
+	}
+	set, d := types.SetValueFrom(ctx, types.StringType, result)
+	diags.Append(d...)
+	return set
+}
 
-		if len(statuses) == 0 {
-			log.Printf("[DEBUG] No status checks found, proceeding")
-			return nil
+// intersectFold returns the values of a that are also in b, ignoring case.
+func intersectFold(a, b []string) []string {
+	result := make([]string, 0, len(a))
+	for _, value := range a {
+		if containsFold(b, value) {
+			result = append(result, value)
 		}
+	}
+	return result
+}
 
-		attempt++
-		time.Sleep(5 * time.Second)
+// diffFold returns the values of want that are not in have, and the values of have that
+// are not in want, ignoring case.
+func diffFold(want, have []string) ([]string, []string) {
+	var add, remove []string
+	for _, value := range want {
+		if !containsFold(have, value) {
+			add = append(add, value)
+		}
+	}
+	for _, value := range have {
+		if !containsFold(want, value) {
+			remove = append(remove, value)
+		}
 	}
+	return add, remove
+}
 
-	return fmt.Errorf("status checks did not complete after %d attempts", maxAttempts)
+// containsFold reports whether values contains value, ignoring case.
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
 }
@@ -2,7 +2,6 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -16,10 +15,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"golang.org/x/oauth2"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -27,6 +27,18 @@ var (
 	_ resource.Resource                = &repositoryPullRequestAutoMergeResource{}
 	_ resource.ResourceWithConfigure   = &repositoryPullRequestAutoMergeResource{}
 	_ resource.ResourceWithImportState = &repositoryPullRequestAutoMergeResource{}
+	_ resource.ResourceWithModifyPlan  = &repositoryPullRequestAutoMergeResource{}
+)
+
+const (
+	// defaultChecksTimeout is how long to wait for checks to complete by default.
+	defaultChecksTimeout = 5 * time.Minute
+	// defaultApprovalsTimeout is how long to wait for approvals by default.
+	defaultApprovalsTimeout = 30 * time.Minute
+	// defaultPollInterval is how often to check a pull request while waiting by default.
+	defaultPollInterval = 5 * time.Second
+	// mergeableTimeout is how long to wait for a pull request to become mergeable.
+	mergeableTimeout = 5 * time.Minute
 )
 
 // NewRepositoryPullRequestAutoMergeResource is a helper function to simplify the provider implementation.
@@ -42,24 +54,28 @@ type repositoryPullRequestAutoMergeResource struct {
 
 // repositoryPullRequestAutoMergeResourceModel maps the resource schema data.
 type repositoryPullRequestAutoMergeResourceModel struct {
-	Repository          types.String `tfsdk:"repository"`
-	BaseRef             types.String `tfsdk:"base_ref"`
-	HeadRef             types.String `tfsdk:"head_ref"`
-	Title               types.String `tfsdk:"title"`
-	Body                types.String `tfsdk:"body"`
-	MergeWhenReady      types.Bool   `tfsdk:"merge_when_ready"`
-	MergeMethod         types.String `tfsdk:"merge_method"`
-	WaitForChecks       types.Bool   `tfsdk:"wait_for_checks"`
-	AutoDeleteBranch    types.Bool   `tfsdk:"auto_delete_branch"`
-	MaintainerCanModify types.Bool   `tfsdk:"maintainer_can_modify"`
-	BaseSHA             types.String `tfsdk:"base_sha"`
-	HeadSHA             types.String `tfsdk:"head_sha"`
-	Number              types.Int64  `tfsdk:"number"`
-	State               types.String `tfsdk:"state"`
-	Merged              types.Bool   `tfsdk:"merged"`
-	MergedAt            types.String `tfsdk:"merged_at"`
-	MergeCommitSHA      types.String `tfsdk:"merge_commit_sha"`
-	ID                  types.String `tfsdk:"id"`
+	pullRequestModel
+	MergeWhenReady   types.Bool   `tfsdk:"merge_when_ready"`
+	MergeMethod      types.String `tfsdk:"merge_method"`
+	WaitForChecks    types.Bool   `tfsdk:"wait_for_checks"`
+	AutoDeleteBranch types.Bool   `tfsdk:"auto_delete_branch"`
+	Approve          types.Object `tfsdk:"approve"`
+	WaitForApprovals types.Bool   `tfsdk:"wait_for_approvals"`
+	ApprovalsTimeout types.String `tfsdk:"approvals_timeout"`
+	RequiredChecks   types.Set    `tfsdk:"required_checks"`
+	ChecksTimeout    types.String `tfsdk:"checks_timeout"`
+	PollInterval     types.String `tfsdk:"poll_interval"`
+	AutoMerge        types.Bool   `tfsdk:"auto_merge"`
+	AutoMergeEnabled types.Bool   `tfsdk:"auto_merge_enabled"`
+	AutoMergeMethod  types.String `tfsdk:"auto_merge_method"`
+	MergeableState   types.String `tfsdk:"mergeable_state"`
+}
+
+// pullRequestApproveModel represents the `approve` block of a pull request.
+type pullRequestApproveModel struct {
+	Enabled types.Bool   `tfsdk:"enabled"`
+	Body    types.String `tfsdk:"body"`
+	Token   types.String `tfsdk:"token"`
 }
 
 // Metadata returns the resource type name.
@@ -69,102 +85,111 @@ func (r *repositoryPullRequestAutoMergeResource) Metadata(_ context.Context, req
 
 // Schema defines the schema for the resource.
 func (r *repositoryPullRequestAutoMergeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
-	resp.Schema = schema.Schema{
-		Description: "Creates and manages a GitHub pull request with optional auto-merge capabilities. Supports multiple files through branch-based commits.",
+	attributes := pullRequestSchemaAttributes()
+	attributes["merge_when_ready"] = schema.BoolAttribute{
+		Description: "Wait for the pull request to be mergeable and its checks to pass, then automatically merge. Set 'wait_for_approvals' to wait for reviews as well.",
+		Optional:    true,
+		Computed:    true,
+		Default:     booldefault.StaticBool(false),
+	}
+	attributes["merge_method"] = schema.StringAttribute{
+		Description: "The merge method to use when auto-merging. Options: 'merge', 'squash', 'rebase'. Defaults to 'merge'.",
+		Optional:    true,
+		Computed:    true,
+		Default:     stringdefault.StaticString("merge"),
+	}
+	attributes["wait_for_checks"] = schema.BoolAttribute{
+		Description: "Wait for CI checks to pass before merging. Only applies when 'merge_when_ready' is true.",
+		Optional:    true,
+		Computed:    true,
+		Default:     booldefault.StaticBool(true),
+	}
+	attributes["approve"] = schema.SingleNestedAttribute{
+		Description: "Approve the pull request before merging, as the user of a separate token. GitHub does not let authors approve their own pull requests, so this cannot be the provider's token. Pull requests are not approved unless this is set.",
+		Optional:    true,
 		Attributes: map[string]schema.Attribute{
-			"repository": schema.StringAttribute{
-				Description: "The GitHub repository name.",
-				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
-			},
-			"base_ref": schema.StringAttribute{
-				Description: "The base branch name (e.g., 'main', 'develop').",
-				Required:    true,
-			},
-			"head_ref": schema.StringAttribute{
-				Description: "The head branch name (e.g., 'feature-branch').",
-				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
-			},
-			"title": schema.StringAttribute{
-				Description: "The title of the pull request.",
-				Required:    true,
-			},
-			"body": schema.StringAttribute{
-				Description: "The body/description of the pull request.",
-				Optional:    true,
-			},
-			"merge_when_ready": schema.BoolAttribute{
-				Description: "Wait for all checks and approvals to pass, then automatically merge.",
-				Optional:    true,
-				Computed:    true,
-				Default:     booldefault.StaticBool(false),
-			},
-			"merge_method": schema.StringAttribute{
-				Description: "The merge method to use when auto-merging. Options: 'merge', 'squash', 'rebase'. Defaults to 'merge'.",
+			"enabled": schema.BoolAttribute{
+				Description: "Whether to approve the pull request. Defaults to \"true\".",
 				Optional:    true,
-				Computed:    true,
-				Default:     stringdefault.StaticString("merge"),
 			},
-			"wait_for_checks": schema.BoolAttribute{
-				Description: "Wait for CI checks to pass before merging. Only applies when 'merge_when_ready' is true.",
-				Optional:    true,
-				Computed:    true,
-				Default:     booldefault.StaticBool(true),
-			},
-			"auto_delete_branch": schema.BoolAttribute{
-				Description: "Automatically delete the head branch after merge.",
-				Optional:    true,
-				Computed:    true,
-				Default:     booldefault.StaticBool(false),
-			},
-			"maintainer_can_modify": schema.BoolAttribute{
-				Description: "Allow maintainers to modify the pull request.",
+			"body": schema.StringAttribute{
+				Description: "The body of the approving review. Defaults to \"Approved by Terraform\".",
 				Optional:    true,
-				Computed:    true,
-				Default:     booldefault.StaticBool(false),
-			},
-			"base_sha": schema.StringAttribute{
-				Description: "The SHA of the base branch.",
-				Computed:    true,
-			},
-			"head_sha": schema.StringAttribute{
-				Description: "The SHA of the head branch.",
-				Computed:    true,
-			},
-			"number": schema.Int64Attribute{
-				Description: "The pull request number.",
-				Computed:    true,
-			},
-			"state": schema.StringAttribute{
-				Description: "The state of the pull request (open, closed, merged).",
-				Computed:    true,
 			},
-			"merged": schema.BoolAttribute{
-				Description: "Whether the pull request has been merged.",
-				Computed:    true,
-			},
-			"merged_at": schema.StringAttribute{
-				Description: "The timestamp when the pull request was merged.",
-				Computed:    true,
-			},
-			"merge_commit_sha": schema.StringAttribute{
-				Description: "The SHA of the merge commit.",
-				Computed:    true,
-			},
-			"id": schema.StringAttribute{
-				Description: "The Terraform state ID (repository:number).",
-				Computed:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
-				},
+			"token": schema.StringAttribute{
+				Description: "The GitHub token of the approver.",
+				Required:    true,
+				Sensitive:   true,
 			},
 		},
 	}
+	attributes["wait_for_approvals"] = schema.BoolAttribute{
+		Description: "Wait for the reviews that the base branch requires before merging, or for an approval if it requires none. Only applies when 'merge_when_ready' is true, as GitHub's auto-merge waits for required reviews itself. Defaults to \"false\".",
+		Optional:    true,
+		Computed:    true,
+		Default:     booldefault.StaticBool(false),
+	}
+	attributes["approvals_timeout"] = schema.StringAttribute{
+		Description: "How long to wait for approvals with 'wait_for_approvals', as a duration such as \"1h\". Defaults to \"30m\".",
+		Optional:    true,
+		Computed:    true,
+		Default:     stringdefault.StaticString("30m"),
+		Validators: []validator.String{
+			durationValidator{},
+		},
+	}
+	attributes["required_checks"] = schema.SetAttribute{
+		Description: "The names of the check runs and commit statuses that must pass before merging. If not set, the status checks required by the protection of the base branch are used, or all reported checks if it requires none.",
+		Optional:    true,
+		ElementType: types.StringType,
+	}
+	attributes["checks_timeout"] = schema.StringAttribute{
+		Description: "How long to wait for checks to complete, as a duration such as \"10m\". Defaults to \"5m\".",
+		Optional:    true,
+		Computed:    true,
+		Default:     stringdefault.StaticString("5m"),
+		Validators: []validator.String{
+			durationValidator{},
+		},
+	}
+	attributes["poll_interval"] = schema.StringAttribute{
+		Description: "How often to check the pull request while waiting for it to be ready, as a duration such as \"10s\". Defaults to \"5s\".",
+		Optional:    true,
+		Computed:    true,
+		Default:     stringdefault.StaticString("5s"),
+		Validators: []validator.String{
+			durationValidator{},
+		},
+	}
+	attributes["auto_delete_branch"] = schema.BoolAttribute{
+		Description: "Automatically delete the head branch after merge. With 'auto_merge', this only applies if the pull request is merged straight away.",
+		Optional:    true,
+		Computed:    true,
+		Default:     booldefault.StaticBool(false),
+	}
+	attributes["auto_merge"] = schema.BoolAttribute{
+		Description: "Enable GitHub's native auto-merge, so that GitHub merges the pull request with 'merge_method' once its requirements are met, instead of waiting for it during apply. A pull request that can already be merged is merged straight away. Takes precedence over 'merge_when_ready'. Defaults to \"false\".",
+		Optional:    true,
+		Computed:    true,
+		Default:     booldefault.StaticBool(false),
+	}
+	attributes["auto_merge_enabled"] = schema.BoolAttribute{
+		Description: "Whether auto-merge is enabled for the pull request.",
+		Computed:    true,
+	}
+	attributes["auto_merge_method"] = schema.StringAttribute{
+		Description: "The merge method that auto-merge will use, if it is enabled.",
+		Computed:    true,
+	}
+	attributes["mergeable_state"] = schema.StringAttribute{
+		Description: "The mergeable state of the pull request as reported by GitHub, such as 'clean', 'blocked', 'behind' or 'unstable'.",
+		Computed:    true,
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Creates and manages a GitHub pull request with optional auto-merge capabilities. Supports multiple files through branch-based commits.",
+		Attributes:  attributes,
+	}
 }
 
 // Configure enables provider-level data or clients to be set in the
@@ -187,6 +212,31 @@ func (r *repositoryPullRequestAutoMergeResource) Configure(ctx context.Context,
 	r.owner = clientData.Owner
 }
 
+// ModifyPlan marks the computed attributes that an update changes as unknown, including
+// the merge attributes when the update sets up merging the pull request.
+func (r *repositoryPullRequestAutoMergeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state repositoryPullRequestAutoMergeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planPullRequestUpdate(&plan.pullRequestModel, &state.pullRequestModel, mergeSettingsChanged(&plan, &state))
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// mergeSettingsChanged reports whether an update changes how the pull request is merged,
+// so that it sets up merging again, which may merge the pull request.
+func mergeSettingsChanged(plan, state *repositoryPullRequestAutoMergeResourceModel) bool {
+	autoMergeChanged := !plan.AutoMerge.Equal(state.AutoMerge) || (plan.AutoMerge.ValueBool() && !plan.MergeMethod.Equal(state.MergeMethod))
+	return autoMergeChanged || (!plan.MergeWhenReady.Equal(state.MergeWhenReady) && plan.MergeWhenReady.ValueBool())
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *repositoryPullRequestAutoMergeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan repositoryPullRequestAutoMergeResourceModel
@@ -214,115 +264,34 @@ func (r *repositoryPullRequestAutoMergeResource) Create(ctx context.Context, req
 	}
 
 	repoName := plan.Repository.ValueString()
-	baseRef := plan.BaseRef.ValueString()
-	headRef := plan.HeadRef.ValueString()
-	title := plan.Title.ValueString()
 
-	if baseRef == headRef {
-		resp.Diagnostics.AddError(
-			"Invalid Configuration",
-			fmt.Sprintf("Base branch '%s' and head branch '%s' cannot be the same. There must be a difference to create a pull request.", baseRef, headRef),
-		)
-		return
+	newPR := &github.NewPullRequest{
+		Title:               github.String(plan.Title.ValueString()),
+		Head:                github.String(plan.HeadRef.ValueString()),
+		Base:                github.String(plan.BaseRef.ValueString()),
+		MaintainerCanModify: github.Bool(plan.MaintainerCanModify.ValueBool()),
 	}
 
-	existingPR, err := r.findExistingPR(ctx, owner, repoName, baseRef, headRef)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error checking for existing pull request",
-			fmt.Sprintf("Unable to check for existing pull request in repository %s/%s: %v", owner, repoName, err),
-		)
-		return
+	if !plan.Body.IsNull() && !plan.Body.IsUnknown() {
+		newPR.Body = github.String(plan.Body.ValueString())
 	}
 
-	var pr *github.PullRequest
-	if existingPR != nil {
-		if existingPR.GetState() == "closed" && !existingPR.GetMerged() {
-			// Reopen the closed PR
-			log.Printf("[INFO] Reopening closed pull request #%d from '%s' to '%s' in repository %s/%s", existingPR.GetNumber(), headRef, baseRef, owner, repoName)
-			update := &github.PullRequest{
-				State: github.String("open"),
-			}
-			reopenedPR, _, err := r.client.PullRequests.Edit(ctx, owner, repoName, existingPR.GetNumber(), update)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Error reopening pull request",
-					fmt.Sprintf("Unable to reopen pull request #%d in repository %s/%s: %v", existingPR.GetNumber(), owner, repoName, err),
-				)
-				return
-			}
-			pr = reopenedPR
-		} else {
-			// Adopt the existing PR - this handles the case where PR was created outside Terraform
-			// or if terraform apply is run again after the PR was already created
-			log.Printf("[INFO] Adopting existing pull request #%d from '%s' to '%s' in repository %s/%s", existingPR.GetNumber(), headRef, baseRef, owner, repoName)
-			pr = existingPR
-		}
-		plan.ID = types.StringValue(fmt.Sprintf("%s:%d", repoName, pr.GetNumber()))
-		plan.Number = types.Int64Value(int64(pr.GetNumber()))
-	} else {
-		// No existing PR found, create a new one
-		baseSHA, headSHA, err := r.getBranchSHAs(ctx, owner, repoName, baseRef, headRef)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error checking branch differences",
-				fmt.Sprintf("Unable to get branch information: %v", err),
-			)
-			return
-		}
-
-		if baseSHA == headSHA {
-			resp.Diagnostics.AddError(
-				"No Differences",
-				fmt.Sprintf("Branches '%s' and '%s' are at the same commit (SHA: %s). There are no changes to create a pull request.", headRef, baseRef, headSHA),
-			)
-			return
-		}
-
-		newPR := &github.NewPullRequest{
-			Title:               github.String(title),
-			Head:                github.String(headRef),
-			Base:                github.String(baseRef),
-			MaintainerCanModify: github.Bool(plan.MaintainerCanModify.ValueBool()),
-		}
-
-		if !plan.Body.IsNull() && !plan.Body.IsUnknown() {
-			newPR.Body = github.String(plan.Body.ValueString())
-		}
-
-		pr, _, err = r.client.PullRequests.Create(ctx, owner, repoName, newPR)
-		if err != nil {
-			var ghErr *github.ErrorResponse
-			if errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusUnprocessableEntity {
-				if strings.Contains(err.Error(), "already exists") || strings.Contains(err.Error(), "No commits between") {
-					resp.Diagnostics.AddError(
-						"Pull Request Already Exists or No Changes",
-						fmt.Sprintf("Unable to create pull request: %v. A pull request may already exist, or there are no commits between '%s' and '%s'.", err, headRef, baseRef),
-					)
-					return
-				}
-			}
-			resp.Diagnostics.AddError(
-				"Error creating pull request",
-				fmt.Sprintf("Unable to create pull request in repository %s/%s: %v", owner, repoName, err),
-			)
-			return
-		}
-
-		plan.ID = types.StringValue(fmt.Sprintf("%s:%d", repoName, pr.GetNumber()))
-		plan.Number = types.Int64Value(int64(pr.GetNumber()))
+	pr := openPullRequest(ctx, r.client, owner, repoName, newPR, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	plan.ID = types.StringValue(fmt.Sprintf("%s:%d", repoName, pr.GetNumber()))
+	plan.Number = types.Int64Value(int64(pr.GetNumber()))
 
 	// Only attempt auto-merge if PR is open and not already merged
 	if pr.GetState() == "open" && !pr.GetMerged() {
-		if plan.MergeWhenReady.ValueBool() {
-			if err := r.handleAutoMerge(ctx, owner, repoName, pr.GetNumber(), &plan, &resp.Diagnostics); err != nil {
-				resp.Diagnostics.AddError(
-					"Error setting up auto-merge",
-					fmt.Sprintf("Unable to set up auto-merge for pull request #%d: %v", pr.GetNumber(), err),
-				)
-				return
-			}
+		if err := r.handleAutoMerge(ctx, owner, repoName, pr.GetNumber(), &plan, &resp.Diagnostics); err != nil {
+			addGitHubError(&resp.Diagnostics,
+				"Error setting up auto-merge",
+				fmt.Sprintf("Unable to set up auto-merge for pull request #%d: %v", pr.GetNumber(), err),
+				err,
+			)
+			return
 		}
 	}
 
@@ -361,34 +330,29 @@ func (r *repositoryPullRequestAutoMergeResource) Read(ctx context.Context, req r
 	}
 
 	id := state.ID.ValueString()
-	parts := strings.SplitN(id, ":", 2)
-	if len(parts) != 2 {
+	repoName, number, err := parsePullRequestID(id)
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Invalid ID",
-			fmt.Sprintf("Invalid ID format: %s. Expected 'repository:number'.", id),
+			fmt.Sprintf("Invalid ID format: %s. Expected 'repository:number'. Error: %v", id, err),
 		)
 		return
 	}
 
-	repoName := parts[0]
-	numberStr := parts[1]
-	number, err := strconv.Atoi(numberStr)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Invalid PR Number",
-			fmt.Sprintf("Invalid PR number in ID: %s", numberStr),
-		)
+	r.readPullRequest(ctx, owner, repoName, number, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	r.readPullRequest(ctx, owner, repoName, number, &state, &resp.Diagnostics)
-	if resp.Diagnostics.HasError() {
+	if state.ID.ValueString() == "" {
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
 	if state.State.ValueString() == "closed" && !state.Merged.ValueBool() {
 		log.Printf("[INFO] Pull request #%d is closed but not merged, removing from state", number)
-		state.ID = types.StringValue("")
+		resp.State.RemoveResource(ctx)
+		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -422,55 +386,36 @@ func (r *repositoryPullRequestAutoMergeResource) Update(ctx context.Context, req
 	}
 
 	id := state.ID.ValueString()
-	parts := strings.SplitN(id, ":", 2)
-	if len(parts) != 2 {
+	repoName, number, err := parsePullRequestID(id)
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Invalid ID",
-			fmt.Sprintf("Invalid ID format: %s. Expected 'repository:number'.", id),
+			fmt.Sprintf("Invalid ID format: %s. Expected 'repository:number'. Error: %v", id, err),
 		)
 		return
 	}
 
-	repoName := parts[0]
-	numberStr := parts[1]
-	number, err := strconv.Atoi(numberStr)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Invalid PR Number",
-			fmt.Sprintf("Invalid PR number in ID: %s", numberStr),
-		)
+	editPullRequest(ctx, r.client, owner, repoName, number, &plan.pullRequestModel, &state.pullRequestModel, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	update := &github.PullRequest{
-		Title:               github.String(plan.Title.ValueString()),
-		MaintainerCanModify: github.Bool(plan.MaintainerCanModify.ValueBool()),
-	}
-
-	if !plan.Body.IsNull() && !plan.Body.IsUnknown() {
-		update.Body = github.String(plan.Body.ValueString())
-	}
-
-	if !plan.BaseRef.Equal(state.BaseRef) {
-		update.Base = &github.PullRequestBranch{
-			Ref: github.String(plan.BaseRef.ValueString()),
+	if !plan.AutoMerge.ValueBool() && state.AutoMerge.ValueBool() {
+		if err := r.disableAutoMerge(ctx, owner, repoName, number); err != nil {
+			addGitHubError(&resp.Diagnostics,
+				"Error disabling auto-merge",
+				fmt.Sprintf("Unable to disable auto-merge for pull request #%d: %v", number, err),
+				err,
+			)
+			return
 		}
 	}
-
-	_, _, err = r.client.PullRequests.Edit(ctx, owner, repoName, number, update)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating pull request",
-			fmt.Sprintf("Unable to update pull request #%d in repository %s/%s: %v", number, owner, repoName, err),
-		)
-		return
-	}
-
-	if !plan.MergeWhenReady.Equal(state.MergeWhenReady) && plan.MergeWhenReady.ValueBool() {
+	if mergeSettingsChanged(&plan, &state) {
 		if err := r.handleAutoMerge(ctx, owner, repoName, number, &plan, &resp.Diagnostics); err != nil {
-			resp.Diagnostics.AddError(
+			addGitHubError(&resp.Diagnostics,
 				"Error setting up auto-merge",
 				fmt.Sprintf("Unable to set up auto-merge for pull request #%d: %v", number, err),
+				err,
 			)
 			return
 		}
@@ -511,61 +456,16 @@ func (r *repositoryPullRequestAutoMergeResource) Delete(ctx context.Context, req
 	}
 
 	id := state.ID.ValueString()
-	parts := strings.SplitN(id, ":", 2)
-	if len(parts) != 2 {
-		resp.Diagnostics.AddError(
-			"Invalid ID",
-			fmt.Sprintf("Invalid ID format: %s. Expected 'repository:number'.", id),
-		)
-		return
-	}
-
-	repoName := parts[0]
-	numberStr := parts[1]
-	number, err := strconv.Atoi(numberStr)
+	repoName, number, err := parsePullRequestID(id)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Invalid PR Number",
-			fmt.Sprintf("Invalid PR number in ID: %s", numberStr),
-		)
-		return
-	}
-
-	pr, _, err := r.client.PullRequests.Get(ctx, owner, repoName, number)
-	if err != nil {
-		var ghErr *github.ErrorResponse
-		if errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusNotFound {
-			log.Printf("[INFO] Pull request #%d not found, assuming already deleted", number)
-			return
-		}
-		resp.Diagnostics.AddError(
-			"Error reading pull request",
-			fmt.Sprintf("Unable to read pull request #%d from repository %s/%s: %v", number, owner, repoName, err),
+			"Invalid ID",
+			fmt.Sprintf("Invalid ID format: %s. Expected 'repository:number'. Error: %v", id, err),
 		)
 		return
 	}
 
-	// If PR is already merged, don't close it - just cleanup
-	if pr.GetMerged() {
-		log.Printf("[INFO] Pull request #%d is already merged, skipping close operation", number)
-		return
-	}
-
-	// Close the PR if it's still open
-	if pr.GetState() == "open" {
-		update := &github.PullRequest{State: github.String("closed")}
-		_, _, err = r.client.PullRequests.Edit(ctx, owner, repoName, number, update)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error closing pull request",
-				fmt.Sprintf("Unable to close pull request #%d in repository %s/%s: %v", number, owner, repoName, err),
-			)
-			return
-		}
-		log.Printf("[INFO] Closed pull request #%d", number)
-	} else {
-		log.Printf("[INFO] Pull request #%d is already closed", number)
-	}
+	closePullRequest(ctx, r.client, owner, repoName, number, &resp.Diagnostics)
 }
 
 // ImportState imports the resource.
@@ -601,9 +501,10 @@ func (r *repositoryPullRequestAutoMergeResource) ImportState(ctx context.Context
 
 	pr, _, err := r.client.PullRequests.Get(ctx, owner, repoName, number)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addGitHubError(&resp.Diagnostics,
 			"Error importing pull request",
 			fmt.Sprintf("Unable to read pull request #%d from repository %s/%s: %v", number, owner, repoName, err),
+			err,
 		)
 		return
 	}
@@ -624,68 +525,99 @@ func (r *repositoryPullRequestAutoMergeResource) getOwner(ctx context.Context) (
 	return user.GetLogin(), nil
 }
 
+// readPullRequest reads the pull request and its auto-merge settings into the model.
 func (r *repositoryPullRequestAutoMergeResource) readPullRequest(ctx context.Context, owner, repoName string, number int, model *repositoryPullRequestAutoMergeResourceModel, diags *diag.Diagnostics) {
-	pr, _, err := r.client.PullRequests.Get(ctx, owner, repoName, number)
-	if err != nil {
-		var ghErr *github.ErrorResponse
-		if errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusNotFound {
-			log.Printf("[INFO] Pull request #%d not found, removing from state", number)
-			model.ID = types.StringValue("")
-			return
-		}
-		diags.AddError(
-			"Error reading pull request",
-			fmt.Sprintf("Unable to read pull request #%d from repository %s/%s: %v", number, owner, repoName, err),
-		)
+	pr := readPullRequest(ctx, r.client, owner, repoName, number, &model.pullRequestModel, diags)
+	if pr == nil {
 		return
 	}
 
-	model.Repository = types.StringValue(repoName)
-	model.Number = types.Int64Value(int64(pr.GetNumber()))
-	model.Title = types.StringValue(pr.GetTitle())
-	model.Body = types.StringValue(pr.GetBody())
-	model.State = types.StringValue(pr.GetState())
-	model.Merged = types.BoolValue(pr.GetMerged())
-	model.MaintainerCanModify = types.BoolValue(pr.GetMaintainerCanModify())
-
-	mergedAt := pr.GetMergedAt()
-	if !mergedAt.IsZero() && pr.GetMerged() {
-		model.MergedAt = types.StringValue(mergedAt.Format(time.RFC3339))
+	model.AutoMergeEnabled = types.BoolValue(pr.AutoMerge != nil)
+	if pr.AutoMerge != nil {
+		model.AutoMergeMethod = types.StringValue(strings.ToLower(pr.AutoMerge.GetMergeMethod()))
 	} else {
-		model.MergedAt = types.StringNull()
+		model.AutoMergeMethod = types.StringNull()
 	}
+	model.MergeableState = types.StringValue(pr.GetMergeableState())
+}
 
-	mergeCommitSHA := pr.GetMergeCommitSHA()
-	if mergeCommitSHA != "" && pr.GetMerged() {
-		model.MergeCommitSHA = types.StringValue(mergeCommitSHA)
-	} else {
-		model.MergeCommitSHA = types.StringNull()
+func (r *repositoryPullRequestAutoMergeResource) handleAutoMerge(ctx context.Context, owner, repoName string, number int, plan *repositoryPullRequestAutoMergeResourceModel, diags *diag.Diagnostics) error {
+	if plan.AutoMerge.ValueBool() {
+		pr, _, err := r.client.PullRequests.Get(ctx, owner, repoName, number)
+		if err != nil {
+			return fmt.Errorf("unable to get pull request: %w", err)
+		}
+		if err := r.approve(ctx, owner, repoName, pr, plan); err != nil {
+			return err
+		}
+		return r.enableAutoMerge(ctx, owner, repoName, number, plan)
 	}
 
-	if head := pr.GetHead(); head != nil {
-		model.HeadRef = types.StringValue(head.GetRef())
-		model.HeadSHA = types.StringValue(head.GetSHA())
+	if plan.MergeWhenReady.ValueBool() {
+		return r.mergeWhenReady(ctx, owner, repoName, number, plan, diags)
 	}
 
-	if base := pr.GetBase(); base != nil {
-		model.BaseRef = types.StringValue(base.GetRef())
-		model.BaseSHA = types.StringValue(base.GetSHA())
-	}
+	return nil
 }
 
-func (r *repositoryPullRequestAutoMergeResource) handleAutoMerge(ctx context.Context, owner, repoName string, number int, plan *repositoryPullRequestAutoMergeResourceModel, diags *diag.Diagnostics) error {
-	if plan.MergeWhenReady.ValueBool() {
-		return r.mergeWhenReady(ctx, owner, repoName, number, plan, diags)
+// enableAutoMerge enables GitHub's auto-merge for the pull request with the planned merge
+// method, and returns without waiting for the pull request to be merged.
+func (r *repositoryPullRequestAutoMergeResource) enableAutoMerge(ctx context.Context, owner, repoName string, number int, plan *repositoryPullRequestAutoMergeResourceModel) error {
+	pr, _, err := r.client.PullRequests.Get(ctx, owner, repoName, number)
+	if err != nil {
+		return fmt.Errorf("unable to get pull request: %w", err)
+	}
+
+	mergeMethod := plan.MergeMethod.ValueString()
+	if pr.AutoMerge != nil {
+		if strings.EqualFold(pr.AutoMerge.GetMergeMethod(), mergeMethod) {
+			return nil
+		}
+		// The merge method of enabled auto-merge cannot be changed, so start over
+		if err := disablePullRequestAutoMerge(ctx, r.client, owner, repoName, pr); err != nil {
+			return err
+		}
 	}
 
+	merged, err := enablePullRequestAutoMerge(ctx, r.client, owner, repoName, pr, mergeMethod)
+	if err != nil {
+		return err
+	}
+	if merged && plan.AutoDeleteBranch.ValueBool() {
+		r.deleteHeadBranch(ctx, owner, repoName, plan.HeadRef.ValueString())
+	}
 	return nil
 }
 
+// disableAutoMerge disables GitHub's auto-merge for the pull request, if it is enabled.
+func (r *repositoryPullRequestAutoMergeResource) disableAutoMerge(ctx context.Context, owner, repoName string, number int) error {
+	pr, _, err := r.client.PullRequests.Get(ctx, owner, repoName, number)
+	if err != nil {
+		return fmt.Errorf("unable to get pull request: %w", err)
+	}
+	if pr.AutoMerge == nil || pr.GetState() != "open" {
+		return nil
+	}
+	return disablePullRequestAutoMerge(ctx, r.client, owner, repoName, pr)
+}
+
+// deleteHeadBranch deletes the head branch of a merged pull request. Failures are only
+// logged, as the pull request itself has been merged.
+func (r *repositoryPullRequestAutoMergeResource) deleteHeadBranch(ctx context.Context, owner, repoName, headRef string) {
+	ref := fmt.Sprintf("refs/heads/%s", headRef)
+	_, err := r.client.Git.DeleteRef(ctx, owner, repoName, ref)
+	if err != nil {
+		log.Printf("[WARN] Failed to delete branch %s: %v", headRef, err)
+	} else {
+		log.Printf("[INFO] Deleted branch %s", headRef)
+	}
+}
+
 func (r *repositoryPullRequestAutoMergeResource) mergeWhenReady(ctx context.Context, owner, repoName string, number int, plan *repositoryPullRequestAutoMergeResourceModel, _ *diag.Diagnostics) error {
-	maxAttempts := 30
-	attempt := 0
+	pollInterval := parseDurationOrDefault(plan.PollInterval, defaultPollInterval)
+	deadline := time.Now().Add(mergeableTimeout)
 
-	for attempt < maxAttempts {
+	for {
 		pr, _, err := r.client.PullRequests.Get(ctx, owner, repoName, number)
 		if err != nil {
 			return fmt.Errorf("unable to get pull request: %w", err)
@@ -700,36 +632,36 @@ func (r *repositoryPullRequestAutoMergeResource) mergeWhenReady(ctx context.Cont
 
 		// Check mergeability - GitHub API returns *bool (nil = not computed yet, false = not mergeable, true = mergeable)
 		mergeablePtr := pr.Mergeable
-		if mergeablePtr == nil {
-			log.Printf("[DEBUG] PR mergeability not yet computed, waiting... (attempt %d/%d)", attempt+1, maxAttempts)
-			attempt++
-			time.Sleep(5 * time.Second)
-			continue
-		}
-
-		if !*mergeablePtr {
-			log.Printf("[DEBUG] PR is not mergeable (conflicts or other issues), waiting... (attempt %d/%d)", attempt+1, maxAttempts)
-			attempt++
-			time.Sleep(5 * time.Second)
+		if mergeablePtr == nil || !*mergeablePtr {
+			if time.Now().Add(pollInterval).After(deadline) {
+				return fmt.Errorf("pull request did not become mergeable within %s, its mergeable state is %q", mergeableTimeout, pr.GetMergeableState())
+			}
+			if mergeablePtr == nil {
+				log.Printf("[DEBUG] PR mergeability not yet computed, waiting...")
+			} else {
+				log.Printf("[DEBUG] PR is not mergeable (conflicts or other issues), waiting...")
+			}
+			if err := sleepContext(ctx, pollInterval); err != nil {
+				return err
+			}
 			continue
 		}
 
 		// PR is mergeable, proceed with merge
 		if plan.WaitForChecks.ValueBool() {
-			if err := r.waitForChecks(ctx, owner, repoName, number); err != nil {
-				log.Printf("[DEBUG] Checks not ready, retrying... (attempt %d/%d)", attempt+1, maxAttempts)
-				attempt++
-				time.Sleep(5 * time.Second)
-				continue
+			if err := r.waitForChecks(ctx, owner, repoName, number, plan); err != nil {
+				return err
 			}
 		}
 
-		_, _, err = r.client.PullRequests.CreateReview(ctx, owner, repoName, number, &github.PullRequestReviewRequest{
-			Event: github.String("APPROVE"),
-			Body:  github.String("Auto-approved by Terraform"),
-		})
-		if err != nil {
-			log.Printf("[WARN] Failed to approve PR: %v", err)
+		if err := r.approve(ctx, owner, repoName, pr, plan); err != nil {
+			return err
+		}
+
+		if plan.WaitForApprovals.ValueBool() {
+			if err := r.waitForApprovals(ctx, owner, repoName, number, plan); err != nil {
+				return err
+			}
 		}
 
 		mergeMethod := plan.MergeMethod.ValueString()
@@ -789,8 +721,7 @@ func (r *repositoryPullRequestAutoMergeResource) mergeWhenReady(ctx context.Cont
 		})
 		if err != nil {
 			// If merge fails due to method not allowed, try to find an allowed method
-			var ghErr *github.ErrorResponse
-			if errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusMethodNotAllowed {
+			if isMethodNotAllowed(err) {
 				if repo != nil {
 					// Try alternative methods
 					if mergeMethod != "merge" && repo.GetAllowMergeCommit() {
@@ -819,104 +750,193 @@ func (r *repositoryPullRequestAutoMergeResource) mergeWhenReady(ctx context.Cont
 		log.Printf("[INFO] Successfully merged pull request #%d", number)
 
 		if plan.AutoDeleteBranch.ValueBool() {
-			headRef := plan.HeadRef.ValueString()
-			ref := fmt.Sprintf("refs/heads/%s", headRef)
-			_, err = r.client.Git.DeleteRef(ctx, owner, repoName, ref)
-			if err != nil {
-				log.Printf("[WARN] Failed to delete branch %s: %v", headRef, err)
-			} else {
-				log.Printf("[INFO] Deleted branch %s", headRef)
-			}
+			r.deleteHeadBranch(ctx, owner, repoName, plan.HeadRef.ValueString())
 		}
 
 		return nil
 	}
-
-	return fmt.Errorf("pull request not ready to merge after %d attempts", maxAttempts)
 }
 
-func (r *repositoryPullRequestAutoMergeResource) findExistingPR(ctx context.Context, owner, repoName, baseRef, headRef string) (*github.PullRequest, error) {
-	opts := &github.PullRequestListOptions{
-		State:       "all",
-		Head:        fmt.Sprintf("%s:%s", owner, headRef),
-		Base:        baseRef,
-		ListOptions: github.ListOptions{PerPage: 100},
+// waitForChecks waits for the checks of the head commit of the pull request to pass. These
+// are the required checks if any are configured or required by the protection of the base
+// branch, and all reported check runs, check suites and commit statuses otherwise.
+func (r *repositoryPullRequestAutoMergeResource) waitForChecks(ctx context.Context, owner, repoName string, number int, plan *repositoryPullRequestAutoMergeResourceModel) error {
+	var required []string
+	if !plan.RequiredChecks.IsNull() && !plan.RequiredChecks.IsUnknown() {
+		if diags := plan.RequiredChecks.ElementsAs(ctx, &required, false); diags.HasError() {
+			return fmt.Errorf("unable to read required_checks")
+		}
+	} else {
+		var err error
+		required, err = branchRequiredChecks(ctx, r.client, owner, repoName, plan.BaseRef.ValueString())
+		if err != nil {
+			return err
+		}
 	}
 
-	prs, _, err := r.client.PullRequests.List(ctx, owner, repoName, opts)
-	if err != nil {
-		return nil, err
-	}
+	timeout := parseDurationOrDefault(plan.ChecksTimeout, defaultChecksTimeout)
+	pollInterval := parseDurationOrDefault(plan.PollInterval, defaultPollInterval)
+	deadline := time.Now().Add(timeout)
 
-	for _, pr := range prs {
-		if pr.GetBase().GetRef() == baseRef && pr.GetHead().GetRef() == headRef {
-			return pr, nil
+	for {
+		pr, _, err := r.client.PullRequests.Get(ctx, owner, repoName, number)
+		if err != nil {
+			return fmt.Errorf("unable to get pull request: %w", err)
 		}
-	}
 
-	return nil, nil
-}
+		checks, err := getCommitChecks(ctx, r.client, owner, repoName, pr.GetHead().GetSHA(), required)
+		if err != nil {
+			return err
+		}
+		if len(checks.Failed) > 0 {
+			return fmt.Errorf("checks failed: %s", strings.Join(checks.Failed, ", "))
+		}
+		if len(checks.Pending) == 0 {
+			return nil
+		}
 
-func (r *repositoryPullRequestAutoMergeResource) getBranchSHAs(ctx context.Context, owner, repoName, baseRef, headRef string) (string, string, error) {
-	baseRefFull := fmt.Sprintf("refs/heads/%s", baseRef)
-	headRefFull := fmt.Sprintf("refs/heads/%s", headRef)
+		if time.Now().Add(pollInterval).After(deadline) {
+			return fmt.Errorf("checks did not complete within %s, still waiting for: %s", timeout, strings.Join(checks.Pending, ", "))
+		}
+		log.Printf("[DEBUG] Waiting for checks of pull request #%d: %s", number, strings.Join(checks.Pending, ", "))
+		if err := sleepContext(ctx, pollInterval); err != nil {
+			return err
+		}
+	}
+}
 
-	baseRefObj, _, err := r.client.Git.GetRef(ctx, owner, repoName, baseRefFull)
-	if err != nil {
-		return "", "", fmt.Errorf("unable to get base branch %s: %w", baseRef, err)
+// approve approves the pull request as the approver of the `approve` block, unless the
+// approver has approved its head commit already.
+func (r *repositoryPullRequestAutoMergeResource) approve(ctx context.Context, owner, repoName string, pr *github.PullRequest, plan *repositoryPullRequestAutoMergeResourceModel) error {
+	if plan.Approve.IsNull() || plan.Approve.IsUnknown() {
+		return nil
+	}
+	var config pullRequestApproveModel
+	if diags := plan.Approve.As(ctx, &config, basetypes.ObjectAsOptions{}); diags.HasError() {
+		return fmt.Errorf("unable to read the approve block")
+	}
+	if !config.Enabled.IsNull() && !config.Enabled.ValueBool() {
+		return nil
 	}
 
-	headRefObj, _, err := r.client.Git.GetRef(ctx, owner, repoName, headRefFull)
+	approver := newApproverClient(r.client, config.Token.ValueString())
+	user, _, err := approver.Users.Get(ctx, "")
 	if err != nil {
-		return "", "", fmt.Errorf("unable to get head branch %s: %w", headRef, err)
+		return fmt.Errorf("unable to get the approver: %w", err)
+	}
+	if strings.EqualFold(user.GetLogin(), pr.GetUser().GetLogin()) {
+		return fmt.Errorf("the approver %s is the author of pull request #%d and cannot approve it", user.GetLogin(), pr.GetNumber())
 	}
 
-	baseSHA := baseRefObj.GetObject().GetSHA()
-	headSHA := headRefObj.GetObject().GetSHA()
+	headSHA := pr.GetHead().GetSHA()
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		reviews, resp, err := approver.PullRequests.ListReviews(ctx, owner, repoName, pr.GetNumber(), opts)
+		if err != nil {
+			return fmt.Errorf("unable to list the reviews of pull request #%d: %w", pr.GetNumber(), err)
+		}
+		for _, review := range reviews {
+			if strings.EqualFold(review.GetUser().GetLogin(), user.GetLogin()) && review.GetState() == "APPROVED" && review.GetCommitID() == headSHA {
+				log.Printf("[DEBUG] Pull request #%d is approved by %s already", pr.GetNumber(), user.GetLogin())
+				return nil
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
 
-	return baseSHA, headSHA, nil
+	body := "Approved by Terraform"
+	if !config.Body.IsNull() && !config.Body.IsUnknown() {
+		body = config.Body.ValueString()
+	}
+	review := &github.PullRequestReviewRequest{
+		Event: github.String("APPROVE"),
+		Body:  github.String(body),
+	}
+	if headSHA != "" {
+		review.CommitID = github.String(headSHA)
+	}
+	if _, _, err := approver.PullRequests.CreateReview(ctx, owner, repoName, pr.GetNumber(), review); err != nil {
+		return fmt.Errorf("unable to approve pull request #%d as %s: %w", pr.GetNumber(), user.GetLogin(), err)
+	}
+	log.Printf("[INFO] Approved pull request #%d as %s", pr.GetNumber(), user.GetLogin())
+	return nil
 }
 
-func (r *repositoryPullRequestAutoMergeResource) waitForChecks(ctx context.Context, owner, repoName string, number int) error {
-	maxAttempts := 60
-	attempt := 0
+// waitForApprovals waits for the pull request to have the reviews that its base branch
+// requires, or an approval if it requires none.
+func (r *repositoryPullRequestAutoMergeResource) waitForApprovals(ctx context.Context, owner, repoName string, number int, plan *repositoryPullRequestAutoMergeResourceModel) error {
+	timeout := parseDurationOrDefault(plan.ApprovalsTimeout, defaultApprovalsTimeout)
+	pollInterval := parseDurationOrDefault(plan.PollInterval, defaultPollInterval)
+	deadline := time.Now().Add(timeout)
 
-	for attempt < maxAttempts {
-		pr, _, err := r.client.PullRequests.Get(ctx, owner, repoName, number)
+	for {
+		decision, err := pullRequestReviewDecision(ctx, r.client, owner, repoName, number)
 		if err != nil {
-			return fmt.Errorf("unable to get pull request: %w", err)
+			return err
 		}
-
-		headSHA := pr.GetHead().GetSHA()
-		statuses, _, err := r.client.Repositories.ListStatuses(ctx, owner, repoName, headSHA, nil)
-		if err != nil {
-			return fmt.Errorf("unable to get status checks: %w", err)
+		switch decision {
+		case "APPROVED":
+			return nil
+		case "CHANGES_REQUESTED":
+			return fmt.Errorf("changes were requested in a review of pull request #%d", number)
 		}
 
-		allPassed := true
-		for _, status := range statuses {
-			state := status.GetState()
-			if state == "pending" {
-				allPassed = false
-				break
-			}
-			if state == "error" || state == "failure" {
-				return fmt.Errorf("status check %s failed", status.GetContext())
-			}
+		if time.Now().Add(pollInterval).After(deadline) {
+			return fmt.Errorf("pull request #%d was not approved within %s", number, timeout)
 		}
-
-		if allPassed && len(statuses) > 0 {
-			return nil
+		log.Printf("[DEBUG] Waiting for approvals of pull request #%d", number)
+		if err := sleepContext(ctx, pollInterval); err != nil {
+			return err
 		}
+	}
+}
 
-		if len(statuses) == 0 {
-			log.Printf("[DEBUG] No status checks found, proceeding")
-			return nil
-		}
+// newApproverClient returns a client for the same GitHub instance as client that is
+// authenticated with token. It keeps the HTTP client of the provider, so the
+// TLS, proxy and timeout settings apply to the approver too.
+func newApproverClient(client *github.Client, token string) *github.Client {
+	httpClient := *client.Client()
+	base := httpClient.Transport
+	// The oauth2 transport of the provider would replace the Authorization header
+	if t, ok := base.(*oauth2.Transport); ok {
+		base = t.Base
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	httpClient.Transport = &approverTransport{token: token, base: base}
+
+	approver := github.NewClient(&httpClient)
+	approver.BaseURL = client.BaseURL
+	approver.UploadURL = client.UploadURL
+	approver.UserAgent = client.UserAgent
+	return approver
+}
 
-		attempt++
-		time.Sleep(5 * time.Second)
-	}
+// approverTransport authenticates the requests with the token of the approver.
+type approverTransport struct {
+	token string
+	base  http.RoundTripper
+}
 
-	return fmt.Errorf("status checks did not complete after %d attempts", maxAttempts)
+func (t *approverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return t.base.RoundTrip(req)
+}
+
+// parseDurationOrDefault parses a duration attribute, which is validated by
+// durationValidator, and returns def if it is not set.
+func parseDurationOrDefault(value types.String, def time.Duration) time.Duration {
+	if value.IsNull() || value.IsUnknown() {
+		return def
+	}
+	d, err := time.ParseDuration(value.ValueString())
+	if err != nil || d <= 0 {
+		return def
+	}
+	return d
 }
@@ -1,11 +1,17 @@
 package provider
 
 import (
+	"encoding/json"
+	"net/http"
 	"testing"
 
 	"github.com/google/go-github/v60/github"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
 )
 
 func TestRepositoryPullRequestAutoMergeResource_Metadata(t *testing.T) {
@@ -72,6 +78,17 @@ func TestRepositoryPullRequestAutoMergeResource_Schema(t *testing.T) {
 	assert.True(t, autoDeleteBranchAttr.IsOptional())
 	assert.True(t, autoDeleteBranchAttr.IsComputed())
 
+	for _, name := range []string{"approve", "wait_for_approvals", "approvals_timeout"} {
+		attr, ok := resp.Schema.Attributes[name]
+		assert.True(t, ok, name)
+		assert.True(t, attr.IsOptional(), name)
+	}
+
+	autoMergeAttr, ok := resp.Schema.Attributes["auto_merge"]
+	assert.True(t, ok)
+	assert.True(t, autoMergeAttr.IsOptional())
+	assert.True(t, autoMergeAttr.IsComputed())
+
 	maintainerCanModifyAttr, ok := resp.Schema.Attributes["maintainer_can_modify"]
 	assert.True(t, ok)
 	assert.True(t, maintainerCanModifyAttr.IsOptional())
@@ -109,6 +126,12 @@ func TestRepositoryPullRequestAutoMergeResource_Schema(t *testing.T) {
 	headSHAAttr, ok := resp.Schema.Attributes["head_sha"]
 	assert.True(t, ok)
 	assert.True(t, headSHAAttr.IsComputed())
+
+	for _, name := range []string{"auto_merge_enabled", "auto_merge_method", "mergeable_state"} {
+		attr, ok := resp.Schema.Attributes[name]
+		assert.True(t, ok, name)
+		assert.True(t, attr.IsComputed(), name)
+	}
 }
 
 func TestRepositoryPullRequestAutoMergeResource_Configure(t *testing.T) {
@@ -173,3 +196,496 @@ func TestRepositoryPullRequestAutoMergeResource_Configure(t *testing.T) {
 // should be implemented as acceptance tests with TF_ACC=1 environment variable set.
 // These unit tests verify the schema, metadata, and configuration validation
 // without making API calls.
+
+func TestRepositoryPullRequestAutoMergeResource_AutoMerge(t *testing.T) {
+	fake := &fakePullRequests{}
+	r := &repositoryPullRequestAutoMergeResource{client: newTestGitHubClient(t, fake), owner: "test-owner"}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(t.Context(), resource.SchemaRequest{}, schemaResp)
+
+	model := repositoryPullRequestAutoMergeResourceModel{
+		pullRequestModel: pullRequestModel{
+			Repository:          types.StringValue("test-repo"),
+			BaseRef:             types.StringValue("main"),
+			HeadRef:             types.StringValue("feature"),
+			Title:               types.StringValue("Add feature"),
+			Body:                types.StringNull(),
+			MaintainerCanModify: types.BoolValue(false),
+			BaseSHA:             types.StringUnknown(),
+			HeadSHA:             types.StringUnknown(),
+			Number:              types.Int64Unknown(),
+			State:               types.StringUnknown(),
+			Merged:              types.BoolUnknown(),
+			MergedAt:            types.StringUnknown(),
+			MergeCommitSHA:      types.StringUnknown(),
+			ID:                  types.StringUnknown(),
+		},
+		MergeWhenReady:   types.BoolValue(false),
+		MergeMethod:      types.StringValue("squash"),
+		WaitForChecks:    types.BoolValue(true),
+		AutoDeleteBranch: types.BoolValue(false),
+		Approve:          types.ObjectNull(pullRequestApproveAttrTypes),
+		WaitForApprovals: types.BoolValue(false),
+		ApprovalsTimeout: types.StringValue("30m"),
+		RequiredChecks:   types.SetNull(types.StringType),
+		ChecksTimeout:    types.StringValue("5m"),
+		PollInterval:     types.StringValue("5s"),
+		AutoMerge:        types.BoolValue(true),
+		AutoMergeEnabled: types.BoolUnknown(),
+		AutoMergeMethod:  types.StringUnknown(),
+		MergeableState:   types.StringUnknown(),
+	}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	assert.False(t, plan.Set(t.Context(), &model).HasError())
+
+	// Auto-merge is enabled without waiting for the pull request to be merged
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(t.Context(), resource.CreateRequest{Plan: plan}, createResp)
+	assert.False(t, createResp.Diagnostics.HasError(), "unexpected errors: %v", createResp.Diagnostics)
+	assert.Equal(t, []map[string]interface{}{{"pullRequestId": "PR_node", "mergeMethod": "SQUASH"}}, fake.graphQLInputs)
+	assert.Empty(t, fake.merged)
+
+	var state repositoryPullRequestAutoMergeResourceModel
+	assert.False(t, createResp.State.Get(t.Context(), &state).HasError())
+	assert.Equal(t, "test-repo:1", state.ID.ValueString())
+	assert.True(t, state.AutoMergeEnabled.ValueBool())
+	assert.Equal(t, "squash", state.AutoMergeMethod.ValueString())
+	assert.True(t, state.Body.IsNull())
+
+	// Turning auto_merge off disables it again
+	model.AutoMerge = types.BoolValue(false)
+	assert.False(t, plan.Set(t.Context(), &model).HasError())
+	updateResp := &resource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Update(t.Context(), resource.UpdateRequest{Plan: plan, State: createResp.State}, updateResp)
+	assert.False(t, updateResp.Diagnostics.HasError(), "unexpected errors: %v", updateResp.Diagnostics)
+	assert.Len(t, fake.graphQLInputs, 2)
+	assert.Equal(t, map[string]interface{}{"pullRequestId": "PR_node"}, fake.graphQLInputs[1])
+
+	assert.False(t, updateResp.State.Get(t.Context(), &state).HasError())
+	assert.False(t, state.AutoMergeEnabled.ValueBool())
+	assert.True(t, state.AutoMergeMethod.IsNull())
+}
+
+func TestRepositoryPullRequestAutoMergeResource_ModifyPlan(t *testing.T) {
+	r := &repositoryPullRequestAutoMergeResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(t.Context(), resource.SchemaRequest{}, schemaResp)
+
+	stateModel := repositoryPullRequestAutoMergeResourceModel{
+		pullRequestModel: pullRequestModel{
+			Repository:          types.StringValue("test-repo"),
+			BaseRef:             types.StringValue("main"),
+			HeadRef:             types.StringValue("feature"),
+			Title:               types.StringValue("Add feature"),
+			Body:                types.StringNull(),
+			MaintainerCanModify: types.BoolValue(false),
+			BaseSHA:             types.StringValue("base-sha"),
+			HeadSHA:             types.StringValue("head-sha"),
+			Number:              types.Int64Value(1),
+			State:               types.StringValue("open"),
+			Merged:              types.BoolValue(false),
+			MergedAt:            types.StringNull(),
+			MergeCommitSHA:      types.StringNull(),
+			ID:                  types.StringValue("test-repo:1"),
+		},
+		MergeWhenReady:   types.BoolValue(false),
+		MergeMethod:      types.StringValue("merge"),
+		WaitForChecks:    types.BoolValue(true),
+		AutoDeleteBranch: types.BoolValue(false),
+		Approve:          types.ObjectNull(pullRequestApproveAttrTypes),
+		WaitForApprovals: types.BoolValue(false),
+		ApprovalsTimeout: types.StringValue("30m"),
+		RequiredChecks:   types.SetNull(types.StringType),
+		ChecksTimeout:    types.StringValue("5m"),
+		PollInterval:     types.StringValue("5s"),
+		AutoMerge:        types.BoolValue(false),
+		AutoMergeEnabled: types.BoolValue(false),
+		AutoMergeMethod:  types.StringNull(),
+		MergeableState:   types.StringValue("clean"),
+	}
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	assert.False(t, state.Set(t.Context(), &stateModel).HasError())
+
+	modifyPlan := func(change func(*repositoryPullRequestAutoMergeResourceModel)) repositoryPullRequestAutoMergeResourceModel {
+		// UseStateForUnknown has planned the prior values of the computed attributes
+		planModel := stateModel
+		change(&planModel)
+		plan := tfsdk.Plan{Schema: schemaResp.Schema}
+		assert.False(t, plan.Set(t.Context(), &planModel).HasError())
+
+		resp := &resource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(t.Context(), resource.ModifyPlanRequest{Plan: plan, State: state}, resp)
+		assert.False(t, resp.Diagnostics.HasError(), "unexpected errors: %v", resp.Diagnostics)
+
+		var result repositoryPullRequestAutoMergeResourceModel
+		assert.False(t, resp.Plan.Get(t.Context(), &result).HasError())
+		return result
+	}
+
+	// Changing the title keeps the computed values
+	result := modifyPlan(func(m *repositoryPullRequestAutoMergeResourceModel) {
+		m.Title = types.StringValue("Add a feature")
+	})
+	assert.Equal(t, types.StringValue("base-sha"), result.BaseSHA)
+	assert.Equal(t, types.StringValue("open"), result.State)
+	assert.Equal(t, types.BoolValue(false), result.Merged)
+
+	// Changing the base branch changes the base SHA
+	result = modifyPlan(func(m *repositoryPullRequestAutoMergeResourceModel) {
+		m.BaseRef = types.StringValue("develop")
+	})
+	assert.True(t, result.BaseSHA.IsUnknown())
+	assert.Equal(t, types.StringValue("head-sha"), result.HeadSHA)
+	assert.Equal(t, types.StringValue("open"), result.State)
+
+	// Setting up merging may merge the pull request
+	result = modifyPlan(func(m *repositoryPullRequestAutoMergeResourceModel) {
+		m.MergeWhenReady = types.BoolValue(true)
+	})
+	assert.Equal(t, types.StringValue("base-sha"), result.BaseSHA)
+	assert.True(t, result.State.IsUnknown())
+	assert.True(t, result.Merged.IsUnknown())
+	assert.True(t, result.MergedAt.IsUnknown())
+	assert.True(t, result.MergeCommitSHA.IsUnknown())
+}
+
+func TestRepositoryPullRequestAutoMergeResource_WaitForChecks(t *testing.T) {
+	tests := []struct {
+		name          string
+		checksTimeout string
+		completeAfter int
+		expectError   string
+	}{
+		{
+			name:          "passes once the required check completes",
+			checksTimeout: "1m",
+			completeAfter: 2,
+		},
+		{
+			name:          "times out while the required check is pending",
+			checksTimeout: "20ms",
+			completeAfter: -1,
+			expectError:   "still waiting for: build",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var polls int
+			client := newTestGitHubClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case "/repos/test-owner/test-repo/pulls/1":
+					_ = json.NewEncoder(w).Encode(map[string]interface{}{"number": 1, "head": map[string]string{"sha": "head-sha"}})
+				case "/repos/test-owner/test-repo/commits/head-sha/check-runs":
+					polls++
+					status := "in_progress"
+					if tt.completeAfter > 0 && polls >= tt.completeAfter {
+						status = "completed"
+					}
+					_ = json.NewEncoder(w).Encode(map[string]interface{}{
+						"total_count": 2,
+						"check_runs": []map[string]interface{}{
+							{"name": "build", "status": status, "conclusion": "success"},
+							{"name": "optional", "status": "completed", "conclusion": "failure"},
+						},
+					})
+				case "/repos/test-owner/test-repo/commits/head-sha/status":
+					_ = json.NewEncoder(w).Encode(map[string]interface{}{"state": "success", "statuses": []interface{}{}})
+				default:
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+				}
+			}))
+			r := &repositoryPullRequestAutoMergeResource{client: client, owner: "test-owner"}
+
+			requiredChecks, diags := types.SetValueFrom(t.Context(), types.StringType, []string{"build"})
+			assert.False(t, diags.HasError())
+			plan := &repositoryPullRequestAutoMergeResourceModel{
+				pullRequestModel: pullRequestModel{BaseRef: types.StringValue("main")},
+				RequiredChecks:   requiredChecks,
+				ChecksTimeout:    types.StringValue(tt.checksTimeout),
+				PollInterval:     types.StringValue("1ms"),
+			}
+
+			err := r.waitForChecks(t.Context(), "test-owner", "test-repo", 1, plan)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.completeAfter, polls)
+			}
+		})
+	}
+}
+
+func TestRepositoryPullRequestAutoMergeResource_MergeWhenReady_NotMergeable(t *testing.T) {
+	var polls int
+	client := newTestGitHubClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/repos/test-owner/test-repo/pulls/1":
+			polls++
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"number": 1, "state": "open", "mergeable": false, "mergeable_state": "dirty"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+		}
+	}))
+	r := &repositoryPullRequestAutoMergeResource{client: client, owner: "test-owner"}
+
+	// Polling again would pass the deadline, so it gives up without waiting
+	plan := &repositoryPullRequestAutoMergeResourceModel{PollInterval: types.StringValue("10m")}
+	err := r.mergeWhenReady(t.Context(), "test-owner", "test-repo", 1, plan, nil)
+	assert.ErrorContains(t, err, `did not become mergeable within 5m0s, its mergeable state is "dirty"`)
+	assert.Equal(t, 1, polls)
+}
+
+// pullRequestApproveAttrTypes are the attribute types of the `approve` block.
+var pullRequestApproveAttrTypes = map[string]attr.Type{
+	"enabled": types.BoolType,
+	"body":    types.StringType,
+	"token":   types.StringType,
+}
+
+func TestRepositoryPullRequestAutoMergeResource_MergeWhenReady(t *testing.T) {
+	tests := []struct {
+		name              string
+		approve           types.Object
+		expectedReviewers []string
+		approvedOnPage2   bool
+		expectError       string
+	}{
+		{
+			name:    "does not approve by default",
+			approve: types.ObjectNull(pullRequestApproveAttrTypes),
+		},
+		{
+			name: "approves with the approver token",
+			approve: types.ObjectValueMust(pullRequestApproveAttrTypes, map[string]attr.Value{
+				"enabled": types.BoolNull(),
+				"body":    types.StringValue("LGTM"),
+				"token":   types.StringValue("approver-token"),
+			}),
+			expectedReviewers: []string{"Bearer approver-token"},
+		},
+		{
+			name: "approved already on a later page of reviews",
+			approve: types.ObjectValueMust(pullRequestApproveAttrTypes, map[string]attr.Value{
+				"enabled": types.BoolValue(true),
+				"body":    types.StringNull(),
+				"token":   types.StringValue("approver-token"),
+			}),
+			approvedOnPage2: true,
+		},
+		{
+			name: "disabled approval",
+			approve: types.ObjectValueMust(pullRequestApproveAttrTypes, map[string]attr.Value{
+				"enabled": types.BoolValue(false),
+				"body":    types.StringNull(),
+				"token":   types.StringValue("approver-token"),
+			}),
+		},
+		{
+			name: "author cannot approve",
+			approve: types.ObjectValueMust(pullRequestApproveAttrTypes, map[string]attr.Value{
+				"enabled": types.BoolValue(true),
+				"body":    types.StringNull(),
+				"token":   types.StringValue("author-token"),
+			}),
+			expectError: "is the author of pull request #1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reviewers []string
+			var reviewBody string
+			var merged bool
+			client := newTestGitHubClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case r.URL.Path == "/user":
+					login := "approver"
+					if r.Header.Get("Authorization") == "Bearer author-token" {
+						login = "author"
+					}
+					_ = json.NewEncoder(w).Encode(map[string]string{"login": login})
+				case r.URL.Path == "/repos/test-owner/test-repo/pulls/1":
+					_ = json.NewEncoder(w).Encode(map[string]interface{}{
+						"number":    1,
+						"state":     "open",
+						"mergeable": true,
+						"user":      map[string]string{"login": "author"},
+						"head":      map[string]string{"sha": "head-sha"},
+					})
+				case r.URL.Path == "/repos/test-owner/test-repo/pulls/1/reviews" && r.Method == http.MethodGet:
+					if !tt.approvedOnPage2 {
+						_ = json.NewEncoder(w).Encode([]interface{}{})
+						return
+					}
+					if r.URL.Query().Get("page") != "2" {
+						w.Header().Set("Link", `<`+r.URL.Path+`?page=2>; rel="next"`)
+						_ = json.NewEncoder(w).Encode([]interface{}{
+							map[string]interface{}{"user": map[string]string{"login": "someone"}, "state": "APPROVED", "commit_id": "head-sha"},
+						})
+						return
+					}
+					_ = json.NewEncoder(w).Encode([]interface{}{
+						map[string]interface{}{"user": map[string]string{"login": "approver"}, "state": "APPROVED", "commit_id": "head-sha"},
+					})
+				case r.URL.Path == "/repos/test-owner/test-repo/pulls/1/reviews" && r.Method == http.MethodPost:
+					var body map[string]string
+					_ = json.NewDecoder(r.Body).Decode(&body)
+					reviewers = append(reviewers, r.Header.Get("Authorization"))
+					reviewBody = body["body"]
+					_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 1, "state": "APPROVED"})
+				case r.URL.Path == "/repos/test-owner/test-repo":
+					_ = json.NewEncoder(w).Encode(map[string]interface{}{"name": "test-repo", "allow_merge_commit": true})
+				case r.URL.Path == "/repos/test-owner/test-repo/pulls/1/merge":
+					merged = true
+					_ = json.NewEncoder(w).Encode(map[string]interface{}{"merged": true})
+				default:
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+				}
+			}))
+			r := &repositoryPullRequestAutoMergeResource{client: client, owner: "test-owner"}
+
+			plan := &repositoryPullRequestAutoMergeResourceModel{
+				MergeWhenReady:   types.BoolValue(true),
+				MergeMethod:      types.StringValue("merge"),
+				WaitForChecks:    types.BoolValue(false),
+				AutoDeleteBranch: types.BoolValue(false),
+				Approve:          tt.approve,
+				WaitForApprovals: types.BoolValue(false),
+			}
+
+			err := r.mergeWhenReady(t.Context(), "test-owner", "test-repo", 1, plan, nil)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				assert.False(t, merged)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, merged)
+			assert.Equal(t, tt.expectedReviewers, reviewers)
+			if len(tt.expectedReviewers) > 0 {
+				assert.Equal(t, "LGTM", reviewBody)
+			}
+		})
+	}
+}
+
+func TestNewApproverClient(t *testing.T) {
+	var authorization, userAgent string
+	client := newTestGitHubClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		userAgent = r.Header.Get("User-Agent")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{"login": "approver"})
+	}))
+
+	// Set up the client the way the provider does, with the provider token in an oauth2 transport
+	var roundTrips int
+	base := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		roundTrips++
+		return http.DefaultTransport.RoundTrip(req)
+	})
+	provider := github.NewClient(&http.Client{
+		Transport: &oauth2.Transport{
+			Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "provider-token"}),
+			Base:   base,
+		},
+	})
+	provider.BaseURL = client.BaseURL
+	provider.UploadURL = client.UploadURL
+	provider.UserAgent = "terraform-provider-githubx/test"
+
+	approver := newApproverClient(provider, "approver-token")
+	user, _, err := approver.Users.Get(t.Context(), "")
+	assert.NoError(t, err)
+	assert.Equal(t, "approver", user.GetLogin())
+	assert.Equal(t, "Bearer approver-token", authorization)
+	assert.Equal(t, "terraform-provider-githubx/test", userAgent)
+	assert.Equal(t, 1, roundTrips)
+}
+
+// roundTripperFunc adapts a function to an http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRepositoryPullRequestAutoMergeResource_WaitForApprovals(t *testing.T) {
+	tests := []struct {
+		name        string
+		decisions   []interface{}
+		reviews     []map[string]interface{}
+		expectError string
+	}{
+		{
+			name:      "approved once required reviews are in",
+			decisions: []interface{}{"REVIEW_REQUIRED", "APPROVED"},
+		},
+		{
+			name:        "changes requested",
+			decisions:   []interface{}{"CHANGES_REQUESTED"},
+			expectError: "changes were requested",
+		},
+		{
+			name:      "no required reviews",
+			decisions: []interface{}{nil},
+			reviews: []map[string]interface{}{
+				{"user": map[string]string{"login": "alice"}, "state": "CHANGES_REQUESTED"},
+				{"user": map[string]string{"login": "bob"}, "state": "COMMENTED"},
+				{"user": map[string]string{"login": "alice"}, "state": "APPROVED"},
+			},
+		},
+		{
+			name:        "times out",
+			decisions:   []interface{}{"REVIEW_REQUIRED"},
+			expectError: "was not approved within 20ms",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var polls int
+			client := newTestGitHubClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case "/graphql":
+					decision := tt.decisions[len(tt.decisions)-1]
+					if polls < len(tt.decisions) {
+						decision = tt.decisions[polls]
+					}
+					polls++
+					_ = json.NewEncoder(w).Encode(map[string]interface{}{
+						"data": map[string]interface{}{"repository": map[string]interface{}{"pullRequest": map[string]interface{}{"reviewDecision": decision}}},
+					})
+				case "/repos/test-owner/test-repo/pulls/1/reviews":
+					_ = json.NewEncoder(w).Encode(tt.reviews)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+				}
+			}))
+			r := &repositoryPullRequestAutoMergeResource{client: client, owner: "test-owner"}
+
+			plan := &repositoryPullRequestAutoMergeResourceModel{
+				ApprovalsTimeout: types.StringValue("20ms"),
+				PollInterval:     types.StringValue("1ms"),
+			}
+
+			err := r.waitForApprovals(t.Context(), "test-owner", "test-repo", 1, plan)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,289 @@
+package provider
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepositoryPullRequestResource_Metadata(t *testing.T) {
+	r := NewRepositoryPullRequestResource()
+	req := resource.MetadataRequest{
+		ProviderTypeName: "githubx",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(t.Context(), req, resp)
+
+	assert.Equal(t, "githubx_repository_pull_request", resp.TypeName)
+}
+
+func TestRepositoryPullRequestResource_Schema(t *testing.T) {
+	r := NewRepositoryPullRequestResource()
+	req := resource.SchemaRequest{}
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(t.Context(), req, resp)
+
+	assert.NotNil(t, resp.Schema)
+	assert.Contains(t, resp.Schema.Description, "Creates and manages a GitHub pull request")
+
+	// Check required attributes
+	for _, name := range []string{"repository", "base_ref", "head_ref", "title"} {
+		attr, ok := resp.Schema.Attributes[name]
+		assert.True(t, ok, name)
+		assert.True(t, attr.IsRequired(), name)
+	}
+
+	// Check optional attributes
+	for _, name := range []string{"body", "draft", "reviewers", "team_reviewers", "assignees", "labels", "milestone", "close_on_destroy"} {
+		attr, ok := resp.Schema.Attributes[name]
+		assert.True(t, ok, name)
+		assert.True(t, attr.IsOptional(), name)
+	}
+
+	// Merging is left to the auto-merge resource
+	for _, name := range []string{"merge_when_ready", "merge_method", "wait_for_checks", "auto_delete_branch"} {
+		_, ok := resp.Schema.Attributes[name]
+		assert.False(t, ok, name)
+	}
+}
+
+func TestRepositoryPullRequestResource_Configure(t *testing.T) {
+	tests := []struct {
+		name          string
+		providerData  interface{}
+		expectError   bool
+		errorContains string
+	}{
+		{
+			name: "valid githubxClientData",
+			providerData: githubxClientData{
+				Client: github.NewClient(nil),
+				Owner:  "test-owner",
+			},
+			expectError: false,
+		},
+		{
+			name:          "invalid provider data type",
+			providerData:  "invalid",
+			expectError:   true,
+			errorContains: "Unexpected Resource Configure Type",
+		},
+		{
+			name:         "nil provider data",
+			providerData: nil,
+			expectError:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := &repositoryPullRequestResource{}
+			req := resource.ConfigureRequest{
+				ProviderData: tt.providerData,
+			}
+			resp := &resource.ConfigureResponse{}
+
+			rs.Configure(t.Context(), req, resp)
+
+			if tt.expectError {
+				assert.True(t, resp.Diagnostics.HasError())
+				if tt.errorContains != "" {
+					assert.Contains(t, resp.Diagnostics.Errors()[0].Summary(), tt.errorContains)
+				}
+			} else {
+				assert.False(t, resp.Diagnostics.HasError())
+				if tt.providerData != nil {
+					clientData, ok := tt.providerData.(githubxClientData)
+					if ok {
+						assert.Equal(t, clientData.Client, rs.client)
+						assert.Equal(t, clientData.Owner, rs.owner)
+					}
+				}
+			}
+		})
+	}
+}
+
+// fakePullRequestDetails is a fake GitHub API for pull request #1 of test-owner/test-repo
+// and its reviewers, assignees, labels and milestone.
+type fakePullRequestDetails struct {
+	mu       sync.Mutex
+	pr       *github.PullRequest
+	reviews  []*github.PullRequestReview
+	mutation string
+}
+
+func (f *fakePullRequestDetails) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	writeJSON := func(status int, v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(v)
+	}
+	var body struct {
+		Assignees     []string `json:"assignees"`
+		Reviewers     []string `json:"reviewers"`
+		TeamReviewers []string `json:"team_reviewers"`
+		Milestone     *int     `json:"milestone"`
+		Query         string   `json:"query"`
+	}
+	raw, _ := io.ReadAll(r.Body)
+	_ = json.Unmarshal(raw, &body)
+
+	switch {
+	case r.URL.Path == "/repos/test-owner/test-repo/pulls" && r.Method == http.MethodGet:
+		writeJSON(http.StatusOK, []*github.PullRequest{f.pr})
+	case r.URL.Path == "/repos/test-owner/test-repo/pulls/1" && r.Method == http.MethodGet:
+		writeJSON(http.StatusOK, f.pr)
+	case r.URL.Path == "/repos/test-owner/test-repo/pulls/1/reviews":
+		writeJSON(http.StatusOK, f.reviews)
+	case r.URL.Path == "/repos/test-owner/test-repo/issues/1/labels" && r.Method == http.MethodPost:
+		var labels []string
+		_ = json.Unmarshal(raw, &labels)
+		for _, name := range labels {
+			f.pr.Labels = append(f.pr.Labels, &github.Label{Name: github.String(name)})
+		}
+		writeJSON(http.StatusOK, f.pr.Labels)
+	case strings.HasPrefix(r.URL.Path, "/repos/test-owner/test-repo/issues/1/labels/") && r.Method == http.MethodDelete:
+		name := strings.TrimPrefix(r.URL.Path, "/repos/test-owner/test-repo/issues/1/labels/")
+		var labels []*github.Label
+		for _, label := range f.pr.Labels {
+			if label.GetName() != name {
+				labels = append(labels, label)
+			}
+		}
+		f.pr.Labels = labels
+		w.WriteHeader(http.StatusNoContent)
+	case r.URL.Path == "/repos/test-owner/test-repo/issues/1/assignees" && r.Method == http.MethodPost:
+		for _, login := range body.Assignees {
+			f.pr.Assignees = append(f.pr.Assignees, &github.User{Login: github.String(login)})
+		}
+		writeJSON(http.StatusCreated, f.pr)
+	case r.URL.Path == "/repos/test-owner/test-repo/issues/1/assignees" && r.Method == http.MethodDelete:
+		var assignees []*github.User
+		for _, user := range f.pr.Assignees {
+			if !containsFold(body.Assignees, user.GetLogin()) {
+				assignees = append(assignees, user)
+			}
+		}
+		f.pr.Assignees = assignees
+		writeJSON(http.StatusOK, f.pr)
+	case r.URL.Path == "/repos/test-owner/test-repo/pulls/1/requested_reviewers" && r.Method == http.MethodPost:
+		for _, login := range body.Reviewers {
+			f.pr.RequestedReviewers = append(f.pr.RequestedReviewers, &github.User{Login: github.String(login)})
+		}
+		for _, slug := range body.TeamReviewers {
+			f.pr.RequestedTeams = append(f.pr.RequestedTeams, &github.Team{Slug: github.String(slug)})
+		}
+		writeJSON(http.StatusCreated, f.pr)
+	case r.URL.Path == "/repos/test-owner/test-repo/pulls/1/requested_reviewers" && r.Method == http.MethodDelete:
+		var reviewers []*github.User
+		for _, user := range f.pr.RequestedReviewers {
+			if !containsFold(body.Reviewers, user.GetLogin()) {
+				reviewers = append(reviewers, user)
+			}
+		}
+		f.pr.RequestedReviewers = reviewers
+		writeJSON(http.StatusOK, f.pr)
+	case r.URL.Path == "/repos/test-owner/test-repo/issues/1" && r.Method == http.MethodPatch:
+		f.pr.Milestone = &github.Milestone{Number: body.Milestone}
+		writeJSON(http.StatusOK, f.pr)
+	case r.URL.Path == "/graphql" && r.Method == http.MethodPost:
+		f.mutation = body.Query
+		f.pr.Draft = github.Bool(strings.Contains(body.Query, "convertPullRequestToDraft"))
+		writeJSON(http.StatusOK, map[string]interface{}{"data": map[string]interface{}{}})
+	default:
+		writeJSON(http.StatusNotFound, map[string]string{"message": "Not Found"})
+	}
+}
+
+func TestRepositoryPullRequestResource_Create(t *testing.T) {
+	// An existing pull request is adopted, and its details are brought in line with the plan
+	fake := &fakePullRequestDetails{
+		pr: &github.PullRequest{
+			Number:             github.Int(1),
+			NodeID:             github.String("PR_node"),
+			State:              github.String("open"),
+			Title:              github.String("Add feature"),
+			Head:               &github.PullRequestBranch{Ref: github.String("feature"), SHA: github.String("head-sha")},
+			Base:               &github.PullRequestBranch{Ref: github.String("main"), SHA: github.String("base-sha")},
+			Labels:             []*github.Label{{Name: github.String("bug")}},
+			Assignees:          []*github.User{{Login: github.String("alice")}},
+			RequestedReviewers: []*github.User{{Login: github.String("bob")}},
+		},
+		reviews: []*github.PullRequestReview{{User: &github.User{Login: github.String("erin")}, State: github.String("APPROVED")}},
+	}
+	r := &repositoryPullRequestResource{client: newTestGitHubClient(t, fake), owner: "test-owner"}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(t.Context(), resource.SchemaRequest{}, schemaResp)
+
+	stringSet := func(values ...string) types.Set {
+		set, diags := types.SetValueFrom(t.Context(), types.StringType, values)
+		assert.False(t, diags.HasError())
+		return set
+	}
+	model := repositoryPullRequestResourceModel{
+		pullRequestModel: pullRequestModel{
+			Repository:          types.StringValue("test-repo"),
+			BaseRef:             types.StringValue("main"),
+			HeadRef:             types.StringValue("feature"),
+			Title:               types.StringValue("Add feature"),
+			Body:                types.StringNull(),
+			MaintainerCanModify: types.BoolValue(false),
+			BaseSHA:             types.StringUnknown(),
+			HeadSHA:             types.StringUnknown(),
+			Number:              types.Int64Unknown(),
+			State:               types.StringUnknown(),
+			Merged:              types.BoolUnknown(),
+			MergedAt:            types.StringUnknown(),
+			MergeCommitSHA:      types.StringUnknown(),
+			ID:                  types.StringUnknown(),
+		},
+		Draft:          types.BoolValue(true),
+		Reviewers:      stringSet("bob", "dave", "Erin"),
+		TeamReviewers:  types.SetNull(types.StringType),
+		Assignees:      stringSet("Alice", "carol"),
+		Labels:         stringSet("enhancement"),
+		Milestone:      types.Int64Value(3),
+		CloseOnDestroy: types.BoolValue(true),
+	}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	assert.False(t, plan.Set(t.Context(), &model).HasError())
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(t.Context(), resource.CreateRequest{Plan: plan}, resp)
+	assert.False(t, resp.Diagnostics.HasError(), "unexpected errors: %v", resp.Diagnostics)
+
+	assert.Contains(t, fake.mutation, "convertPullRequestToDraft")
+
+	var state repositoryPullRequestResourceModel
+	assert.False(t, resp.State.Get(t.Context(), &state).HasError())
+	elements := func(set types.Set) []string {
+		var values []string
+		assert.False(t, set.ElementsAs(t.Context(), &values, false).HasError())
+		return values
+	}
+	assert.Equal(t, "test-repo:1", state.ID.ValueString())
+	assert.Equal(t, int64(1), state.Number.ValueInt64())
+	assert.True(t, state.Body.IsNull())
+	assert.True(t, state.Draft.ValueBool())
+	assert.Equal(t, int64(3), state.Milestone.ValueInt64())
+	assert.ElementsMatch(t, []string{"enhancement"}, elements(state.Labels))
+	assert.ElementsMatch(t, []string{"Alice", "carol"}, elements(state.Assignees))
+	assert.ElementsMatch(t, []string{"bob", "dave", "Erin"}, elements(state.Reviewers))
+	assert.True(t, state.TeamReviewers.IsNull())
+}
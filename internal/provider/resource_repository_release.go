@@ -0,0 +1,861 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &repositoryReleaseResource{}
+	_ resource.ResourceWithConfigure   = &repositoryReleaseResource{}
+	_ resource.ResourceWithImportState = &repositoryReleaseResource{}
+	_ resource.ResourceWithModifyPlan  = &repositoryReleaseResource{}
+)
+
+// NewRepositoryReleaseResource is a helper function to simplify the provider implementation.
+func NewRepositoryReleaseResource() resource.Resource {
+	return &repositoryReleaseResource{}
+}
+
+// repositoryReleaseResource is the resource implementation.
+type repositoryReleaseResource struct {
+	client *github.Client
+	owner  string
+}
+
+// repositoryReleaseResourceModel maps the resource schema data.
+type repositoryReleaseResourceModel struct {
+	Repository           types.String `tfsdk:"repository"`
+	Tag                  types.String `tfsdk:"tag"`
+	TargetCommitish      types.String `tfsdk:"target_commitish"`
+	Name                 types.String `tfsdk:"name"`
+	Body                 types.String `tfsdk:"body"`
+	Draft                types.Bool   `tfsdk:"draft"`
+	Prerelease           types.Bool   `tfsdk:"prerelease"`
+	GenerateReleaseNotes types.Bool   `tfsdk:"generate_release_notes"`
+	MakeLatest           types.String `tfsdk:"make_latest"`
+	Assets               types.Map    `tfsdk:"assets"`
+	AssetSHA256          types.Map    `tfsdk:"asset_sha256"`
+	AssetIDs             types.Map    `tfsdk:"asset_ids"`
+	ReleaseID            types.Int64  `tfsdk:"release_id"`
+	HTMLURL              types.String `tfsdk:"html_url"`
+	ID                   types.String `tfsdk:"id"`
+}
+
+// Metadata returns the resource type name.
+func (r *repositoryReleaseResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repository_release"
+}
+
+// Schema defines the schema for the resource.
+func (r *repositoryReleaseResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Creates and manages a GitHub release, including its assets. The tag is created if it does not exist.",
+		Attributes: map[string]schema.Attribute{
+			"repository": schema.StringAttribute{
+				Description: "The GitHub repository name.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tag": schema.StringAttribute{
+				Description: "The name of the tag of the release. If the tag does not exist, it is created at 'target_commitish'. Tags created for a release are kept when the release is deleted.",
+				Required:    true,
+			},
+			"target_commitish": schema.StringAttribute{
+				Description: "The branch or commit SHA that the tag is created at, if it does not exist. Defaults to the repository's default branch.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the release.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"body": schema.StringAttribute{
+				Description: "The description of the release. If not set and `generate_release_notes` is set, this is the generated release notes.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"draft": schema.BoolAttribute{
+				Description: "Whether the release is a draft. Defaults to \"false\".",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"prerelease": schema.BoolAttribute{
+				Description: "Whether the release is a prerelease. Defaults to \"false\".",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"generate_release_notes": schema.BoolAttribute{
+				Description: "Generate the name and body of the release from the changes since the previous release, when it is created. A `name` or `body` that is set is used instead. Defaults to \"false\".",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"make_latest": schema.StringAttribute{
+				Description: "Whether the release is set as the latest release of the repository: 'true', 'false', or 'legacy' to use the date and version of the release. Defaults to 'true' for releases that are not drafts or prereleases.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("true", "false", "legacy"),
+				},
+			},
+			"assets": schema.MapAttribute{
+				Description: "A map of asset names to paths of local files to upload as release assets. Assets are uploaded again when the SHA-256 of the local file changes, or when the asset was deleted or replaced in GitHub.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"asset_sha256": schema.MapAttribute{
+				Description: "A map of asset names to the SHA-256 of the local file that was uploaded.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"asset_ids": schema.MapAttribute{
+				Description: "A map of asset names to the IDs of the uploaded assets.",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+			"release_id": schema.Int64Attribute{
+				Description: "The ID of the release.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"html_url": schema.StringAttribute{
+				Description: "The URL of the release page.",
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "The Terraform state ID (repository:tag).",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure enables provider-level data or clients to be set in the
+// provider-defined resource type.
+func (r *repositoryReleaseResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(githubxClientData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected githubxClientData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = clientData.Client
+	r.owner = clientData.Owner
+}
+
+// ModifyPlan hashes the local asset files so that the plan shows which assets will be uploaded.
+func (r *repositoryReleaseResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan repositoryReleaseResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state *repositoryReleaseResourceModel
+	if !req.State.Raw.IsNull() {
+		state = &repositoryReleaseResourceModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !plan.Tag.Equal(state.Tag) {
+			plan.ID = types.StringUnknown()
+		}
+	}
+
+	switch {
+	case plan.Assets.IsUnknown():
+		plan.AssetSHA256 = types.MapUnknown(types.StringType)
+		plan.AssetIDs = types.MapUnknown(types.Int64Type)
+	case plan.Assets.IsNull():
+		plan.AssetSHA256 = types.MapNull(types.StringType)
+		plan.AssetIDs = types.MapNull(types.Int64Type)
+	default:
+		// The files may be built by another resource during apply, so a file that cannot
+		// be read yet leaves the hashes unknown. syncAssets reports it if it is still missing.
+		var hashDiags diag.Diagnostics
+		hashes := r.assetHashes(ctx, &plan, &hashDiags)
+		if hashDiags.HasError() {
+			log.Printf("[DEBUG] Unable to hash the assets of release %s at plan time: %v", plan.Tag.ValueString(), hashDiags)
+			plan.AssetSHA256 = types.MapUnknown(types.StringType)
+			plan.AssetIDs = types.MapUnknown(types.Int64Type)
+			break
+		}
+		hashesMap, diags := types.MapValueFrom(ctx, types.StringType, hashes)
+		resp.Diagnostics.Append(diags...)
+		plan.AssetSHA256 = hashesMap
+		if state != nil && hashesMap.Equal(state.AssetSHA256) {
+			plan.AssetIDs = state.AssetIDs
+		} else {
+			plan.AssetIDs = types.MapUnknown(types.Int64Type)
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *repositoryReleaseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan repositoryReleaseResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			"GitHub client is not configured. Please ensure a token is provided.",
+		)
+		return
+	}
+
+	// Get owner, falling back to authenticated user if not set
+	owner, err := r.getOwner(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Missing Owner",
+			fmt.Sprintf("Unable to determine owner: %v. Please set provider-level `owner` configuration or ensure authentication is working.", err),
+		)
+		return
+	}
+
+	repoName := plan.Repository.ValueString()
+	tagName := plan.Tag.ValueString()
+
+	if plan.TargetCommitish.IsNull() || plan.TargetCommitish.IsUnknown() || plan.TargetCommitish.ValueString() == "" {
+		repo, _, err := r.client.Repositories.Get(ctx, owner, repoName)
+		if err != nil {
+			addGitHubError(&resp.Diagnostics,
+				"Error reading repository",
+				fmt.Sprintf("Unable to read repository %s/%s to determine the default branch: %v", owner, repoName, err),
+				err,
+			)
+			return
+		}
+		plan.TargetCommitish = types.StringValue(repo.GetDefaultBranch())
+	}
+
+	r.ensureReleaseTag(ctx, owner, repoName, tagName, plan.TargetCommitish.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create the release
+	release := r.releaseFromModel(&plan)
+	release.GenerateReleaseNotes = github.Bool(plan.GenerateReleaseNotes.ValueBool())
+	created, _, err := r.client.Repositories.CreateRelease(ctx, owner, repoName, release)
+	if err != nil {
+		addGitHubError(&resp.Diagnostics,
+			"Error creating release",
+			fmt.Sprintf("Unable to create release %s in %s/%s: %v", tagName, owner, repoName, err),
+			err,
+		)
+		return
+	}
+	log.Printf("[INFO] Created release %d (%s) in %s/%s", created.GetID(), tagName, owner, repoName)
+
+	plan.ReleaseID = types.Int64Value(created.GetID())
+	plan.ID = types.StringValue(buildTwoPartID(repoName, tagName))
+
+	// Upload the assets. The release is saved even if an upload fails, so that it is
+	// tainted and replaced rather than left behind.
+	r.syncAssets(ctx, owner, repoName, created, &plan, nil, &resp.Diagnostics)
+	if !resp.Diagnostics.HasError() {
+		r.readRelease(ctx, owner, repoName, &plan, &resp.Diagnostics)
+	}
+	if plan.HTMLURL.IsUnknown() {
+		plan.HTMLURL = types.StringValue(created.GetHTMLURL())
+	}
+	if plan.Name.IsUnknown() {
+		plan.Name = types.StringValue(created.GetName())
+	}
+	if plan.Body.IsUnknown() {
+		plan.Body = types.StringValue(created.GetBody())
+	}
+
+	// Save state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *repositoryReleaseResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state repositoryReleaseResourceModel
+
+	// Read Terraform state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			"GitHub client is not configured. Please ensure a token is provided.",
+		)
+		return
+	}
+
+	// Get owner, falling back to authenticated user if not set
+	owner, err := r.getOwner(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Missing Owner",
+			fmt.Sprintf("Unable to determine owner: %v. Please set provider-level `owner` configuration or ensure authentication is working.", err),
+		)
+		return
+	}
+
+	// Parse ID (format: repository:tag)
+	id := state.ID.ValueString()
+	repoName, _, err := parseTwoPartID(id, "repository", "tag")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid ID",
+			fmt.Sprintf("Invalid ID format: %s. Expected 'repository:tag'. Error: %v", id, err),
+		)
+		return
+	}
+
+	// Read the release
+	r.readRelease(ctx, owner, repoName, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state.ID.ValueString() == "" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *repositoryReleaseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state repositoryReleaseResourceModel
+
+	// Read Terraform plan and state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			"GitHub client is not configured. Please ensure a token is provided.",
+		)
+		return
+	}
+
+	// Get owner, falling back to authenticated user if not set
+	owner, err := r.getOwner(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Missing Owner",
+			fmt.Sprintf("Unable to determine owner: %v. Please set provider-level `owner` configuration or ensure authentication is working.", err),
+		)
+		return
+	}
+
+	repoName := plan.Repository.ValueString()
+	tagName := plan.Tag.ValueString()
+	releaseID := state.ReleaseID.ValueInt64()
+
+	if !plan.Tag.Equal(state.Tag) {
+		r.ensureReleaseTag(ctx, owner, repoName, tagName, plan.TargetCommitish.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Update the release
+	edited, _, err := r.client.Repositories.EditRelease(ctx, owner, repoName, releaseID, r.releaseFromModel(&plan))
+	if err != nil {
+		addGitHubError(&resp.Diagnostics,
+			"Error updating release",
+			fmt.Sprintf("Unable to update release %d in %s/%s: %v", releaseID, owner, repoName, err),
+			err,
+		)
+		return
+	}
+
+	plan.ReleaseID = state.ReleaseID
+	plan.ID = types.StringValue(buildTwoPartID(repoName, tagName))
+
+	r.syncAssets(ctx, owner, repoName, edited, &plan, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Read the release to get all computed values
+	r.readRelease(ctx, owner, repoName, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *repositoryReleaseResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state repositoryReleaseResourceModel
+
+	// Read Terraform state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			"GitHub client is not configured. Please ensure a token is provided.",
+		)
+		return
+	}
+
+	// Get owner, falling back to authenticated user if not set
+	owner, err := r.getOwner(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Missing Owner",
+			fmt.Sprintf("Unable to determine owner: %v. Please set provider-level `owner` configuration or ensure authentication is working.", err),
+		)
+		return
+	}
+
+	repoName := state.Repository.ValueString()
+	releaseID := state.ReleaseID.ValueInt64()
+
+	// Delete the release. Its assets are deleted with it, while the tag is kept.
+	log.Printf("[DEBUG] Deleting release: %s/%s (%d)", owner, repoName, releaseID)
+	_, err = r.client.Repositories.DeleteRelease(ctx, owner, repoName, releaseID)
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[INFO] Release %s/%s (%d) no longer exists, removing from state", owner, repoName, releaseID)
+			return
+		}
+		addGitHubError(&resp.Diagnostics,
+			"Error deleting release",
+			fmt.Sprintf("Unable to delete release %d in %s/%s: %v", releaseID, owner, repoName, err),
+			err,
+		)
+		return
+	}
+}
+
+// ImportState imports the resource into Terraform state.
+func (r *repositoryReleaseResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Parse the import ID (format: repository:tag)
+	parts := strings.SplitN(req.ID, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Import ID must be in format 'repository:tag'.",
+		)
+		return
+	}
+
+	// The release is looked up by tag in Read, as its ID is not known yet
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), buildTwoPartID(parts[0], parts[1]))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repository"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tag"), parts[1])...)
+}
+
+// Helper methods
+
+// getOwner gets the owner, falling back to authenticated user if not set.
+func (r *repositoryReleaseResource) getOwner(ctx context.Context) (string, error) {
+	if r.owner != "" {
+		return r.owner, nil
+	}
+	// Try to get authenticated user
+	user, _, err := r.client.Users.Get(ctx, "")
+	if err != nil {
+		return "", fmt.Errorf("unable to determine owner: provider-level `owner` is not set and unable to fetch authenticated user: %v", err)
+	}
+	if user == nil || user.Login == nil {
+		return "", fmt.Errorf("unable to determine owner: provider-level `owner` is not set and authenticated user information is unavailable")
+	}
+	return user.GetLogin(), nil
+}
+
+// releaseFromModel builds the release fields that can be set when creating or editing a release.
+func (r *repositoryReleaseResource) releaseFromModel(model *repositoryReleaseResourceModel) *github.RepositoryRelease {
+	release := &github.RepositoryRelease{
+		TagName:         github.String(model.Tag.ValueString()),
+		TargetCommitish: github.String(model.TargetCommitish.ValueString()),
+		Draft:           github.Bool(model.Draft.ValueBool()),
+		Prerelease:      github.Bool(model.Prerelease.ValueBool()),
+	}
+	if !model.Name.IsNull() && !model.Name.IsUnknown() {
+		release.Name = github.String(model.Name.ValueString())
+	}
+	if !model.Body.IsNull() && !model.Body.IsUnknown() {
+		release.Body = github.String(model.Body.ValueString())
+	}
+	if !model.MakeLatest.IsNull() {
+		release.MakeLatest = github.String(model.MakeLatest.ValueString())
+	}
+	return release
+}
+
+// ensureReleaseTag creates a lightweight tag at commitish, which is a branch or commit SHA,
+// unless the tag exists already. GitHub only creates missing tags when a release is
+// published, so drafts would otherwise have no tag.
+func (r *repositoryReleaseResource) ensureReleaseTag(ctx context.Context, owner, repoName, tagName, commitish string, diags *diag.Diagnostics) {
+	tagRefName := "refs/tags/" + tagName
+
+	_, _, err := r.client.Git.GetRef(ctx, owner, repoName, tagRefName)
+	if err == nil {
+		return
+	}
+	if !isNotFound(err) {
+		addGitHubError(diags,
+			"Error reading tag",
+			fmt.Sprintf("Unable to read tag %s/%s (%s): %v", owner, repoName, tagRefName, err),
+			err,
+		)
+		return
+	}
+
+	sha, _, err := r.client.Repositories.GetCommitSHA1(ctx, owner, repoName, commitish, "")
+	if err != nil {
+		addGitHubError(diags,
+			"Error resolving target commitish",
+			fmt.Sprintf("Unable to resolve %s in %s/%s to a commit: %v", commitish, owner, repoName, err),
+			err,
+		)
+		return
+	}
+
+	_, _, err = r.client.Git.CreateRef(ctx, owner, repoName, &github.Reference{
+		Ref:    github.String(tagRefName),
+		Object: &github.GitObject{SHA: github.String(sha)},
+	})
+	if err != nil && !isReferenceAlreadyExists(err) {
+		addGitHubError(diags,
+			"Error creating tag",
+			fmt.Sprintf("Unable to create GitHub tag reference %s/%s (%s): %v", owner, repoName, tagRefName, err),
+			err,
+		)
+		return
+	}
+	log.Printf("[INFO] Created tag %s/%s (%s) at %s", owner, repoName, tagRefName, sha)
+}
+
+// assetHashes returns the SHA-256 of each local asset file, by asset name.
+func (r *repositoryReleaseResource) assetHashes(ctx context.Context, model *repositoryReleaseResourceModel, diags *diag.Diagnostics) map[string]string {
+	var assets map[string]string
+	diags.Append(model.Assets.ElementsAs(ctx, &assets, false)...)
+	if diags.HasError() {
+		return nil
+	}
+
+	hashes := make(map[string]string, len(assets))
+	for name, filePath := range assets {
+		hash, err := fileSHA256(filePath)
+		if err != nil {
+			diags.AddError(
+				"Error reading release asset",
+				fmt.Sprintf("Unable to read local file %s for release asset %s: %v", filePath, name, err),
+			)
+			return nil
+		}
+		hashes[name] = hash
+	}
+	return hashes
+}
+
+// syncAssets uploads the assets whose local file changed or that are missing from the
+// release, and deletes the assets that are no longer configured. The model records the
+// assets that were uploaded, even if a later upload fails.
+func (r *repositoryReleaseResource) syncAssets(ctx context.Context, owner, repoName string, release *github.RepositoryRelease, plan, state *repositoryReleaseResourceModel, diags *diag.Diagnostics) {
+	var assets map[string]string
+	if !plan.Assets.IsNull() {
+		diags.Append(plan.Assets.ElementsAs(ctx, &assets, false)...)
+	}
+	hashes := r.assetHashes(ctx, plan, diags)
+	if diags.HasError() {
+		return
+	}
+
+	priorHashes := map[string]string{}
+	priorIDs := map[string]int64{}
+	if state != nil {
+		if !state.AssetSHA256.IsNull() {
+			diags.Append(state.AssetSHA256.ElementsAs(ctx, &priorHashes, false)...)
+		}
+		if !state.AssetIDs.IsNull() {
+			diags.Append(state.AssetIDs.ElementsAs(ctx, &priorIDs, false)...)
+		}
+		if diags.HasError() {
+			return
+		}
+	}
+
+	remoteIDs := make(map[string]int64, len(release.Assets))
+	for _, asset := range release.Assets {
+		remoteIDs[asset.GetName()] = asset.GetID()
+	}
+
+	uploadedHashes := make(map[string]string, len(assets))
+	uploadedIDs := make(map[string]int64, len(assets))
+	defer func() {
+		if plan.Assets.IsNull() {
+			plan.AssetSHA256 = types.MapNull(types.StringType)
+			plan.AssetIDs = types.MapNull(types.Int64Type)
+			return
+		}
+		hashesMap, d := types.MapValueFrom(ctx, types.StringType, uploadedHashes)
+		diags.Append(d...)
+		idsMap, d := types.MapValueFrom(ctx, types.Int64Type, uploadedIDs)
+		diags.Append(d...)
+		plan.AssetSHA256 = hashesMap
+		plan.AssetIDs = idsMap
+	}()
+
+	// Delete the assets that are no longer configured
+	for name := range priorIDs {
+		if _, ok := assets[name]; ok {
+			continue
+		}
+		if remoteID, ok := remoteIDs[name]; ok {
+			r.deleteAsset(ctx, owner, repoName, name, remoteID, diags)
+			if diags.HasError() {
+				return
+			}
+		}
+	}
+
+	names := make([]string, 0, len(assets))
+	for name := range assets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		remoteID, exists := remoteIDs[name]
+		if exists && priorHashes[name] == hashes[name] && priorIDs[name] == remoteID {
+			uploadedHashes[name] = hashes[name]
+			uploadedIDs[name] = remoteID
+			continue
+		}
+
+		// Asset names are unique, so the old asset has to go before the new one is uploaded
+		if exists {
+			r.deleteAsset(ctx, owner, repoName, name, remoteID, diags)
+			if diags.HasError() {
+				return
+			}
+		}
+
+		asset, err := r.uploadAsset(ctx, release, name, assets[name])
+		if err != nil {
+			addGitHubError(diags,
+				"Error uploading release asset",
+				fmt.Sprintf("Unable to upload %s as asset %s of release %s in %s/%s: %v", assets[name], name, release.GetTagName(), owner, repoName, err),
+				err,
+			)
+			return
+		}
+		log.Printf("[INFO] Uploaded asset %s (%d) to release %s in %s/%s", name, asset.GetID(), release.GetTagName(), owner, repoName)
+		uploadedHashes[name] = hashes[name]
+		uploadedIDs[name] = asset.GetID()
+	}
+}
+
+// deleteAsset deletes a release asset, ignoring assets that no longer exist.
+func (r *repositoryReleaseResource) deleteAsset(ctx context.Context, owner, repoName, name string, assetID int64, diags *diag.Diagnostics) {
+	_, err := r.client.Repositories.DeleteReleaseAsset(ctx, owner, repoName, assetID)
+	if err != nil && !isNotFound(err) {
+		addGitHubError(diags,
+			"Error deleting release asset",
+			fmt.Sprintf("Unable to delete release asset %s (%d) in %s/%s: %v", name, assetID, owner, repoName, err),
+			err,
+		)
+	}
+}
+
+// uploadAsset uploads a local file as a release asset. It uses the upload URL of the
+// release, which also points at the right host for GitHub Enterprise Server.
+func (r *repositoryReleaseResource) uploadAsset(ctx context.Context, release *github.RepositoryRelease, name, filePath string) (*github.ReleaseAsset, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if stat.IsDir() {
+		return nil, fmt.Errorf("%s is a directory", filePath)
+	}
+
+	mediaType := mime.TypeByExtension(filepath.Ext(name))
+	if mediaType == "" {
+		mediaType = "application/octet-stream"
+	}
+
+	uploadURL, _, _ := strings.Cut(release.GetUploadURL(), "{")
+	uploadURL += "?name=" + url.QueryEscape(name)
+	req, err := r.client.NewUploadRequest(uploadURL, file, stat.Size(), mediaType)
+	if err != nil {
+		return nil, err
+	}
+
+	asset := new(github.ReleaseAsset)
+	if _, err := r.client.Do(ctx, req, asset); err != nil {
+		return nil, err
+	}
+	return asset, nil
+}
+
+// readRelease reads release data from GitHub and populates the model. Assets that were
+// deleted or replaced in GitHub are dropped from the model, so that they are uploaded again.
+func (r *repositoryReleaseResource) readRelease(ctx context.Context, owner, repoName string, model *repositoryReleaseResourceModel, diags *diag.Diagnostics) {
+	var release *github.RepositoryRelease
+	var err error
+	if model.ReleaseID.IsNull() || model.ReleaseID.IsUnknown() {
+		// Imported releases are looked up by tag, which does not find drafts
+		release, _, err = r.client.Repositories.GetReleaseByTag(ctx, owner, repoName, model.Tag.ValueString())
+	} else {
+		release, _, err = r.client.Repositories.GetRelease(ctx, owner, repoName, model.ReleaseID.ValueInt64())
+	}
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[INFO] Removing release %s/%s (%s) from state because it no longer exists in GitHub",
+				owner, repoName, model.Tag.ValueString())
+			model.ID = types.StringValue("")
+			return
+		}
+		addGitHubError(diags,
+			"Error reading release",
+			fmt.Sprintf("Unable to read release %s in %s/%s: %v", model.Tag.ValueString(), owner, repoName, err),
+			err,
+		)
+		return
+	}
+
+	model.ID = types.StringValue(buildTwoPartID(repoName, release.GetTagName()))
+	model.Repository = types.StringValue(repoName)
+	model.ReleaseID = types.Int64Value(release.GetID())
+	model.Tag = types.StringValue(release.GetTagName())
+	model.TargetCommitish = types.StringValue(release.GetTargetCommitish())
+	model.Name = types.StringValue(release.GetName())
+	model.Body = types.StringValue(release.GetBody())
+	model.Draft = types.BoolValue(release.GetDraft())
+	model.Prerelease = types.BoolValue(release.GetPrerelease())
+	model.HTMLURL = types.StringValue(release.GetHTMLURL())
+	if model.GenerateReleaseNotes.IsNull() || model.GenerateReleaseNotes.IsUnknown() {
+		model.GenerateReleaseNotes = types.BoolValue(false)
+	}
+
+	if model.AssetIDs.IsNull() || model.AssetIDs.IsUnknown() {
+		return
+	}
+	var ids map[string]int64
+	var hashes map[string]string
+	diags.Append(model.AssetIDs.ElementsAs(ctx, &ids, false)...)
+	diags.Append(model.AssetSHA256.ElementsAs(ctx, &hashes, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	remoteIDs := make(map[string]int64, len(release.Assets))
+	for _, asset := range release.Assets {
+		remoteIDs[asset.GetName()] = asset.GetID()
+	}
+	for name, id := range ids {
+		if remoteIDs[name] != id {
+			log.Printf("[INFO] Release asset %s of %s/%s (%s) was deleted or replaced in GitHub", name, owner, repoName, release.GetTagName())
+			delete(ids, name)
+			delete(hashes, name)
+		}
+	}
+
+	idsMap, d := types.MapValueFrom(ctx, types.Int64Type, ids)
+	diags.Append(d...)
+	hashesMap, d := types.MapValueFrom(ctx, types.StringType, hashes)
+	diags.Append(d...)
+	model.AssetIDs = idsMap
+	model.AssetSHA256 = hashesMap
+}
+
+// fileSHA256 returns the hex encoded SHA-256 of a local file.
+func fileSHA256(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,353 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepositoryReleaseResource_Metadata(t *testing.T) {
+	r := NewRepositoryReleaseResource()
+	req := resource.MetadataRequest{
+		ProviderTypeName: "githubx",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(t.Context(), req, resp)
+
+	assert.Equal(t, "githubx_repository_release", resp.TypeName)
+}
+
+func TestRepositoryReleaseResource_Schema(t *testing.T) {
+	r := NewRepositoryReleaseResource()
+	req := resource.SchemaRequest{}
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(t.Context(), req, resp)
+
+	assert.NotNil(t, resp.Schema)
+	assert.Contains(t, resp.Schema.Description, "Creates and manages a GitHub release")
+
+	// Check required attributes
+	for _, name := range []string{"repository", "tag"} {
+		attr, ok := resp.Schema.Attributes[name]
+		assert.True(t, ok, name)
+		assert.True(t, attr.IsRequired(), name)
+	}
+
+	// Check optional attributes
+	for _, name := range []string{"target_commitish", "name", "body", "draft", "prerelease", "generate_release_notes", "make_latest", "assets"} {
+		attr, ok := resp.Schema.Attributes[name]
+		assert.True(t, ok, name)
+		assert.True(t, attr.IsOptional(), name)
+	}
+
+	// Check computed attributes
+	for _, name := range []string{"asset_sha256", "asset_ids", "release_id", "html_url", "id"} {
+		attr, ok := resp.Schema.Attributes[name]
+		assert.True(t, ok, name)
+		assert.True(t, attr.IsComputed(), name)
+	}
+}
+
+func TestRepositoryReleaseResource_Configure(t *testing.T) {
+	rs := &repositoryReleaseResource{}
+	resp := &resource.ConfigureResponse{}
+	clientData := githubxClientData{
+		Client: github.NewClient(nil),
+		Owner:  "test-owner",
+	}
+
+	rs.Configure(t.Context(), resource.ConfigureRequest{ProviderData: clientData}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.Equal(t, clientData.Client, rs.client)
+	assert.Equal(t, clientData.Owner, rs.owner)
+
+	resp = &resource.ConfigureResponse{}
+	rs.Configure(t.Context(), resource.ConfigureRequest{ProviderData: "invalid"}, resp)
+	assert.True(t, resp.Diagnostics.HasError())
+}
+
+// fakeReleaseRepository serves the parts of the GitHub API used by the release resource,
+// for a repository without tags.
+type fakeReleaseRepository struct {
+	mu       sync.Mutex
+	url      string
+	tags     map[string]string
+	release  map[string]interface{}
+	assets   map[string]int64
+	contents map[string]string
+	nextID   int64
+	deleted  []int64
+}
+
+func newFakeReleaseRepository() *fakeReleaseRepository {
+	return &fakeReleaseRepository{
+		tags:     map[string]string{},
+		assets:   map[string]int64{},
+		contents: map[string]string{},
+		nextID:   100,
+	}
+}
+
+func (f *fakeReleaseRepository) releaseJSON() map[string]interface{} {
+	release := map[string]interface{}{}
+	for k, v := range f.release {
+		release[k] = v
+	}
+	assets := []map[string]interface{}{}
+	for name, id := range f.assets {
+		assets = append(assets, map[string]interface{}{"id": id, "name": name})
+	}
+	release["id"] = 1
+	release["assets"] = assets
+	release["html_url"] = "https://github.com/test-owner/test-repo/releases/tag/" + f.release["tag_name"].(string)
+	release["upload_url"] = f.url + "/uploads/repos/test-owner/test-repo/releases/1/assets{?name,label}"
+	return release
+}
+
+func (f *fakeReleaseRepository) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.URL.Path == "/repos/test-owner/test-repo":
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"name": "test-repo", "default_branch": "main"})
+	case r.URL.Path == "/repos/test-owner/test-repo/commits/main":
+		_, _ = w.Write([]byte("main-sha"))
+	case strings.HasPrefix(r.URL.Path, "/repos/test-owner/test-repo/git/ref/tags/"):
+		tag := strings.TrimPrefix(r.URL.Path, "/repos/test-owner/test-repo/git/ref/tags/")
+		sha, ok := f.tags[tag]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ref": "refs/tags/" + tag, "object": map[string]string{"sha": sha}})
+	case r.URL.Path == "/repos/test-owner/test-repo/git/refs" && r.Method == http.MethodPost:
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.tags[strings.TrimPrefix(body["ref"], "refs/tags/")] = body["sha"]
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ref": body["ref"], "object": map[string]string{"sha": body["sha"]}})
+	case r.URL.Path == "/repos/test-owner/test-repo/releases" && r.Method == http.MethodPost:
+		_ = json.NewDecoder(r.Body).Decode(&f.release)
+		if f.release["generate_release_notes"] == true {
+			f.release["body"] = "Generated notes"
+		}
+		delete(f.release, "generate_release_notes")
+		_ = json.NewEncoder(w).Encode(f.releaseJSON())
+	case r.URL.Path == "/repos/test-owner/test-repo/releases/1" && r.Method == http.MethodPatch:
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		for k, v := range body {
+			f.release[k] = v
+		}
+		_ = json.NewEncoder(w).Encode(f.releaseJSON())
+	case r.URL.Path == "/repos/test-owner/test-repo/releases/1":
+		_ = json.NewEncoder(w).Encode(f.releaseJSON())
+	case r.URL.Path == "/uploads/repos/test-owner/test-repo/releases/1/assets":
+		name := r.URL.Query().Get("name")
+		if _, ok := f.assets[name]; ok {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"message":"Validation Failed","errors":[{"resource":"ReleaseAsset","code":"already_exists","field":"name"}]}`))
+			return
+		}
+		content, _ := io.ReadAll(r.Body)
+		f.nextID++
+		f.assets[name] = f.nextID
+		f.contents[name] = string(content)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": f.nextID, "name": name})
+	case strings.HasPrefix(r.URL.Path, "/repos/test-owner/test-repo/releases/assets/") && r.Method == http.MethodDelete:
+		var id int64
+		_, _ = fmt.Sscanf(strings.TrimPrefix(r.URL.Path, "/repos/test-owner/test-repo/releases/assets/"), "%d", &id)
+		for name, assetID := range f.assets {
+			if assetID == id {
+				delete(f.assets, name)
+				delete(f.contents, name)
+			}
+		}
+		f.deleted = append(f.deleted, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+	}
+}
+
+// planRelease runs ModifyPlan on the model, as Terraform would before applying it.
+func planRelease(t *testing.T, r *repositoryReleaseResource, schemaResp *resource.SchemaResponse, state *tfsdk.State, model repositoryReleaseResourceModel) tfsdk.Plan {
+	t.Helper()
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	assert.False(t, plan.Set(t.Context(), &model).HasError())
+
+	req := resource.ModifyPlanRequest{Plan: plan, State: tfsdk.State{Schema: schemaResp.Schema}}
+	if state != nil {
+		req.State = *state
+	}
+	resp := &resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(t.Context(), req, resp)
+	assert.False(t, resp.Diagnostics.HasError(), "unexpected errors: %v", resp.Diagnostics)
+	return resp.Plan
+}
+
+func TestRepositoryReleaseResource_MissingAssetFile(t *testing.T) {
+	binPath := filepath.Join(t.TempDir(), "tool-linux-amd64")
+
+	fake := newFakeReleaseRepository()
+	client := newTestGitHubClient(t, fake)
+	fake.url = strings.TrimSuffix(client.BaseURL.String(), "/")
+	r := &repositoryReleaseResource{client: client, owner: "test-owner"}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(t.Context(), resource.SchemaRequest{}, schemaResp)
+
+	assets, diags := types.MapValueFrom(t.Context(), types.StringType, map[string]string{
+		"tool-linux-amd64": binPath,
+	})
+	assert.False(t, diags.HasError())
+
+	model := repositoryReleaseResourceModel{
+		Repository:           types.StringValue("test-repo"),
+		Tag:                  types.StringValue("v1.0.0"),
+		TargetCommitish:      types.StringUnknown(),
+		Name:                 types.StringValue("v1.0.0"),
+		Body:                 types.StringValue("Notes"),
+		Draft:                types.BoolValue(false),
+		Prerelease:           types.BoolValue(false),
+		GenerateReleaseNotes: types.BoolValue(false),
+		MakeLatest:           types.StringNull(),
+		Assets:               assets,
+		AssetSHA256:          types.MapUnknown(types.StringType),
+		AssetIDs:             types.MapUnknown(types.Int64Type),
+		ReleaseID:            types.Int64Unknown(),
+		HTMLURL:              types.StringUnknown(),
+		ID:                   types.StringUnknown(),
+	}
+
+	// A file that does not exist yet leaves the hashes unknown at plan time
+	plan := planRelease(t, r, schemaResp, nil, model)
+	var planned repositoryReleaseResourceModel
+	assert.False(t, plan.Get(t.Context(), &planned).HasError())
+	assert.True(t, planned.AssetSHA256.IsUnknown())
+	assert.True(t, planned.AssetIDs.IsUnknown())
+
+	// It is an error if the file is still missing at apply time
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(t.Context(), resource.CreateRequest{Plan: plan}, createResp)
+	assert.True(t, createResp.Diagnostics.HasError())
+	assert.Contains(t, createResp.Diagnostics.Errors()[0].Summary(), "Error reading release asset")
+	assert.Empty(t, fake.contents)
+}
+
+func TestRepositoryReleaseResource_Lifecycle(t *testing.T) {
+	dir := t.TempDir()
+	binPath := filepath.Join(dir, "tool-linux-amd64")
+	sumsPath := filepath.Join(dir, "SHA256SUMS")
+	assert.NoError(t, os.WriteFile(binPath, []byte("binary v1"), 0o644))
+	assert.NoError(t, os.WriteFile(sumsPath, []byte("sums"), 0o644))
+
+	fake := newFakeReleaseRepository()
+	client := newTestGitHubClient(t, fake)
+	fake.url = strings.TrimSuffix(client.BaseURL.String(), "/")
+	r := &repositoryReleaseResource{client: client, owner: "test-owner"}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(t.Context(), resource.SchemaRequest{}, schemaResp)
+
+	assets, diags := types.MapValueFrom(t.Context(), types.StringType, map[string]string{
+		"tool-linux-amd64": binPath,
+		"SHA256SUMS":       sumsPath,
+	})
+	assert.False(t, diags.HasError())
+
+	model := repositoryReleaseResourceModel{
+		Repository:           types.StringValue("test-repo"),
+		Tag:                  types.StringValue("v1.0.0"),
+		TargetCommitish:      types.StringUnknown(),
+		Name:                 types.StringValue("v1.0.0"),
+		Body:                 types.StringUnknown(),
+		Draft:                types.BoolValue(false),
+		Prerelease:           types.BoolValue(false),
+		GenerateReleaseNotes: types.BoolValue(true),
+		MakeLatest:           types.StringNull(),
+		Assets:               assets,
+		AssetSHA256:          types.MapUnknown(types.StringType),
+		AssetIDs:             types.MapUnknown(types.Int64Type),
+		ReleaseID:            types.Int64Unknown(),
+		HTMLURL:              types.StringUnknown(),
+		ID:                   types.StringUnknown(),
+	}
+
+	// Create uploads every asset, after creating the missing tag
+	plan := planRelease(t, r, schemaResp, nil, model)
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(t.Context(), resource.CreateRequest{Plan: plan}, createResp)
+	assert.False(t, createResp.Diagnostics.HasError(), "unexpected errors: %v", createResp.Diagnostics)
+
+	assert.Equal(t, map[string]string{"v1.0.0": "main-sha"}, fake.tags)
+	assert.Equal(t, map[string]string{"tool-linux-amd64": "binary v1", "SHA256SUMS": "sums"}, fake.contents)
+
+	var state repositoryReleaseResourceModel
+	assert.False(t, createResp.State.Get(t.Context(), &state).HasError())
+	assert.Equal(t, "test-repo:v1.0.0", state.ID.ValueString())
+	assert.Equal(t, int64(1), state.ReleaseID.ValueInt64())
+	assert.Equal(t, "main", state.TargetCommitish.ValueString())
+	assert.Equal(t, "Generated notes", state.Body.ValueString())
+	var hashes map[string]string
+	assert.False(t, state.AssetSHA256.ElementsAs(t.Context(), &hashes, false).HasError())
+	assert.Equal(t, map[string]string{
+		"tool-linux-amd64": fmt.Sprintf("%x", sha256.Sum256([]byte("binary v1"))),
+		"SHA256SUMS":       fmt.Sprintf("%x", sha256.Sum256([]byte("sums"))),
+	}, hashes)
+
+	// A changed local file is uploaded again, and a removed asset is deleted
+	assert.NoError(t, os.WriteFile(binPath, []byte("binary v2"), 0o644))
+	oldBinID := fake.assets["tool-linux-amd64"]
+	sumsID := fake.assets["SHA256SUMS"]
+
+	assets, diags = types.MapValueFrom(t.Context(), types.StringType, map[string]string{
+		"tool-linux-amd64": binPath,
+	})
+	assert.False(t, diags.HasError())
+	model = state
+	model.Assets = assets
+	model.HTMLURL = types.StringUnknown()
+	plan = planRelease(t, r, schemaResp, &createResp.State, model)
+
+	var planned repositoryReleaseResourceModel
+	assert.False(t, plan.Get(t.Context(), &planned).HasError())
+	assert.True(t, planned.AssetIDs.IsUnknown())
+
+	updateResp := &resource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Update(t.Context(), resource.UpdateRequest{Plan: plan, State: createResp.State}, updateResp)
+	assert.False(t, updateResp.Diagnostics.HasError(), "unexpected errors: %v", updateResp.Diagnostics)
+
+	assert.ElementsMatch(t, []int64{oldBinID, sumsID}, fake.deleted)
+	assert.Equal(t, map[string]string{"tool-linux-amd64": "binary v2"}, fake.contents)
+
+	// An asset replaced in GitHub is dropped from state, so that it is uploaded again
+	fake.assets["tool-linux-amd64"] = 999
+	readResp := &resource.ReadResponse{State: updateResp.State}
+	r.Read(t.Context(), resource.ReadRequest{State: updateResp.State}, readResp)
+	assert.False(t, readResp.Diagnostics.HasError(), "unexpected errors: %v", readResp.Diagnostics)
+
+	assert.False(t, readResp.State.Get(t.Context(), &state).HasError())
+	assert.Empty(t, state.AssetIDs.Elements())
+	assert.Empty(t, state.AssetSHA256.Elements())
+}
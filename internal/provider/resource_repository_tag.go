@@ -0,0 +1,639 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &repositoryTagResource{}
+	_ resource.ResourceWithConfigure   = &repositoryTagResource{}
+	_ resource.ResourceWithImportState = &repositoryTagResource{}
+)
+
+// NewRepositoryTagResource is a helper function to simplify the provider implementation.
+func NewRepositoryTagResource() resource.Resource {
+	return &repositoryTagResource{}
+}
+
+// repositoryTagResource is the resource implementation.
+type repositoryTagResource struct {
+	client *github.Client
+	owner  string
+}
+
+// repositoryTagResourceModel maps the resource schema data.
+type repositoryTagResourceModel struct {
+	Repository   types.String `tfsdk:"repository"`
+	Tag          types.String `tfsdk:"tag"`
+	SourceBranch types.String `tfsdk:"source_branch"`
+	SourceSHA    types.String `tfsdk:"source_sha"`
+	Message      types.String `tfsdk:"message"`
+	TaggerName   types.String `tfsdk:"tagger_name"`
+	TaggerEmail  types.String `tfsdk:"tagger_email"`
+	Protect      types.Bool   `tfsdk:"protect"`
+	RulesetID    types.Int64  `tfsdk:"ruleset_id"`
+	Ref          types.String `tfsdk:"ref"`
+	SHA          types.String `tfsdk:"sha"`
+	TagSHA       types.String `tfsdk:"tag_sha"`
+	ID           types.String `tfsdk:"id"`
+}
+
+// Metadata returns the resource type name.
+func (r *repositoryTagResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repository_tag"
+}
+
+// Schema defines the schema for the resource.
+func (r *repositoryTagResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Creates and manages a GitHub repository tag. Tags are lightweight unless a `message` is set, in which case an annotated tag is created.",
+		Attributes: map[string]schema.Attribute{
+			"repository": schema.StringAttribute{
+				Description: "The GitHub repository name.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tag": schema.StringAttribute{
+				Description: "The name of the tag to create, such as 'v1.2.0'.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source_branch": schema.StringAttribute{
+				Description: "The branch whose head is tagged. Defaults to the repository's default branch. Changing it recreates the tag.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source_sha": schema.StringAttribute{
+				Description: "The commit hash to tag. Defaults to the tip of 'source_branch'. If provided, 'source_branch' is ignored. Changing it recreates the tag.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"message": schema.StringAttribute{
+				Description: "The message of an annotated tag. If not set, a lightweight tag is created. Changing it recreates the tag.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tagger_name": schema.StringAttribute{
+				Description: "The name of the tagger of an annotated tag. Defaults to the authenticated user. Requires `message` and `tagger_email`.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("message"), path.MatchRoot("tagger_email")),
+				},
+			},
+			"tagger_email": schema.StringAttribute{
+				Description: "The email address of the tagger of an annotated tag. Defaults to the authenticated user. Requires `message` and `tagger_name`.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("message"), path.MatchRoot("tagger_name")),
+				},
+			},
+			"protect": schema.BoolAttribute{
+				Description: "Protect the tag with a tag ruleset that blocks updating and deleting it. Defaults to \"false\".",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"ruleset_id": schema.Int64Attribute{
+				Description: "The ID of the ruleset that protects the tag, if `protect` is set.",
+				Computed:    true,
+			},
+			"ref": schema.StringAttribute{
+				Description: "A string representing a tag reference, in the form of 'refs/tags/<tag>'.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"sha": schema.StringAttribute{
+				Description: "The SHA1 of the commit that the tag points at.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tag_sha": schema.StringAttribute{
+				Description: "The SHA1 of the tag object of an annotated tag. Not set for lightweight tags.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "The Terraform state ID (repository:tag).",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure enables provider-level data or clients to be set in the
+// provider-defined resource type.
+func (r *repositoryTagResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(githubxClientData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected githubxClientData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = clientData.Client
+	r.owner = clientData.Owner
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *repositoryTagResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan repositoryTagResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			"GitHub client is not configured. Please ensure a token is provided.",
+		)
+		return
+	}
+
+	// Get owner, falling back to authenticated user if not set
+	owner, err := r.getOwner(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Missing Owner",
+			fmt.Sprintf("Unable to determine owner: %v. Please set provider-level `owner` configuration or ensure authentication is working.", err),
+		)
+		return
+	}
+
+	repoName := plan.Repository.ValueString()
+	tagName := plan.Tag.ValueString()
+	tagRefName := "refs/tags/" + tagName
+
+	// Resolve the commit to tag
+	resolveSourceSHA(ctx, r.client, owner, repoName, &plan.SourceBranch, &plan.SourceSHA, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	targetSHA := plan.SourceSHA.ValueString()
+
+	// An annotated tag is a tag object, which the reference points at instead of the commit
+	if !plan.Message.IsNull() {
+		tag := &github.Tag{
+			Tag:     github.String(tagName),
+			Message: github.String(plan.Message.ValueString()),
+			Object: &github.GitObject{
+				SHA:  github.String(targetSHA),
+				Type: github.String("commit"),
+			},
+		}
+		if !plan.TaggerName.IsNull() {
+			tag.Tagger = &github.CommitAuthor{
+				Name:  github.String(plan.TaggerName.ValueString()),
+				Email: github.String(plan.TaggerEmail.ValueString()),
+			}
+		}
+		created, _, err := r.client.Git.CreateTag(ctx, owner, repoName, tag)
+		if err != nil {
+			addGitHubError(&resp.Diagnostics,
+				"Error creating tag",
+				fmt.Sprintf("Unable to create GitHub tag object %s/%s (%s): %v", owner, repoName, tagName, err),
+				err,
+			)
+			return
+		}
+		targetSHA = created.GetSHA()
+	}
+
+	// Create the tag
+	_, _, err = r.client.Git.CreateRef(ctx, owner, repoName, &github.Reference{
+		Ref:    github.String(tagRefName),
+		Object: &github.GitObject{SHA: github.String(targetSHA)},
+	})
+	if err != nil {
+		detail := fmt.Sprintf("Unable to create GitHub tag reference %s/%s (%s): %v", owner, repoName, tagRefName, err)
+		if isReferenceAlreadyExists(err) {
+			detail += fmt.Sprintf("\n\nTo manage the existing tag, import it with the ID '%s'.", buildTwoPartID(repoName, tagName))
+		}
+		addGitHubError(&resp.Diagnostics, "Error creating tag", detail, err)
+		return
+	}
+	log.Printf("[INFO] Created tag %s/%s (%s) at %s", owner, repoName, tagRefName, plan.SourceSHA.ValueString())
+
+	// Protect the tag. If this fails, the tag is still saved below so that it is not left
+	// behind, and Terraform replaces it on the next apply.
+	plan.RulesetID = types.Int64Null()
+	if plan.Protect.ValueBool() {
+		r.createTagRuleset(ctx, owner, repoName, tagName, &plan, &resp.Diagnostics)
+	}
+
+	// Set ID using colon delimiter (standard Terraform pattern)
+	plan.ID = types.StringValue(buildTwoPartID(repoName, tagName))
+
+	// Read the tag to get all computed values
+	var readDiags diag.Diagnostics
+	r.readTag(ctx, owner, repoName, tagName, &plan, &readDiags)
+	resp.Diagnostics.Append(readDiags...)
+	if readDiags.HasError() {
+		return
+	}
+
+	// Save state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *repositoryTagResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state repositoryTagResourceModel
+
+	// Read Terraform state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			"GitHub client is not configured. Please ensure a token is provided.",
+		)
+		return
+	}
+
+	// Get owner, falling back to authenticated user if not set
+	owner, err := r.getOwner(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Missing Owner",
+			fmt.Sprintf("Unable to determine owner: %v. Please set provider-level `owner` configuration or ensure authentication is working.", err),
+		)
+		return
+	}
+
+	// Parse ID (format: repository:tag)
+	id := state.ID.ValueString()
+	repoName, tagName, err := parseTwoPartID(id, "repository", "tag")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid ID",
+			fmt.Sprintf("Invalid ID format: %s. Expected 'repository:tag'. Error: %v", id, err),
+		)
+		return
+	}
+
+	// Read the tag
+	r.readTag(ctx, owner, repoName, tagName, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state.ID.ValueString() == "" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+// Only `protect` can change in place; all other changes recreate the tag.
+func (r *repositoryTagResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state repositoryTagResourceModel
+
+	// Read Terraform plan and state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			"GitHub client is not configured. Please ensure a token is provided.",
+		)
+		return
+	}
+
+	// Get owner, falling back to authenticated user if not set
+	owner, err := r.getOwner(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Missing Owner",
+			fmt.Sprintf("Unable to determine owner: %v. Please set provider-level `owner` configuration or ensure authentication is working.", err),
+		)
+		return
+	}
+
+	repoName := plan.Repository.ValueString()
+	tagName := plan.Tag.ValueString()
+	plan.ID = state.ID
+	plan.RulesetID = state.RulesetID
+
+	switch {
+	case plan.Protect.ValueBool() && state.RulesetID.IsNull():
+		r.createTagRuleset(ctx, owner, repoName, tagName, &plan, &resp.Diagnostics)
+	case !plan.Protect.ValueBool() && !state.RulesetID.IsNull():
+		r.deleteTagRuleset(ctx, owner, repoName, state.RulesetID.ValueInt64(), &resp.Diagnostics)
+		plan.RulesetID = types.Int64Null()
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Read the tag to get all computed values
+	r.readTag(ctx, owner, repoName, tagName, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *repositoryTagResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state repositoryTagResourceModel
+
+	// Read Terraform state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			"GitHub client is not configured. Please ensure a token is provided.",
+		)
+		return
+	}
+
+	// Get owner, falling back to authenticated user if not set
+	owner, err := r.getOwner(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Missing Owner",
+			fmt.Sprintf("Unable to determine owner: %v. Please set provider-level `owner` configuration or ensure authentication is working.", err),
+		)
+		return
+	}
+
+	// Parse ID (format: repository:tag)
+	id := state.ID.ValueString()
+	repoName, tagName, err := parseTwoPartID(id, "repository", "tag")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid ID",
+			fmt.Sprintf("Invalid ID format: %s. Expected 'repository:tag'. Error: %v", id, err),
+		)
+		return
+	}
+	tagRefName := "refs/tags/" + tagName
+
+	// The ruleset has to go first, as it blocks deleting the tag
+	if !state.RulesetID.IsNull() {
+		r.deleteTagRuleset(ctx, owner, repoName, state.RulesetID.ValueInt64(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Delete the tag
+	log.Printf("[DEBUG] Deleting tag: %s/%s (%s)", owner, repoName, tagRefName)
+	_, err = r.client.Git.DeleteRef(ctx, owner, repoName, tagRefName)
+	if err != nil {
+		if isReferenceNotFound(err) {
+			log.Printf("[INFO] Tag %s/%s (%s) no longer exists, removing from state", owner, repoName, tagRefName)
+			return
+		}
+		addGitHubError(&resp.Diagnostics,
+			"Error deleting tag",
+			fmt.Sprintf("Unable to delete GitHub tag reference %s/%s (%s): %v", owner, repoName, tagRefName, err),
+			err,
+		)
+		return
+	}
+}
+
+// ImportState imports the resource into Terraform state.
+func (r *repositoryTagResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Parse the import ID (format: repository:tag)
+	parts := strings.SplitN(req.ID, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Import ID must be in format 'repository:tag'.",
+		)
+		return
+	}
+
+	repoName := parts[0]
+	tagName := parts[1]
+
+	sourceBranch := "main"
+	if r.client != nil {
+		if owner, err := r.getOwner(ctx); err == nil {
+			if repo, _, err := r.client.Repositories.Get(ctx, owner, repoName); err == nil && repo.GetDefaultBranch() != "" {
+				sourceBranch = repo.GetDefaultBranch()
+			}
+		}
+	}
+
+	// Set the ID using colon delimiter
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), buildTwoPartID(repoName, tagName))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repository"), repoName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tag"), tagName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source_branch"), sourceBranch)...)
+}
+
+// Helper methods
+
+// getOwner gets the owner, falling back to authenticated user if not set.
+func (r *repositoryTagResource) getOwner(ctx context.Context) (string, error) {
+	if r.owner != "" {
+		return r.owner, nil
+	}
+	// Try to get authenticated user
+	user, _, err := r.client.Users.Get(ctx, "")
+	if err != nil {
+		return "", fmt.Errorf("unable to determine owner: provider-level `owner` is not set and unable to fetch authenticated user: %v", err)
+	}
+	if user == nil || user.Login == nil {
+		return "", fmt.Errorf("unable to determine owner: provider-level `owner` is not set and authenticated user information is unavailable")
+	}
+	return user.GetLogin(), nil
+}
+
+// createTagRuleset creates a ruleset that blocks updating and deleting the tag, and
+// records its ID in the model.
+func (r *repositoryTagResource) createTagRuleset(ctx context.Context, owner, repoName, tagName string, model *repositoryTagResourceModel, diags *diag.Diagnostics) {
+	ruleset, _, err := r.client.Repositories.CreateRuleset(ctx, owner, repoName, &github.Ruleset{
+		Name:        fmt.Sprintf("Protect tag %s", tagName),
+		Target:      github.String("tag"),
+		Source:      repoName,
+		Enforcement: "active",
+		Conditions: &github.RulesetConditions{
+			RefName: &github.RulesetRefConditionParameters{
+				Include: []string{"refs/tags/" + tagName},
+				Exclude: []string{},
+			},
+		},
+		Rules: []*github.RepositoryRule{
+			github.NewUpdateRule(nil),
+			github.NewDeletionRule(),
+			github.NewNonFastForwardRule(),
+		},
+	})
+	if err != nil {
+		addGitHubError(diags,
+			"Error protecting tag",
+			fmt.Sprintf("Unable to create a tag ruleset for %s/%s (%s): %v", owner, repoName, tagName, err),
+			err,
+		)
+		return
+	}
+	log.Printf("[INFO] Created tag ruleset %d for %s/%s (%s)", ruleset.GetID(), owner, repoName, tagName)
+	model.RulesetID = types.Int64Value(ruleset.GetID())
+}
+
+// deleteTagRuleset deletes the ruleset that protects a tag, ignoring rulesets that no
+// longer exist.
+func (r *repositoryTagResource) deleteTagRuleset(ctx context.Context, owner, repoName string, rulesetID int64, diags *diag.Diagnostics) {
+	_, err := r.client.Repositories.DeleteRuleset(ctx, owner, repoName, rulesetID)
+	if err != nil && !isNotFound(err) {
+		addGitHubError(diags,
+			"Error unprotecting tag",
+			fmt.Sprintf("Unable to delete tag ruleset %d of %s/%s: %v", rulesetID, owner, repoName, err),
+			err,
+		)
+	}
+}
+
+// readTag reads tag data from GitHub and populates the model.
+func (r *repositoryTagResource) readTag(ctx context.Context, owner, repoName, tagName string, model *repositoryTagResourceModel, diags *diag.Diagnostics) {
+	tagRefName := "refs/tags/" + tagName
+
+	ref, _, err := r.client.Git.GetRef(ctx, owner, repoName, tagRefName)
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[INFO] Removing tag %s/%s (%s) from state because it no longer exists in GitHub",
+				owner, repoName, tagName)
+			model.ID = types.StringValue("")
+			return
+		}
+		addGitHubError(diags,
+			"Error reading tag",
+			fmt.Sprintf("Unable to read tag %s/%s (%s): %v", owner, repoName, tagRefName, err),
+			err,
+		)
+		return
+	}
+
+	// Set ID using colon delimiter
+	model.ID = types.StringValue(buildTwoPartID(repoName, tagName))
+	model.Repository = types.StringValue(repoName)
+	model.Tag = types.StringValue(tagName)
+	model.Ref = types.StringValue(ref.GetRef())
+
+	if ref.GetObject().GetType() == "tag" {
+		// Annotated tag, so the commit is the object of the tag object
+		tag, _, err := r.client.Git.GetTag(ctx, owner, repoName, ref.GetObject().GetSHA())
+		if err != nil {
+			addGitHubError(diags,
+				"Error reading tag",
+				fmt.Sprintf("Unable to read tag object %s/%s (%s): %v", owner, repoName, ref.GetObject().GetSHA(), err),
+				err,
+			)
+			return
+		}
+		model.SHA = types.StringValue(tag.GetObject().GetSHA())
+		model.TagSHA = types.StringValue(tag.GetSHA())
+		// Git ends tag messages with a newline, which should not show as a change
+		if strings.TrimSuffix(tag.GetMessage(), "\n") != strings.TrimSuffix(model.Message.ValueString(), "\n") {
+			model.Message = types.StringValue(tag.GetMessage())
+		}
+		// The tagger defaults to the authenticated user, so it is only refreshed if it was set
+		if !model.TaggerName.IsNull() {
+			model.TaggerName = types.StringValue(tag.GetTagger().GetName())
+			model.TaggerEmail = types.StringValue(tag.GetTagger().GetEmail())
+		}
+	} else {
+		model.SHA = types.StringValue(ref.GetObject().GetSHA())
+		model.TagSHA = types.StringNull()
+		model.Message = types.StringNull()
+	}
+
+	// Imported tags have no source, so they start from the commit they point at
+	if model.SourceSHA.IsNull() || model.SourceSHA.IsUnknown() {
+		model.SourceSHA = model.SHA
+	}
+
+	// Check that the ruleset still exists
+	if !model.RulesetID.IsNull() && !model.RulesetID.IsUnknown() {
+		_, _, err := r.client.Repositories.GetRuleset(ctx, owner, repoName, model.RulesetID.ValueInt64(), false)
+		if err != nil {
+			if !isNotFound(err) {
+				addGitHubError(diags,
+					"Error reading tag ruleset",
+					fmt.Sprintf("Unable to read tag ruleset %d of %s/%s: %v", model.RulesetID.ValueInt64(), owner, repoName, err),
+					err,
+				)
+				return
+			}
+			log.Printf("[INFO] Tag ruleset %d of %s/%s no longer exists", model.RulesetID.ValueInt64(), owner, repoName)
+			model.RulesetID = types.Int64Null()
+		}
+	} else {
+		model.RulesetID = types.Int64Null()
+	}
+	model.Protect = types.BoolValue(!model.RulesetID.IsNull())
+}
@@ -0,0 +1,247 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepositoryTagResource_Metadata(t *testing.T) {
+	r := NewRepositoryTagResource()
+	req := resource.MetadataRequest{
+		ProviderTypeName: "githubx",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(t.Context(), req, resp)
+
+	assert.Equal(t, "githubx_repository_tag", resp.TypeName)
+}
+
+func TestRepositoryTagResource_Schema(t *testing.T) {
+	r := NewRepositoryTagResource()
+	req := resource.SchemaRequest{}
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(t.Context(), req, resp)
+
+	assert.NotNil(t, resp.Schema)
+	assert.Contains(t, resp.Schema.Description, "Creates and manages a GitHub repository tag")
+
+	// Check required attributes
+	for _, name := range []string{"repository", "tag"} {
+		attr, ok := resp.Schema.Attributes[name]
+		assert.True(t, ok, name)
+		assert.True(t, attr.IsRequired(), name)
+	}
+
+	// Check optional attributes
+	for _, name := range []string{"source_branch", "source_sha", "message", "tagger_name", "tagger_email", "protect"} {
+		attr, ok := resp.Schema.Attributes[name]
+		assert.True(t, ok, name)
+		assert.True(t, attr.IsOptional(), name)
+	}
+
+	// Check computed attributes
+	for _, name := range []string{"ruleset_id", "ref", "sha", "tag_sha", "id"} {
+		attr, ok := resp.Schema.Attributes[name]
+		assert.True(t, ok, name)
+		assert.True(t, attr.IsComputed(), name)
+	}
+}
+
+func TestRepositoryTagResource_Configure(t *testing.T) {
+	tests := []struct {
+		name          string
+		providerData  interface{}
+		expectError   bool
+		errorContains string
+	}{
+		{
+			name: "valid githubxClientData",
+			providerData: githubxClientData{
+				Client: github.NewClient(nil),
+				Owner:  "test-owner",
+			},
+			expectError: false,
+		},
+		{
+			name:          "invalid provider data type",
+			providerData:  "invalid",
+			expectError:   true,
+			errorContains: "Unexpected Resource Configure Type",
+		},
+		{
+			name:         "nil provider data",
+			providerData: nil,
+			expectError:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := &repositoryTagResource{}
+			req := resource.ConfigureRequest{
+				ProviderData: tt.providerData,
+			}
+			resp := &resource.ConfigureResponse{}
+
+			rs.Configure(t.Context(), req, resp)
+
+			if tt.expectError {
+				assert.True(t, resp.Diagnostics.HasError())
+				if tt.errorContains != "" {
+					assert.Contains(t, resp.Diagnostics.Errors()[0].Summary(), tt.errorContains)
+				}
+			} else {
+				assert.False(t, resp.Diagnostics.HasError())
+				if tt.providerData != nil {
+					clientData, ok := tt.providerData.(githubxClientData)
+					if ok {
+						assert.Equal(t, clientData.Client, rs.client)
+						assert.Equal(t, clientData.Owner, rs.owner)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRepositoryTagResource_Create(t *testing.T) {
+	tests := []struct {
+		name           string
+		message        types.String
+		protect        bool
+		expectedRef    string
+		expectedTagSHA types.String
+		expectRuleset  bool
+		rulesetFails   bool
+	}{
+		{
+			name:           "lightweight",
+			message:        types.StringNull(),
+			expectedRef:    "commit-sha",
+			expectedTagSHA: types.StringNull(),
+		},
+		{
+			name:           "annotated and protected",
+			message:        types.StringValue("Release 1.0.0"),
+			protect:        true,
+			expectedRef:    "tag-object-sha",
+			expectedTagSHA: types.StringValue("tag-object-sha"),
+			expectRuleset:  true,
+		},
+		{
+			// The tag is saved, so that Terraform replaces it rather than leaving it behind
+			name:           "protection fails",
+			message:        types.StringNull(),
+			protect:        true,
+			expectedRef:    "commit-sha",
+			expectedTagSHA: types.StringNull(),
+			rulesetFails:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created map[string]interface{}
+			var ruleset map[string]interface{}
+			client := newTestGitHubClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case r.URL.Path == "/repos/test-owner/test-repo":
+					_ = json.NewEncoder(w).Encode(map[string]interface{}{"name": "test-repo", "default_branch": "main"})
+				case r.URL.Path == "/repos/test-owner/test-repo/git/ref/heads/main":
+					_ = json.NewEncoder(w).Encode(map[string]interface{}{"ref": "refs/heads/main", "object": map[string]string{"sha": "commit-sha", "type": "commit"}})
+				case r.URL.Path == "/repos/test-owner/test-repo/git/tags" && r.Method == http.MethodPost:
+					_ = json.NewEncoder(w).Encode(map[string]interface{}{"sha": "tag-object-sha", "tag": "v1.0.0"})
+				case r.URL.Path == "/repos/test-owner/test-repo/git/tags/tag-object-sha":
+					_ = json.NewEncoder(w).Encode(map[string]interface{}{
+						"sha":     "tag-object-sha",
+						"tag":     "v1.0.0",
+						"message": "Release 1.0.0\n",
+						"object":  map[string]string{"sha": "commit-sha", "type": "commit"},
+					})
+				case r.URL.Path == "/repos/test-owner/test-repo/git/refs" && r.Method == http.MethodPost:
+					_ = json.NewDecoder(r.Body).Decode(&created)
+					_ = json.NewEncoder(w).Encode(map[string]interface{}{"ref": created["ref"], "object": map[string]interface{}{"sha": created["sha"]}})
+				case r.URL.Path == "/repos/test-owner/test-repo/git/ref/tags/v1.0.0":
+					objectType := "commit"
+					if tt.message.ValueString() != "" {
+						objectType = "tag"
+					}
+					_ = json.NewEncoder(w).Encode(map[string]interface{}{"ref": "refs/tags/v1.0.0", "object": map[string]interface{}{"sha": created["sha"], "type": objectType}})
+				case r.URL.Path == "/repos/test-owner/test-repo/rulesets" && r.Method == http.MethodPost && tt.rulesetFails:
+					w.WriteHeader(http.StatusForbidden)
+					_, _ = w.Write([]byte(`{"message":"Resource not accessible by integration"}`))
+				case r.URL.Path == "/repos/test-owner/test-repo/rulesets" && r.Method == http.MethodPost:
+					_ = json.NewDecoder(r.Body).Decode(&ruleset)
+					_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 42, "name": ruleset["name"], "source": "test-repo", "enforcement": "active"})
+				case r.URL.Path == "/repos/test-owner/test-repo/rulesets/42":
+					_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 42, "name": "Protect tag v1.0.0", "source": "test-repo", "enforcement": "active"})
+				default:
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+				}
+			}))
+			r := &repositoryTagResource{client: client, owner: "test-owner"}
+
+			schemaResp := &resource.SchemaResponse{}
+			r.Schema(t.Context(), resource.SchemaRequest{}, schemaResp)
+
+			model := repositoryTagResourceModel{
+				Repository:   types.StringValue("test-repo"),
+				Tag:          types.StringValue("v1.0.0"),
+				SourceBranch: types.StringUnknown(),
+				SourceSHA:    types.StringUnknown(),
+				Message:      tt.message,
+				TaggerName:   types.StringNull(),
+				TaggerEmail:  types.StringNull(),
+				Protect:      types.BoolValue(tt.protect),
+				RulesetID:    types.Int64Unknown(),
+				Ref:          types.StringUnknown(),
+				SHA:          types.StringUnknown(),
+				TagSHA:       types.StringUnknown(),
+				ID:           types.StringUnknown(),
+			}
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			assert.False(t, plan.Set(t.Context(), &model).HasError())
+
+			resp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+			r.Create(t.Context(), resource.CreateRequest{Plan: plan}, resp)
+			if tt.rulesetFails {
+				assert.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, "Error protecting tag", resp.Diagnostics.Errors()[0].Summary())
+			} else {
+				assert.False(t, resp.Diagnostics.HasError(), "unexpected errors: %v", resp.Diagnostics)
+			}
+
+			assert.Equal(t, "refs/tags/v1.0.0", created["ref"])
+			assert.Equal(t, tt.expectedRef, created["sha"])
+
+			var state repositoryTagResourceModel
+			assert.False(t, resp.State.Get(t.Context(), &state).HasError())
+			assert.Equal(t, "test-repo:v1.0.0", state.ID.ValueString())
+			assert.Equal(t, "main", state.SourceBranch.ValueString())
+			assert.Equal(t, "commit-sha", state.SourceSHA.ValueString())
+			assert.Equal(t, "commit-sha", state.SHA.ValueString())
+			assert.Equal(t, tt.expectedTagSHA, state.TagSHA)
+			assert.Equal(t, tt.message, state.Message)
+			if tt.expectRuleset {
+				assert.Equal(t, int64(42), state.RulesetID.ValueInt64())
+				assert.Equal(t, "tag", ruleset["target"])
+				assert.Equal(t, []interface{}{"refs/tags/v1.0.0"}, ruleset["conditions"].(map[string]interface{})["ref_name"].(map[string]interface{})["include"])
+			} else {
+				assert.True(t, state.RulesetID.IsNull())
+				assert.False(t, state.Protect.ValueBool())
+				assert.Nil(t, ruleset)
+			}
+		})
+	}
+}
@@ -1,10 +1,26 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-github/v60/github"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -36,6 +52,15 @@ func TestRepositoryResource_Schema(t *testing.T) {
 	assert.True(t, nameAttr.IsRequired())
 
 	// Check optional attributes
+	ownerAttr, ok := resp.Schema.Attributes["owner"]
+	assert.True(t, ok)
+	assert.True(t, ownerAttr.IsOptional())
+	assert.True(t, ownerAttr.IsComputed())
+
+	teamIDsAttr, ok := resp.Schema.Attributes["team_ids"]
+	assert.True(t, ok)
+	assert.True(t, teamIDsAttr.IsOptional())
+
 	descriptionAttr, ok := resp.Schema.Attributes["description"]
 	assert.True(t, ok)
 	assert.True(t, descriptionAttr.IsOptional())
@@ -133,6 +158,15 @@ func TestRepositoryResource_Schema(t *testing.T) {
 	assert.True(t, ok)
 	assert.True(t, archiveOnDestroyAttr.IsOptional())
 
+	deletionProtectionAttr, ok := resp.Schema.Attributes["deletion_protection"]
+	assert.True(t, ok)
+	assert.True(t, deletionProtectionAttr.IsOptional())
+	assert.True(t, deletionProtectionAttr.IsComputed())
+
+	backupOnDestroyPathAttr, ok := resp.Schema.Attributes["backup_on_destroy_path"]
+	assert.True(t, ok)
+	assert.True(t, backupOnDestroyPathAttr.IsOptional())
+
 	autoInitAttr, ok := resp.Schema.Attributes["auto_init"]
 	assert.True(t, ok)
 	assert.True(t, autoInitAttr.IsOptional())
@@ -163,8 +197,14 @@ func TestRepositoryResource_Schema(t *testing.T) {
 
 	defaultBranchAttr, ok := resp.Schema.Attributes["default_branch"]
 	assert.True(t, ok)
+	assert.True(t, defaultBranchAttr.IsOptional())
 	assert.True(t, defaultBranchAttr.IsComputed())
 
+	renameDefaultBranchAttr, ok := resp.Schema.Attributes["rename_default_branch"]
+	assert.True(t, ok)
+	assert.True(t, renameDefaultBranchAttr.IsOptional())
+	assert.True(t, renameDefaultBranchAttr.IsComputed())
+
 	htmlURLAttr, ok := resp.Schema.Attributes["html_url"]
 	assert.True(t, ok)
 	assert.True(t, htmlURLAttr.IsComputed())
@@ -179,6 +219,7 @@ func TestRepositoryResource_Schema(t *testing.T) {
 
 	archivedAttr, ok := resp.Schema.Attributes["archived"]
 	assert.True(t, ok)
+	assert.True(t, archivedAttr.IsOptional())
 	assert.True(t, archivedAttr.IsComputed())
 }
 
@@ -240,6 +281,862 @@ func TestRepositoryResource_Configure(t *testing.T) {
 	}
 }
 
+func TestRepositoryResource_ModifyPlan_DeletionProtection(t *testing.T) {
+	tests := []struct {
+		name               string
+		deletionProtection bool
+		destroy            bool
+		expectError        bool
+	}{
+		{
+			name:               "destroy with protection",
+			deletionProtection: true,
+			destroy:            true,
+			expectError:        true,
+		},
+		{
+			name:               "destroy without protection",
+			deletionProtection: false,
+			destroy:            true,
+			expectError:        false,
+		},
+		{
+			name:               "update with protection",
+			deletionProtection: true,
+			destroy:            false,
+			expectError:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := &repositoryResource{}
+			schemaResp := &resource.SchemaResponse{}
+			rs.Schema(t.Context(), resource.SchemaRequest{}, schemaResp)
+			objType := schemaResp.Schema.Type().TerraformType(t.Context())
+
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objType, nil)}
+			diags := state.SetAttribute(t.Context(), path.Root("name"), "my-repo")
+			diags.Append(state.SetAttribute(t.Context(), path.Root("deletion_protection"), tt.deletionProtection)...)
+			assert.False(t, diags.HasError())
+
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: state.Raw.Copy()}
+			if tt.destroy {
+				plan.Raw = tftypes.NewValue(objType, nil)
+			}
+
+			req := resource.ModifyPlanRequest{State: state, Plan: plan}
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+
+			rs.ModifyPlan(t.Context(), req, resp)
+
+			assert.Equal(t, tt.expectError, resp.Diagnostics.HasError())
+			if tt.expectError {
+				assert.Contains(t, resp.Diagnostics.Errors()[0].Summary(), "protected from deletion")
+			}
+		})
+	}
+}
+
+// newTestGitHubClient returns a GitHub client that sends requests to a fake API server.
+func newTestGitHubClient(t *testing.T, handler http.Handler) *github.Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	baseURL, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatalf("unable to parse test server URL: %v", err)
+	}
+
+	client := github.NewClient(nil)
+	client.BaseURL = baseURL
+	client.UploadURL = baseURL
+	return client
+}
+
+// fakeArchivableRepository is a fake GitHub API for a single repository that,
+// like GitHub, rejects edits while the repository is archived.
+type fakeArchivableRepository struct {
+	mu             sync.Mutex
+	archived       bool
+	description    string
+	denyUnarchive  bool
+	editedArchived []interface{}
+	editedFields   [][]string
+}
+
+func (f *fakeArchivableRepository) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.URL.Path == "/repos/test-owner/test-repo" && r.Method == http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":             1,
+			"node_id":        "R_1",
+			"name":           "test-repo",
+			"full_name":      "test-owner/test-repo",
+			"owner":          map[string]interface{}{"login": "test-owner"},
+			"description":    f.description,
+			"archived":       f.archived,
+			"default_branch": "main",
+		})
+	case r.URL.Path == "/repos/test-owner/test-repo" && r.Method == http.MethodPatch:
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		fields := make([]string, 0, len(body))
+		for field := range body {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		f.editedFields = append(f.editedFields, fields)
+		f.editedArchived = append(f.editedArchived, body["archived"])
+
+		archived, hasArchived := body["archived"].(bool)
+		if hasArchived && !archived && f.denyUnarchive {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message":"Must have admin rights to Repository."}`))
+			return
+		}
+		if f.archived && !hasArchived {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message":"Repository was archived so is read-only."}`))
+			return
+		}
+		if hasArchived {
+			f.archived = archived
+		}
+		if description, ok := body["description"].(string); ok {
+			f.description = description
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"test-repo"}`))
+	case r.URL.Path == "/repos/test-owner/test-repo/vulnerability-alerts":
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestRepositoryResource_Update_ArchivedTransitions(t *testing.T) {
+	tests := []struct {
+		name                string
+		archived            bool
+		planArchived        bool
+		planDescription     string
+		denyUnarchive       bool
+		expectError         string
+		expectArchivedEdits []interface{}
+		expectArchived      bool
+		expectDescription   string
+	}{
+		{
+			name:                "change archived repository",
+			archived:            true,
+			planArchived:        true,
+			planDescription:     "updated",
+			expectArchivedEdits: []interface{}{false, nil, true},
+			expectArchived:      true,
+			expectDescription:   "updated",
+		},
+		{
+			name:                "unarchive repository",
+			archived:            true,
+			planArchived:        false,
+			planDescription:     "original",
+			expectArchivedEdits: []interface{}{false},
+			expectArchived:      false,
+			expectDescription:   "original",
+		},
+		{
+			name:                "unarchive and change repository",
+			archived:            true,
+			planArchived:        false,
+			planDescription:     "updated",
+			expectArchivedEdits: []interface{}{false, nil},
+			expectArchived:      false,
+			expectDescription:   "updated",
+		},
+		{
+			name:                "archive and change repository",
+			archived:            false,
+			planArchived:        true,
+			planDescription:     "updated",
+			expectArchivedEdits: []interface{}{nil, true},
+			expectArchived:      true,
+			expectDescription:   "updated",
+		},
+		{
+			name:                "unarchive denied",
+			archived:            true,
+			planArchived:        true,
+			planDescription:     "updated",
+			denyUnarchive:       true,
+			expectError:         "Unable to change archived repository",
+			expectArchivedEdits: []interface{}{false},
+			expectArchived:      true,
+			expectDescription:   "original",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeArchivableRepository{
+				archived:      tt.archived,
+				description:   "original",
+				denyUnarchive: tt.denyUnarchive,
+			}
+			rs := &repositoryResource{
+				client: newTestGitHubClient(t, fake),
+				owner:  "test-owner",
+			}
+
+			schemaResp := &resource.SchemaResponse{}
+			rs.Schema(t.Context(), resource.SchemaRequest{}, schemaResp)
+			objType := schemaResp.Schema.Type().TerraformType(t.Context())
+
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objType, nil)}
+			diags := state.SetAttribute(t.Context(), path.Root("id"), "test-repo")
+			diags.Append(state.SetAttribute(t.Context(), path.Root("name"), "test-repo")...)
+			diags.Append(state.SetAttribute(t.Context(), path.Root("owner"), "test-owner")...)
+			diags.Append(state.SetAttribute(t.Context(), path.Root("description"), "original")...)
+			diags.Append(state.SetAttribute(t.Context(), path.Root("archived"), tt.archived)...)
+			diags.Append(state.SetAttribute(t.Context(), path.Root("allow_merge_commit"), true)...)
+			diags.Append(state.SetAttribute(t.Context(), path.Root("allow_squash_merge"), true)...)
+			diags.Append(state.SetAttribute(t.Context(), path.Root("allow_rebase_merge"), true)...)
+			assert.False(t, diags.HasError())
+
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: state.Raw.Copy()}
+			diags.Append(plan.SetAttribute(t.Context(), path.Root("description"), tt.planDescription)...)
+			diags.Append(plan.SetAttribute(t.Context(), path.Root("archived"), tt.planArchived)...)
+			assert.False(t, diags.HasError())
+
+			req := resource.UpdateRequest{Plan: plan, State: state}
+			resp := &resource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: state.Raw.Copy()}}
+
+			rs.Update(t.Context(), req, resp)
+
+			if tt.expectError != "" {
+				assert.True(t, resp.Diagnostics.HasError())
+				assert.Contains(t, resp.Diagnostics.Errors()[0].Summary(), tt.expectError)
+				assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "description")
+			} else {
+				assert.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+
+				var archived types.Bool
+				resp.Diagnostics.Append(resp.State.GetAttribute(t.Context(), path.Root("archived"), &archived)...)
+				assert.Equal(t, tt.expectArchived, archived.ValueBool())
+			}
+
+			assert.Equal(t, tt.expectArchivedEdits, fake.editedArchived)
+			assert.Equal(t, tt.expectArchived, fake.archived)
+			assert.Equal(t, tt.expectDescription, fake.description)
+			for i, fields := range fake.editedFields {
+				// Archive state changes are never combined with other edits
+				if fake.editedArchived[i] != nil {
+					assert.Equal(t, []string{"archived"}, fields)
+				}
+			}
+		})
+	}
+}
+
+// fakeRenamableRepository is a fake GitHub API for a single repository that can be
+// renamed and transferred.
+type fakeRenamableRepository struct {
+	mu        sync.Mutex
+	owner     string
+	name      string
+	deleted   bool
+	renames   []string
+	transfers []map[string]interface{}
+}
+
+func (f *fakeRenamableRepository) repository() map[string]interface{} {
+	return map[string]interface{}{
+		"id":             42,
+		"node_id":        "R_42",
+		"name":           f.name,
+		"full_name":      f.owner + "/" + f.name,
+		"owner":          map[string]interface{}{"login": f.owner},
+		"default_branch": "main",
+	}
+}
+
+func (f *fakeRenamableRepository) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	repoPath := "/repos/" + f.owner + "/" + f.name
+	switch {
+	case f.deleted:
+		w.WriteHeader(http.StatusNotFound)
+	case r.URL.Path == "/repositories/42" && r.Method == http.MethodGet,
+		r.URL.Path == repoPath && r.Method == http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(f.repository())
+	case r.URL.Path == repoPath && r.Method == http.MethodPatch:
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if name, ok := body["name"].(string); ok {
+			f.renames = append(f.renames, name)
+			f.name = name
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(f.repository())
+	case r.URL.Path == repoPath+"/transfer" && r.Method == http.MethodPost:
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.transfers = append(f.transfers, body)
+		f.owner = body["new_owner"].(string)
+		if name, ok := body["new_name"].(string); ok {
+			f.name = name
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(f.repository())
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newRenamableRepositoryState(t *testing.T, rs *repositoryResource, id, name string) tfsdk.State {
+	t.Helper()
+
+	schemaResp := &resource.SchemaResponse{}
+	rs.Schema(t.Context(), resource.SchemaRequest{}, schemaResp)
+	objType := schemaResp.Schema.Type().TerraformType(t.Context())
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objType, nil)}
+	diags := state.SetAttribute(t.Context(), path.Root("id"), id)
+	diags.Append(state.SetAttribute(t.Context(), path.Root("repo_id"), int64(42))...)
+	diags.Append(state.SetAttribute(t.Context(), path.Root("name"), name)...)
+	diags.Append(state.SetAttribute(t.Context(), path.Root("owner"), "test-owner")...)
+	diags.Append(state.SetAttribute(t.Context(), path.Root("allow_merge_commit"), true)...)
+	assert.False(t, diags.HasError())
+	return state
+}
+
+func TestRepositoryResource_Read_Renamed(t *testing.T) {
+	tests := []struct {
+		name         string
+		stateID      string
+		remoteName   string
+		deleted      bool
+		expectRemove bool
+		expectName   string
+	}{
+		{
+			name:       "unchanged repository",
+			stateID:    "42",
+			remoteName: "test-repo",
+			expectName: "test-repo",
+		},
+		{
+			name:       "renamed outside of terraform",
+			stateID:    "42",
+			remoteName: "renamed-repo",
+			expectName: "renamed-repo",
+		},
+		{
+			name:       "name-based id is migrated",
+			stateID:    "test-repo",
+			remoteName: "test-repo",
+			expectName: "test-repo",
+		},
+		{
+			name:         "deleted repository",
+			stateID:      "42",
+			remoteName:   "test-repo",
+			deleted:      true,
+			expectRemove: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeRenamableRepository{owner: "test-owner", name: tt.remoteName, deleted: tt.deleted}
+			rs := &repositoryResource{
+				client: newTestGitHubClient(t, fake),
+				owner:  "test-owner",
+			}
+
+			state := newRenamableRepositoryState(t, rs, tt.stateID, "test-repo")
+			req := resource.ReadRequest{State: state}
+			resp := &resource.ReadResponse{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw.Copy()}}
+
+			rs.Read(t.Context(), req, resp)
+
+			assert.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+			if tt.expectRemove {
+				assert.True(t, resp.State.Raw.IsNull())
+				return
+			}
+
+			var id, name types.String
+			resp.Diagnostics.Append(resp.State.GetAttribute(t.Context(), path.Root("id"), &id)...)
+			resp.Diagnostics.Append(resp.State.GetAttribute(t.Context(), path.Root("name"), &name)...)
+			assert.Equal(t, "42", id.ValueString())
+			assert.Equal(t, tt.expectName, name.ValueString())
+		})
+	}
+}
+
+func TestRepositoryResource_Update_Rename(t *testing.T) {
+	fake := &fakeRenamableRepository{owner: "test-owner", name: "test-repo"}
+	rs := &repositoryResource{
+		client: newTestGitHubClient(t, fake),
+		owner:  "test-owner",
+	}
+
+	state := newRenamableRepositoryState(t, rs, "42", "test-repo")
+	plan := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw.Copy()}
+	diags := plan.SetAttribute(t.Context(), path.Root("name"), "renamed-repo")
+	assert.False(t, diags.HasError())
+
+	req := resource.UpdateRequest{Plan: plan, State: state}
+	resp := &resource.UpdateResponse{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw.Copy()}}
+
+	rs.Update(t.Context(), req, resp)
+
+	assert.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+	assert.Equal(t, []string{"renamed-repo"}, fake.renames)
+
+	var id, name types.String
+	resp.Diagnostics.Append(resp.State.GetAttribute(t.Context(), path.Root("id"), &id)...)
+	resp.Diagnostics.Append(resp.State.GetAttribute(t.Context(), path.Root("name"), &name)...)
+	assert.Equal(t, "42", id.ValueString())
+	assert.Equal(t, "renamed-repo", name.ValueString())
+}
+
+func TestRepositoryResource_Update_TransferAndRename(t *testing.T) {
+	interval := transferPollInterval
+	transferPollInterval = time.Millisecond
+	t.Cleanup(func() { transferPollInterval = interval })
+
+	fake := &fakeRenamableRepository{owner: "test-owner", name: "test-repo"}
+	rs := &repositoryResource{
+		client: newTestGitHubClient(t, fake),
+		owner:  "test-owner",
+	}
+
+	state := newRenamableRepositoryState(t, rs, "42", "test-repo")
+	plan := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw.Copy()}
+	diags := plan.SetAttribute(t.Context(), path.Root("name"), "renamed-repo")
+	diags.Append(plan.SetAttribute(t.Context(), path.Root("owner"), "new-owner")...)
+	assert.False(t, diags.HasError())
+
+	req := resource.UpdateRequest{Plan: plan, State: state}
+	resp := &resource.UpdateResponse{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw.Copy()}}
+
+	rs.Update(t.Context(), req, resp)
+
+	// The transfer renames the repository, so it is not renamed again
+	assert.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+	assert.Len(t, fake.transfers, 1)
+	assert.Equal(t, "new-owner", fake.transfers[0]["new_owner"])
+	assert.Equal(t, "renamed-repo", fake.transfers[0]["new_name"])
+	assert.Empty(t, fake.renames)
+
+	var owner, name types.String
+	resp.Diagnostics.Append(resp.State.GetAttribute(t.Context(), path.Root("owner"), &owner)...)
+	resp.Diagnostics.Append(resp.State.GetAttribute(t.Context(), path.Root("name"), &name)...)
+	assert.Equal(t, "new-owner", owner.ValueString())
+	assert.Equal(t, "renamed-repo", name.ValueString())
+}
+
+func TestRepositoryResource_ImportState(t *testing.T) {
+	tests := []struct {
+		name          string
+		importID      string
+		expectID      string
+		expectName    string
+		expectOwner   string
+		expectLookup  bool
+		errorContains string
+	}{
+		{
+			name:         "repository ID",
+			importID:     "id:42",
+			expectID:     "42",
+			expectName:   "test-repo",
+			expectOwner:  "test-owner",
+			expectLookup: true,
+		},
+		{
+			name:        "numeric repository name",
+			importID:    "2024",
+			expectID:    "2024",
+			expectName:  "2024",
+			expectOwner: "test-owner",
+		},
+		{
+			name:        "owner and numeric repository name",
+			importID:    "other-owner/42",
+			expectID:    "42",
+			expectName:  "42",
+			expectOwner: "other-owner",
+		},
+		{
+			name:          "invalid repository ID",
+			importID:      "id:test-repo",
+			errorContains: "Invalid repository ID",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeRenamableRepository{owner: "test-owner", name: "test-repo"}
+			var lookups []string
+			rs := &repositoryResource{
+				client: newTestGitHubClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					lookups = append(lookups, r.URL.Path)
+					fake.ServeHTTP(w, r)
+				})),
+				owner: "test-owner",
+			}
+
+			schemaResp := &resource.SchemaResponse{}
+			rs.Schema(t.Context(), resource.SchemaRequest{}, schemaResp)
+			objType := schemaResp.Schema.Type().TerraformType(t.Context())
+			resp := &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objType, nil)}}
+
+			rs.ImportState(t.Context(), resource.ImportStateRequest{ID: tt.importID}, resp)
+
+			if tt.errorContains != "" {
+				assert.True(t, resp.Diagnostics.HasError())
+				assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), tt.errorContains)
+				return
+			}
+			assert.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+			if tt.expectLookup {
+				assert.Equal(t, []string{"/repositories/42"}, lookups)
+			} else {
+				assert.Empty(t, lookups)
+			}
+
+			var id, name, owner types.String
+			resp.Diagnostics.Append(resp.State.GetAttribute(t.Context(), path.Root("id"), &id)...)
+			resp.Diagnostics.Append(resp.State.GetAttribute(t.Context(), path.Root("name"), &name)...)
+			resp.Diagnostics.Append(resp.State.GetAttribute(t.Context(), path.Root("owner"), &owner)...)
+			assert.Equal(t, tt.expectID, id.ValueString())
+			assert.Equal(t, tt.expectName, name.ValueString())
+			assert.Equal(t, tt.expectOwner, owner.ValueString())
+		})
+	}
+}
+
+// fakeBackupRepository is a fake GitHub API for a repository that can be backed up
+// and deleted. Empty repositories have no tarball.
+type fakeBackupRepository struct {
+	mu       sync.Mutex
+	empty    bool
+	requests []string
+}
+
+func (f *fakeBackupRepository) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+
+	switch {
+	case r.URL.Path == "/repos/test-owner/test-repo" && r.Method == http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 42, "name": "test-repo", "default_branch": "main"})
+	case r.URL.Path == "/repos/test-owner/test-repo" && r.Method == http.MethodDelete:
+		w.WriteHeader(http.StatusNoContent)
+	case r.URL.Path == "/repos/test-owner/test-repo/tarball/main" && !f.empty:
+		w.Header().Set("Location", "http://"+r.Host+"/archives/test-repo.tar.gz")
+		w.WriteHeader(http.StatusFound)
+	case r.URL.Path == "/archives/test-repo.tar.gz":
+		_, _ = w.Write([]byte("tarball"))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+	}
+}
+
+func TestRepositoryResource_BackupRepository(t *testing.T) {
+	t.Run("writes metadata and tarball", func(t *testing.T) {
+		fake := &fakeBackupRepository{}
+		r := &repositoryResource{client: newTestGitHubClient(t, fake)}
+		dir := filepath.Join(t.TempDir(), "backups")
+
+		var diags diag.Diagnostics
+		r.backupRepository(t.Context(), "test-owner", "test-repo", dir, &diags)
+		assert.False(t, diags.HasError(), "unexpected errors: %v", diags)
+
+		metadata, _ := filepath.Glob(filepath.Join(dir, "test-owner-test-repo-*.json"))
+		assert.Len(t, metadata, 1)
+		content, err := os.ReadFile(metadata[0])
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), `"default_branch": "main"`)
+
+		tarball, err := os.ReadFile(strings.TrimSuffix(metadata[0], ".json") + ".tar.gz")
+		assert.NoError(t, err)
+		assert.Equal(t, "tarball", string(tarball))
+	})
+
+	t.Run("empty repository", func(t *testing.T) {
+		fake := &fakeBackupRepository{empty: true}
+		r := &repositoryResource{client: newTestGitHubClient(t, fake)}
+		dir := t.TempDir()
+
+		var diags diag.Diagnostics
+		r.backupRepository(t.Context(), "test-owner", "test-repo", dir, &diags)
+		assert.False(t, diags.HasError(), "unexpected errors: %v", diags)
+		assert.Equal(t, "Repository tarball not available", diags.Warnings()[0].Summary())
+
+		metadata, _ := filepath.Glob(filepath.Join(dir, "test-owner-test-repo-*.json"))
+		assert.Len(t, metadata, 1)
+		tarballs, _ := filepath.Glob(filepath.Join(dir, "*.tar.gz"))
+		assert.Empty(t, tarballs)
+	})
+}
+
+func TestRepositoryResource_Delete(t *testing.T) {
+	// A regular file cannot be used as the backup directory
+	notADir := filepath.Join(t.TempDir(), "file")
+	assert.NoError(t, os.WriteFile(notADir, nil, 0o644))
+
+	tests := []struct {
+		name               string
+		deletionProtection bool
+		backupPath         string
+		expectError        string
+		expectDeleted      bool
+	}{
+		{
+			name:          "deletes the repository",
+			expectDeleted: true,
+		},
+		{
+			name:               "deletion protection",
+			deletionProtection: true,
+			expectError:        "Repository is protected from deletion",
+		},
+		{
+			name:          "backs up before deleting",
+			backupPath:    t.TempDir(),
+			expectDeleted: true,
+		},
+		{
+			name:        "backup fails",
+			backupPath:  notADir,
+			expectError: "Error backing up repository",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeBackupRepository{}
+			rs := &repositoryResource{client: newTestGitHubClient(t, fake), owner: "test-owner"}
+
+			state := newRenamableRepositoryState(t, rs, "42", "test-repo")
+			diags := state.SetAttribute(t.Context(), path.Root("deletion_protection"), tt.deletionProtection)
+			if tt.backupPath != "" {
+				diags.Append(state.SetAttribute(t.Context(), path.Root("backup_on_destroy_path"), tt.backupPath)...)
+			}
+			assert.False(t, diags.HasError())
+
+			resp := &resource.DeleteResponse{State: state}
+			rs.Delete(t.Context(), resource.DeleteRequest{State: state}, resp)
+
+			if tt.expectError != "" {
+				assert.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.expectError, resp.Diagnostics.Errors()[0].Summary())
+			} else {
+				assert.False(t, resp.Diagnostics.HasError(), "unexpected errors: %v", resp.Diagnostics)
+			}
+			assert.Equal(t, tt.expectDeleted, containsFold(fake.requests, "DELETE /repos/test-owner/test-repo"))
+		})
+	}
+}
+
+// fakeTransferredRepository is a fake GitHub API for a repository that moves to
+// new-owner/new-repo once it has been polled a number of times after its transfer.
+type fakeTransferredRepository struct {
+	mu             sync.Mutex
+	completeAfter  int
+	polls          int
+	transferred    bool
+	transferTarget map[string]interface{}
+}
+
+func (f *fakeTransferredRepository) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.URL.Path == "/repos/test-owner/test-repo/transfer" && r.Method == http.MethodPost:
+		_ = json.NewDecoder(r.Body).Decode(&f.transferTarget)
+		f.transferred = true
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"name": "test-repo"})
+	case r.URL.Path == "/repos/new-owner/new-repo" && r.Method == http.MethodGet:
+		f.polls++
+		if !f.transferred || f.completeAfter < 0 || f.polls < f.completeAfter {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"name":      "new-repo",
+			"full_name": "new-owner/new-repo",
+			"owner":     map[string]string{"login": "new-owner"},
+		})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+	}
+}
+
+func TestRepositoryResource_TransferRepository(t *testing.T) {
+	interval := transferPollInterval
+	transferPollInterval = time.Millisecond
+	t.Cleanup(func() { transferPollInterval = interval })
+
+	t.Run("waits for the transfer to complete", func(t *testing.T) {
+		fake := &fakeTransferredRepository{completeAfter: 3}
+		r := &repositoryResource{client: newTestGitHubClient(t, fake)}
+
+		var diags diag.Diagnostics
+		r.transferRepository(t.Context(), "test-owner", "test-repo", "new-owner", "new-repo", []int64{7}, &diags)
+		assert.False(t, diags.HasError(), "unexpected errors: %v", diags)
+		assert.Equal(t, 3, fake.polls)
+		assert.Equal(t, "new-owner", fake.transferTarget["new_owner"])
+		assert.Equal(t, "new-repo", fake.transferTarget["new_name"])
+		assert.Equal(t, []interface{}{float64(7)}, fake.transferTarget["team_ids"])
+	})
+
+	t.Run("stops waiting when cancelled", func(t *testing.T) {
+		transferPollInterval = time.Hour
+		t.Cleanup(func() { transferPollInterval = time.Millisecond })
+
+		fake := &fakeTransferredRepository{completeAfter: -1}
+		r := &repositoryResource{client: newTestGitHubClient(t, fake)}
+
+		ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+		defer cancel()
+
+		var diags diag.Diagnostics
+		r.transferRepository(ctx, "test-owner", "test-repo", "new-owner", "new-repo", nil, &diags)
+		assert.True(t, diags.HasError())
+		assert.Contains(t, diags.Errors()[0].Detail(), "Stopped waiting")
+		assert.Equal(t, 1, fake.polls)
+	})
+}
+
+// fakeDefaultBranchRepository is a fake GitHub API for the branches of a single repository.
+type fakeDefaultBranchRepository struct {
+	mu            sync.Mutex
+	defaultBranch string
+	branches      map[string]string
+	requests      []string
+}
+
+func (f *fakeDefaultBranchRepository) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.URL.Path == "/repos/test-owner/test-repo" && r.Method == http.MethodGet:
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"name": "test-repo", "default_branch": f.defaultBranch})
+	case r.URL.Path == "/repos/test-owner/test-repo" && r.Method == http.MethodPatch:
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.defaultBranch = body["default_branch"].(string)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"name": "test-repo"})
+	case strings.HasPrefix(r.URL.Path, "/repos/test-owner/test-repo/branches/") && strings.HasSuffix(r.URL.Path, "/rename"):
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		old := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/repos/test-owner/test-repo/branches/"), "/rename")
+		f.branches[body["new_name"]] = f.branches[old]
+		delete(f.branches, old)
+		if f.defaultBranch == old {
+			f.defaultBranch = body["new_name"]
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"name": body["new_name"]})
+	case strings.HasPrefix(r.URL.Path, "/repos/test-owner/test-repo/branches/"):
+		name := strings.TrimPrefix(r.URL.Path, "/repos/test-owner/test-repo/branches/")
+		if _, ok := f.branches[name]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Branch not found"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"name": name})
+	case strings.HasPrefix(r.URL.Path, "/repos/test-owner/test-repo/git/ref/heads/"):
+		name := strings.TrimPrefix(r.URL.Path, "/repos/test-owner/test-repo/git/ref/heads/")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ref": "refs/heads/" + name, "object": map[string]string{"sha": f.branches[name]}})
+	case r.URL.Path == "/repos/test-owner/test-repo/git/refs" && r.Method == http.MethodPost:
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.branches[strings.TrimPrefix(body["ref"], "refs/heads/")] = body["sha"]
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ref": body["ref"]})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+	}
+}
+
+func TestRepositoryResource_SetDefaultBranch(t *testing.T) {
+	tests := []struct {
+		name             string
+		branches         map[string]string
+		rename           bool
+		expectedBranches map[string]string
+	}{
+		{
+			name:             "creates missing branch",
+			branches:         map[string]string{"master": "abc123"},
+			expectedBranches: map[string]string{"master": "abc123", "main": "abc123"},
+		},
+		{
+			name:             "renames old default branch",
+			branches:         map[string]string{"master": "abc123"},
+			rename:           true,
+			expectedBranches: map[string]string{"main": "abc123"},
+		},
+		{
+			name:             "switches to existing branch",
+			branches:         map[string]string{"master": "abc123", "main": "def456"},
+			rename:           true,
+			expectedBranches: map[string]string{"master": "abc123", "main": "def456"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeDefaultBranchRepository{defaultBranch: "master", branches: tt.branches}
+			r := &repositoryResource{client: newTestGitHubClient(t, fake)}
+
+			var diags diag.Diagnostics
+			r.setDefaultBranch(t.Context(), "test-owner", "test-repo", "main", tt.rename, &diags)
+			assert.False(t, diags.HasError(), "unexpected errors: %v", diags)
+			assert.Equal(t, "main", fake.defaultBranch)
+			assert.Equal(t, tt.expectedBranches, fake.branches)
+		})
+	}
+
+	t.Run("already default", func(t *testing.T) {
+		fake := &fakeDefaultBranchRepository{defaultBranch: "main", branches: map[string]string{"main": "abc123"}}
+		r := &repositoryResource{client: newTestGitHubClient(t, fake)}
+
+		var diags diag.Diagnostics
+		r.setDefaultBranch(t.Context(), "test-owner", "test-repo", "main", false, &diags)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, []string{"GET /repos/test-owner/test-repo"}, fake.requests)
+	})
+}
+
 // Note: Tests for Create(), Read(), Update(), and Delete() methods that require GitHub API calls
 // should be implemented as acceptance tests with TF_ACC=1 environment variable set.
 // These unit tests verify the schema, metadata, and configuration validation